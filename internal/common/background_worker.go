@@ -22,3 +22,27 @@ func BackgroundWorker(ctx context.Context, delay time.Duration, logger *zerolog.
 		}
 	}
 }
+
+// AdaptiveWorker runs fn after the delay returned by delayFn, the delay is asked again after every run.
+func AdaptiveWorker(
+	ctx context.Context,
+	delayFn func(ctx context.Context) time.Duration,
+	logger *zerolog.Logger,
+	fn func(ctx context.Context) error,
+) {
+	t := time.NewTimer(delayFn(ctx))
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := fn(ctx); err != nil {
+				logger.Err(err).Msg("background worker task run")
+			}
+
+			t.Reset(delayFn(ctx))
+		}
+	}
+}
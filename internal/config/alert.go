@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+type alert struct {
+	WebhookTimeout     time.Duration `envconfig:"ALERT_WEBHOOK_TIMEOUT" default:"5s"`
+	WebhookMaxAttempts int           `envconfig:"ALERT_WEBHOOK_MAX_ATTEMPTS" default:"5"`
+	WebhookBackoff     time.Duration `envconfig:"ALERT_WEBHOOK_BACKOFF" default:"1s"`
+}
+
+func (c Config) AlertWebhookTimeout() time.Duration {
+	return c.alert.WebhookTimeout
+}
+
+func (c Config) AlertWebhookMaxAttempts() int {
+	return c.alert.WebhookMaxAttempts
+}
+
+func (c Config) AlertWebhookBackoff() time.Duration {
+	return c.alert.WebhookBackoff
+}
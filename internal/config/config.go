@@ -10,10 +10,13 @@ import (
 )
 
 type Config struct {
-	app          app
-	postgres     postgres
-	http         http
-	fastForexAPI fastForexAPI
+	app              app
+	postgres         postgres
+	http             http
+	fastForexAPI     fastForexAPI
+	cryptoCompareAPI cryptoCompareAPI
+	currency         currency
+	alert            alert
 }
 
 type app struct {
@@ -23,6 +26,8 @@ type app struct {
 
 type http struct {
 	Port int32 `envconfig:"HTTP_PORT"`
+	// AdminToken guards admin endpoints, they are disabled when it is empty
+	AdminToken string `envconfig:"HTTP_ADMIN_TOKEN"`
 }
 
 func Load() (Config, error) {
@@ -46,6 +51,18 @@ func Load() (Config, error) {
 		return Config{}, errors.Wrap(err, "parse fast forex api env")
 	}
 
+	if err := envconfig.Process("", &cnf.cryptoCompareAPI); err != nil {
+		return Config{}, errors.Wrap(err, "parse crypto compare api env")
+	}
+
+	if err := envconfig.Process("", &cnf.currency); err != nil {
+		return Config{}, errors.Wrap(err, "parse currency env")
+	}
+
+	if err := envconfig.Process("", &cnf.alert); err != nil {
+		return Config{}, errors.Wrap(err, "parse alert env")
+	}
+
 	return cnf, nil
 }
 
@@ -60,3 +77,7 @@ func (c Config) LogLevel() zerolog.Level {
 func (c Config) HTTPAddr() string {
 	return fmt.Sprintf(":%d", c.http.Port)
 }
+
+func (c Config) HTTPAdminToken() string {
+	return c.http.AdminToken
+}
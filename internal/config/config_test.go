@@ -15,19 +15,55 @@ func TestConfig(t *testing.T) {
 		"APP_NAME":  "app",
 		"LOG_LEVEL": "3",
 
-		"HTTP_PORT": "9090",
+		"HTTP_PORT":        "9090",
+		"HTTP_ADMIN_TOKEN": "admin-token",
 
-		"PG_HOST":     "postgres",
-		"PG_DATABASE": "pg-db",
-		"PG_USER":     "pg-user",
-		"PG_PASSWORD": "pg-password",
-		"PG_PORT":     "5432",
-		"PG_TIMEOUT":  "5s",
+		"PG_HOST":           "postgres",
+		"PG_DATABASE":       "pg-db",
+		"PG_USER":           "pg-user",
+		"PG_PASSWORD":       "pg-password",
+		"PG_PORT":           "5432",
+		"PG_TIMEOUT":        "5s",
+		"PG_IMPORT_TIMEOUT": "2m",
 
 		"FAST_FOREX_API_HOST":     "fast-forex",
 		"FAST_FOREX_API_KEY":      "fast-forex-api-key",
 		"FAST_FOREX_TASK_DELAY":   "2m",
 		"FAST_FOREX_HTTP_TIMEOUT": "3s",
+
+		"FAST_FOREX_MAX_ATTEMPTS":      "4",
+		"FAST_FOREX_RETRY_BACKOFF":     "100ms",
+		"FAST_FOREX_RETRY_MAX_BACKOFF": "2s",
+		"FAST_FOREX_BREAKER_THRESHOLD": "3",
+		"FAST_FOREX_BREAKER_COOLDOWN":  "30s",
+
+		"FAST_FOREX_BUDGET":                   "100000",
+		"FAST_FOREX_BUDGET_PERIOD":            "day",
+		"FAST_FOREX_BUDGET_MAX_DELAY":         "2h",
+		"FAST_FOREX_USAGE_RECONCILE_INTERVAL": "30m",
+
+		"CRYPTO_COMPARE_API_HOST":     "crypto-compare",
+		"CRYPTO_COMPARE_API_KEY":      "crypto-compare-api-key",
+		"CRYPTO_COMPARE_HTTP_TIMEOUT": "2s",
+
+		"CURRENCY_PIVOT":         "USD",
+		"CURRENCY_STALE_MAX_AGE": "10m",
+		"CURRENCY_QUOTE_TTL":     "30s",
+
+		"CURRENCY_HISTORY_MAX_GAP": "5m",
+		"CURRENCY_PROVIDERS":       "fastforex,cryptocompare",
+		"CURRENCY_UPDATE_WORKERS":  "8",
+		"CURRENCY_CYCLE_TIMEOUT":   "20s",
+
+		"CURRENCY_AGGREGATE":         "true",
+		"CURRENCY_OUTLIER_TOLERANCE": "0.5",
+
+		"CURRENCY_PRICING_REFRESH_INTERVAL": "2m",
+		"CURRENCY_PAIRING_RULES":            "crypto:fiat|stablecoin,metal:fiat",
+
+		"ALERT_WEBHOOK_TIMEOUT":      "5s",
+		"ALERT_WEBHOOK_MAX_ATTEMPTS": "5",
+		"ALERT_WEBHOOK_BACKOFF":      "1s",
 	}
 
 	for k, v := range env {
@@ -43,14 +79,42 @@ func TestConfig(t *testing.T) {
 	assert.Equal(t, conf.AppName(), "app")
 	assert.Equal(t, conf.LogLevel(), zerolog.Level(3))
 	assert.Equal(t, conf.HTTPAddr(), ":9090")
+	assert.Equal(t, conf.HTTPAdminToken(), "admin-token")
 	assert.Equal(t, conf.PgHost(), "postgres")
 	assert.Equal(t, conf.PgDB(), "pg-db")
 	assert.Equal(t, conf.PgUser(), "pg-user")
 	assert.Equal(t, conf.PgPassword(), "pg-password")
 	assert.Equal(t, conf.PgPort(), 5432)
 	assert.Equal(t, conf.PgTimeout(), time.Second*5)
+	assert.Equal(t, conf.PgImportTimeout(), 2*time.Minute)
 	assert.Equal(t, conf.FastForexAPIHost(), "fast-forex")
 	assert.Equal(t, conf.FastForexAPIKey(), "fast-forex-api-key")
 	assert.Equal(t, conf.FastForexBackgroundTaskDelay(), 2*time.Minute)
 	assert.Equal(t, conf.FastForexHTTPTimeout(), 3*time.Second)
+	assert.Equal(t, conf.FastForexMaxAttempts(), 4)
+	assert.Equal(t, conf.FastForexRetryBackoff(), 100*time.Millisecond)
+	assert.Equal(t, conf.FastForexRetryMaxBackoff(), 2*time.Second)
+	assert.Equal(t, conf.FastForexBreakerThreshold(), 3)
+	assert.Equal(t, conf.FastForexBreakerCooldown(), 30*time.Second)
+	assert.Equal(t, conf.FastForexBudget(), int64(100000))
+	assert.Equal(t, conf.FastForexBudgetPeriod(), "day")
+	assert.Equal(t, conf.FastForexBudgetMaxDelay(), 2*time.Hour)
+	assert.Equal(t, conf.FastForexUsageReconcileInterval(), 30*time.Minute)
+	assert.Equal(t, conf.CryptoCompareAPIHost(), "crypto-compare")
+	assert.Equal(t, conf.CryptoCompareAPIKey(), "crypto-compare-api-key")
+	assert.Equal(t, conf.CryptoCompareHTTPTimeout(), 2*time.Second)
+	assert.Equal(t, conf.CurrencyPivot(), "USD")
+	assert.Equal(t, conf.CurrencyStaleMaxAge(), 10*time.Minute)
+	assert.Equal(t, conf.CurrencyQuoteTTL(), 30*time.Second)
+	assert.Equal(t, conf.CurrencyHistoryMaxGap(), 5*time.Minute)
+	assert.Equal(t, conf.CurrencyProviders(), []string{"fastforex", "cryptocompare"})
+	assert.Equal(t, conf.CurrencyUpdateWorkers(), 8)
+	assert.Equal(t, conf.CurrencyCycleTimeout(), 20*time.Second)
+	assert.Equal(t, conf.CurrencyAggregate(), true)
+	assert.Equal(t, conf.CurrencyOutlierTolerance(), 0.5)
+	assert.Equal(t, conf.CurrencyPricingRefreshInterval(), 2*time.Minute)
+	assert.Equal(t, conf.CurrencyPairingRules(), map[string]string{"crypto": "fiat|stablecoin", "metal": "fiat"})
+	assert.Equal(t, conf.AlertWebhookTimeout(), 5*time.Second)
+	assert.Equal(t, conf.AlertWebhookMaxAttempts(), 5)
+	assert.Equal(t, conf.AlertWebhookBackoff(), time.Second)
 }
@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+type cryptoCompareAPI struct {
+	Host        string        `envconfig:"CRYPTO_COMPARE_API_HOST"`
+	APIKey      string        `envconfig:"CRYPTO_COMPARE_API_KEY"`
+	HTTPTimeout time.Duration `envconfig:"CRYPTO_COMPARE_HTTP_TIMEOUT"`
+}
+
+func (c Config) CryptoCompareAPIHost() string {
+	return c.cryptoCompareAPI.Host
+}
+
+func (c Config) CryptoCompareAPIKey() string {
+	return c.cryptoCompareAPI.APIKey
+}
+
+func (c Config) CryptoCompareHTTPTimeout() time.Duration {
+	return c.cryptoCompareAPI.HTTPTimeout
+}
@@ -0,0 +1,69 @@
+package config
+
+import "time"
+
+type currency struct {
+	PivotCode   string        `envconfig:"CURRENCY_PIVOT" default:"USD"`
+	StaleMaxAge time.Duration `envconfig:"CURRENCY_STALE_MAX_AGE" default:"10m"`
+	QuoteTTL    time.Duration `envconfig:"CURRENCY_QUOTE_TTL" default:"30s"`
+	// HistoryMaxGap is how old a persisted rate may be to serve a historical conversion
+	HistoryMaxGap time.Duration `envconfig:"CURRENCY_HISTORY_MAX_GAP" default:"5m"`
+	// Providers are the names of courses providers in priority order
+	Providers []string `envconfig:"CURRENCY_PROVIDERS" default:"fastforex"`
+	// UpdateWorkers is how many batches of pairs are fetched at once
+	UpdateWorkers int `envconfig:"CURRENCY_UPDATE_WORKERS" default:"4"`
+	// CycleTimeout limits fetching of one update courses cycle
+	CycleTimeout time.Duration `envconfig:"CURRENCY_CYCLE_TIMEOUT" default:"30s"`
+	// Aggregate asks every provider for a pair and publishes the median of their courses
+	Aggregate bool `envconfig:"CURRENCY_AGGREGATE" default:"false"`
+	// OutlierTolerance is how many percent a provider course may deviate from the median
+	OutlierTolerance float64 `envconfig:"CURRENCY_OUTLIER_TOLERANCE" default:"1"`
+	// PricingRefreshInterval is how often the pricing rules cached in memory are reloaded
+	PricingRefreshInterval time.Duration `envconfig:"CURRENCY_PRICING_REFRESH_INTERVAL" default:"1m"`
+	// PairingRules map a currency type to the types it is paired with, e.g. crypto:fiat|stablecoin
+	PairingRules map[string]string `envconfig:"CURRENCY_PAIRING_RULES" default:"crypto:fiat|stablecoin,stablecoin:fiat,metal:fiat,index:fiat"` //nolint:lll
+}
+
+func (c Config) CurrencyPivot() string {
+	return c.currency.PivotCode
+}
+
+func (c Config) CurrencyStaleMaxAge() time.Duration {
+	return c.currency.StaleMaxAge
+}
+
+func (c Config) CurrencyQuoteTTL() time.Duration {
+	return c.currency.QuoteTTL
+}
+
+func (c Config) CurrencyHistoryMaxGap() time.Duration {
+	return c.currency.HistoryMaxGap
+}
+
+func (c Config) CurrencyProviders() []string {
+	return c.currency.Providers
+}
+
+func (c Config) CurrencyUpdateWorkers() int {
+	return c.currency.UpdateWorkers
+}
+
+func (c Config) CurrencyCycleTimeout() time.Duration {
+	return c.currency.CycleTimeout
+}
+
+func (c Config) CurrencyAggregate() bool {
+	return c.currency.Aggregate
+}
+
+func (c Config) CurrencyOutlierTolerance() float64 {
+	return c.currency.OutlierTolerance
+}
+
+func (c Config) CurrencyPricingRefreshInterval() time.Duration {
+	return c.currency.PricingRefreshInterval
+}
+
+func (c Config) CurrencyPairingRules() map[string]string {
+	return c.currency.PairingRules
+}
@@ -7,6 +7,20 @@ type fastForexAPI struct {
 	APIKey              string        `envconfig:"FAST_FOREX_API_KEY"`
 	BackgroundTaskDelay time.Duration `envconfig:"FAST_FOREX_TASK_DELAY"`
 	HTTPTimeout         time.Duration `envconfig:"FAST_FOREX_HTTP_TIMEOUT"`
+	// MaxAttempts is how many times a request failed with a transient error is sent
+	MaxAttempts     int           `envconfig:"FAST_FOREX_MAX_ATTEMPTS" default:"3"`
+	RetryBackoff    time.Duration `envconfig:"FAST_FOREX_RETRY_BACKOFF" default:"200ms"`
+	RetryMaxBackoff time.Duration `envconfig:"FAST_FOREX_RETRY_MAX_BACKOFF" default:"5s"`
+	// BreakerThreshold is how many failed requests in a row open the circuit breaker
+	BreakerThreshold int           `envconfig:"FAST_FOREX_BREAKER_THRESHOLD" default:"5"`
+	BreakerCooldown  time.Duration `envconfig:"FAST_FOREX_BREAKER_COOLDOWN" default:"1m"`
+	// Budget is how many calls the api key may make in the budget period, zero disables budgeting
+	Budget       int64  `envconfig:"FAST_FOREX_BUDGET" default:"0"`
+	BudgetPeriod string `envconfig:"FAST_FOREX_BUDGET_PERIOD" default:"month"`
+	// BudgetMaxDelay caps the refresh interval stretched by the budget
+	BudgetMaxDelay time.Duration `envconfig:"FAST_FOREX_BUDGET_MAX_DELAY" default:"1h"`
+	// UsageReconcileInterval is how often the counted calls are replaced with the provider figures
+	UsageReconcileInterval time.Duration `envconfig:"FAST_FOREX_USAGE_RECONCILE_INTERVAL" default:"1h"`
 }
 
 func (c Config) FastForexAPIHost() string {
@@ -24,3 +38,39 @@ func (c Config) FastForexBackgroundTaskDelay() time.Duration {
 func (c Config) FastForexHTTPTimeout() time.Duration {
 	return c.fastForexAPI.HTTPTimeout
 }
+
+func (c Config) FastForexMaxAttempts() int {
+	return c.fastForexAPI.MaxAttempts
+}
+
+func (c Config) FastForexRetryBackoff() time.Duration {
+	return c.fastForexAPI.RetryBackoff
+}
+
+func (c Config) FastForexRetryMaxBackoff() time.Duration {
+	return c.fastForexAPI.RetryMaxBackoff
+}
+
+func (c Config) FastForexBreakerThreshold() int {
+	return c.fastForexAPI.BreakerThreshold
+}
+
+func (c Config) FastForexBreakerCooldown() time.Duration {
+	return c.fastForexAPI.BreakerCooldown
+}
+
+func (c Config) FastForexBudget() int64 {
+	return c.fastForexAPI.Budget
+}
+
+func (c Config) FastForexBudgetPeriod() string {
+	return c.fastForexAPI.BudgetPeriod
+}
+
+func (c Config) FastForexBudgetMaxDelay() time.Duration {
+	return c.fastForexAPI.BudgetMaxDelay
+}
+
+func (c Config) FastForexUsageReconcileInterval() time.Duration {
+	return c.fastForexAPI.UsageReconcileInterval
+}
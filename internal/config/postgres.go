@@ -9,6 +9,8 @@ type postgres struct {
 	User     string        `envconfig:"PG_USER"`
 	Password string        `envconfig:"PG_PASSWORD"`
 	Timeout  time.Duration `envconfig:"PG_TIMEOUT"`
+	// ImportTimeout limits the currencies import, which writes the whole catalogue in one transaction
+	ImportTimeout time.Duration `envconfig:"PG_IMPORT_TIMEOUT" default:"1m"`
 }
 
 func (c Config) PgHost() string {
@@ -34,3 +36,7 @@ func (c Config) PgPort() int {
 func (c Config) PgTimeout() time.Duration {
 	return c.postgres.Timeout
 }
+
+func (c Config) PgImportTimeout() time.Duration {
+	return c.postgres.ImportTimeout
+}
@@ -0,0 +1,379 @@
+package currency
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	alertentity "github.com/veleton777/test_work_blum/internal/currency/v1/alert/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+)
+
+const alertSecretSize = 32
+
+type AlertSvc struct {
+	alertRepo    AlertRepo
+	ratesRepo    RatesRepo
+	currencyRepo Repo
+	pairRepo     PairRepo
+	sender       WebhookSender
+	opts         AlertOptions
+
+	mu        sync.Mutex
+	lastRates map[string]decimal.Decimal
+
+	wg *sync.WaitGroup
+	// stopMu orders closing stop with adding deliveries to wg
+	stopMu   sync.Mutex
+	stopOnce sync.Once
+	stop     chan struct{}
+	l        *zerolog.Logger
+}
+
+type AlertOptions struct {
+	// MaxAttempts is how many times a webhook is sent before the delivery is given up
+	MaxAttempts int
+	// Backoff is the delay before the second attempt, it doubles after every failed attempt
+	Backoff time.Duration
+	// PivotCode is the currency every other one is fetched to and from
+	PivotCode string
+	// PairingRules decide which pairs of currency types are fetched, any pair may be when nil
+	PairingRules entity.PairingRules
+}
+
+func NewAlertSvc(
+	alertRepo AlertRepo,
+	ratesRepo RatesRepo,
+	currencyRepo Repo,
+	pairRepo PairRepo,
+	sender WebhookSender,
+	opts AlertOptions,
+	l *zerolog.Logger,
+) *AlertSvc {
+	return &AlertSvc{
+		alertRepo:    alertRepo,
+		ratesRepo:    ratesRepo,
+		currencyRepo: currencyRepo,
+		pairRepo:     pairRepo,
+		sender:       sender,
+		opts:         opts,
+		lastRates:    make(map[string]decimal.Decimal),
+		wg:           &sync.WaitGroup{},
+		stop:         make(chan struct{}),
+		l:            l,
+	}
+}
+
+func (s *AlertSvc) CreateAlert(ctx context.Context, req dto.CreateAlert) (dto.Alert, error) {
+	secret, err := newAlertSecret()
+	if err != nil {
+		return dto.Alert{}, errors.Wrap(err, "generate alert secret")
+	}
+
+	alert := alertentity.Alert{
+		ID:            uuid.New(),
+		CodeFrom:      req.From,
+		CodeTo:        req.To,
+		Kind:          alertentity.Kind(req.Kind),
+		Threshold:     req.Threshold,
+		ChangePercent: req.ChangePercent,
+		Window:        req.Window,
+		WebhookURL:    req.URL,
+		Secret:        secret,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	if err = alert.Validate(); err != nil {
+		return dto.Alert{}, err
+	}
+
+	if err = s.checkFetched(ctx, alert.CodeFrom, alert.CodeTo); err != nil {
+		return dto.Alert{}, err
+	}
+
+	if err = s.sender.Validate(ctx, alert.WebhookURL); err != nil {
+		return dto.Alert{}, errors.Wrap(alertentity.ErrInvalidWebhookURL, err.Error())
+	}
+
+	if err = s.alertRepo.CreateAlert(ctx, alert); err != nil {
+		return dto.Alert{}, errors.Wrap(err, "save alert to storage")
+	}
+
+	res := alertToDTO(alert)
+	res.Secret = alert.Secret
+
+	return res, nil
+}
+
+// checkFetched rejects pairs which are not fetched directly. Alerts are evaluated
+// on fetched courses only, since synthetic ones have no persisted history to compare with.
+func (s *AlertSvc) checkFetched(ctx context.Context, codeFrom, codeTo string) error {
+	currencies, err := s.currencyRepo.GetCurrencies(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get currencies from storage")
+	}
+
+	registered, err := s.pairRepo.GetPairs(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get pairs from storage")
+	}
+
+	pairs, _ := directPairs(currencies, registered, s.opts.PivotCode, s.opts.PairingRules)
+	for _, p := range pairs {
+		if p.from.Code == codeFrom && p.to.Code == codeTo {
+			return nil
+		}
+	}
+
+	return alertentity.ErrAlertPairNotFetched
+}
+
+func (s *AlertSvc) GetAlerts(ctx context.Context) ([]dto.Alert, error) {
+	alerts, err := s.alertRepo.GetAlerts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get alerts from storage")
+	}
+
+	res := make([]dto.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		res = append(res, alertToDTO(a))
+	}
+
+	return res, nil
+}
+
+func (s *AlertSvc) DeleteAlert(ctx context.Context, id uuid.UUID) error {
+	if err := s.alertRepo.DeleteAlert(ctx, id); err != nil {
+		return errors.Wrap(err, "delete alert from storage")
+	}
+
+	return nil
+}
+
+func (s *AlertSvc) GetDeliveries(ctx context.Context, alertID uuid.UUID) ([]dto.AlertDelivery, error) {
+	deliveries, err := s.alertRepo.GetDeliveries(ctx, alertID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get deliveries from storage")
+	}
+
+	res := make([]dto.AlertDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		res = append(res, dto.AlertDelivery{
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Error:      d.Error,
+			CreatedAt:  d.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+// Evaluate checks alerts of the pair against the new course and sends webhooks
+// of the triggered ones in background. Cross alerts compare the course with the
+// previous one seen by the service, change alerts compare it with the persisted
+// rate at the start of the window and fire at most once per window.
+func (s *AlertSvc) Evaluate(ctx context.Context, tick dto.RateTick) {
+	prev, hasPrev := s.swapLastRate(tick)
+
+	alerts, err := s.alertRepo.GetAlertsByPair(ctx, tick.From, tick.To)
+	if err != nil {
+		s.l.Err(err).Msgf("get alerts: from %s to %s", tick.From, tick.To)
+
+		return
+	}
+
+	for _, alert := range alerts {
+		base, ok := s.triggeredBase(ctx, alert, tick, prev, hasPrev)
+		if !ok {
+			continue
+		}
+
+		if err = s.alertRepo.MarkTriggered(ctx, alert.ID, tick.At); err != nil {
+			s.l.Err(err).Msgf("mark alert %s triggered", alert.ID)
+
+			continue
+		}
+
+		body, err := json.Marshal(dto.AlertEvent{
+			AlertID:       alert.ID,
+			Kind:          string(alert.Kind),
+			From:          tick.From,
+			To:            tick.To,
+			Rate:          tick.Rate,
+			BaseRate:      base,
+			Threshold:     alert.Threshold,
+			ChangePercent: alert.ChangePercent,
+			At:            tick.At,
+		})
+		if err != nil {
+			s.l.Err(err).Msgf("marshal alert %s event", alert.ID)
+
+			continue
+		}
+
+		if !s.startDelivery() {
+			s.l.Warn().Msgf("alert %s is not delivered: service is stopped", alert.ID)
+
+			continue
+		}
+
+		go s.deliver(ctx, alert, body)
+	}
+}
+
+// Stop cancels pending retries and waits for webhooks being sent, no webhooks
+// are sent after it. It is safe to call Stop more than once.
+func (s *AlertSvc) Stop() {
+	s.stopOnce.Do(func() {
+		s.stopMu.Lock()
+		close(s.stop)
+		s.stopMu.Unlock()
+	})
+
+	s.wg.Wait()
+}
+
+// startDelivery adds the delivery to the wait group unless the service is stopped.
+func (s *AlertSvc) startDelivery() bool {
+	s.stopMu.Lock()
+	defer s.stopMu.Unlock()
+
+	select {
+	case <-s.stop:
+		return false
+	default:
+	}
+
+	s.wg.Add(1)
+
+	return true
+}
+
+// triggeredBase returns the rate the new course is compared with when the alert fires.
+func (s *AlertSvc) triggeredBase(
+	ctx context.Context,
+	alert alertentity.Alert,
+	tick dto.RateTick,
+	prev decimal.Decimal,
+	hasPrev bool,
+) (decimal.Decimal, bool) {
+	switch alert.Kind {
+	case alertentity.KindCross:
+		return prev, hasPrev && alert.IsCrossed(prev, tick.Rate)
+	case alertentity.KindChange:
+		if alert.InCooldown(tick.At) {
+			return decimal.Decimal{}, false
+		}
+
+		base, err := s.ratesRepo.GetRateAt(ctx, tick.From, tick.To, tick.At.Add(-alert.Window))
+		if err != nil {
+			if !errors.Is(err, entity.ErrEntityNotFound) {
+				s.l.Err(err).Msgf("get rate at window start: from %s to %s", tick.From, tick.To)
+			}
+
+			return decimal.Decimal{}, false
+		}
+
+		return base.Rate, alert.IsChanged(base.Rate, tick.Rate)
+	}
+
+	return decimal.Decimal{}, false
+}
+
+func (s *AlertSvc) swapLastRate(tick dto.RateTick) (decimal.Decimal, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := pairKey(tick.From, tick.To)
+
+	prev, ok := s.lastRates[key]
+	s.lastRates[key] = tick.Rate
+
+	return prev, ok
+}
+
+// deliver sends the webhook until it is accepted or attempts are over and logs
+// every attempt. Backoff is interrupted when ctx is done or the service is stopped.
+func (s *AlertSvc) deliver(ctx context.Context, alert alertentity.Alert, body []byte) {
+	defer s.wg.Done()
+
+	sendCtx := context.WithoutCancel(ctx)
+	backoff := s.opts.Backoff
+
+	for attempt := 1; ; attempt++ {
+		code, err := s.sender.Send(sendCtx, alert.WebhookURL, alert.Secret, body)
+
+		delivery := alertentity.Delivery{
+			ID:         uuid.New(),
+			AlertID:    alert.ID,
+			Attempt:    attempt,
+			StatusCode: code,
+			CreatedAt:  time.Now().UTC(),
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+
+		if saveErr := s.alertRepo.SaveDelivery(sendCtx, delivery); saveErr != nil {
+			s.l.Err(saveErr).Msgf("save alert %s delivery", alert.ID)
+		}
+
+		if err == nil {
+			return
+		}
+
+		if attempt >= s.opts.MaxAttempts {
+			s.l.Err(err).Msgf("deliver alert %s: give up after %d attempts", alert.ID, attempt)
+
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stop:
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+func newAlertSecret() (string, error) {
+	b := make([]byte, alertSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random bytes")
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func alertToDTO(a alertentity.Alert) dto.Alert {
+	res := dto.Alert{
+		ID:              a.ID,
+		From:            a.CodeFrom,
+		To:              a.CodeTo,
+		Kind:            string(a.Kind),
+		Threshold:       a.Threshold,
+		ChangePercent:   a.ChangePercent,
+		URL:             a.WebhookURL,
+		LastTriggeredAt: a.LastTriggeredAt,
+		CreatedAt:       a.CreatedAt,
+	}
+
+	if a.Window > 0 {
+		res.Window = a.Window.String()
+	}
+
+	return res
+}
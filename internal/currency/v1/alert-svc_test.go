@@ -0,0 +1,411 @@
+package currency_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	alertentity "github.com/veleton777/test_work_blum/internal/currency/v1/alert/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	pairentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	rateentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"testing"
+	"time"
+)
+
+type AlertServiceTestSuite struct {
+	suite.Suite
+	svc           *currency.AlertSvc
+	mockAlertRepo *mocks.AlertRepo
+	mockRatesRepo *mocks.RatesRepo
+	mockCurrRepo  *mocks.Repo
+	mockPairRepo  *mocks.PairRepo
+	mockSender    *mocks.WebhookSender
+
+	buf *bytes.Buffer
+}
+
+func (s *AlertServiceTestSuite) SetupTest() {
+	s.buf = &bytes.Buffer{}
+	l := zerolog.New(s.buf)
+
+	s.mockAlertRepo = mocks.NewAlertRepo(s.T())
+	s.mockRatesRepo = mocks.NewRatesRepo(s.T())
+	s.mockCurrRepo = mocks.NewRepo(s.T())
+	s.mockPairRepo = mocks.NewPairRepo(s.T())
+	s.mockSender = mocks.NewWebhookSender(s.T())
+	s.svc = currency.NewAlertSvc(
+		s.mockAlertRepo,
+		s.mockRatesRepo,
+		s.mockCurrRepo,
+		s.mockPairRepo,
+		s.mockSender,
+		currency.AlertOptions{MaxAttempts: 3, Backoff: time.Millisecond, PivotCode: "USD"},
+		&l,
+	)
+}
+
+func TestAlertServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AlertServiceTestSuite))
+}
+
+func (s *AlertServiceTestSuite) TestCreateAlert_NoErr() {
+	ctx := context.Background()
+
+	s.mockFetchedPairs(ctx)
+
+	s.mockSender.On("Validate", ctx, "https://example.com/hook").Return(nil).Once()
+
+	s.mockAlertRepo.On("CreateAlert", ctx, mock.MatchedBy(func(a alertentity.Alert) bool {
+		return a.CodeFrom == "BTC" && a.CodeTo == "USD" && a.Kind == alertentity.KindChange &&
+			a.Window == time.Hour && len(a.Secret) == 64
+	})).Return(nil).Once()
+
+	res, err := s.svc.CreateAlert(ctx, dto.CreateAlert{
+		From:          "BTC",
+		To:            "USD",
+		Kind:          "change",
+		ChangePercent: decimal.NewFromInt(5),
+		Window:        time.Hour,
+		URL:           "https://example.com/hook",
+	})
+	require.NoError(s.T(), err)
+
+	require.NotEqual(s.T(), uuid.Nil, res.ID)
+	require.Equal(s.T(), "1h0m0s", res.Window)
+	require.Len(s.T(), res.Secret, 64)
+}
+
+func (s *AlertServiceTestSuite) TestCreateAlert_InvalidAlert() {
+	ctx := context.Background()
+
+	_, err := s.svc.CreateAlert(ctx, dto.CreateAlert{
+		From: "BTC",
+		To:   "USD",
+		Kind: "cross",
+		URL:  "https://example.com/hook",
+	})
+	require.ErrorIs(s.T(), err, alertentity.ErrInvalidAlertValue)
+
+	_, err = s.svc.CreateAlert(ctx, dto.CreateAlert{
+		From:          "BTC",
+		To:            "USD",
+		Kind:          "change",
+		ChangePercent: decimal.NewFromInt(5),
+		URL:           "https://example.com/hook",
+	})
+	require.ErrorIs(s.T(), err, alertentity.ErrInvalidAlertValue)
+}
+
+func (s *AlertServiceTestSuite) TestCreateAlert_InvalidWebhookURL() {
+	ctx := context.Background()
+
+	s.mockFetchedPairs(ctx)
+
+	s.mockSender.On("Validate", ctx, "http://169.254.169.254/latest").
+		Return(errors.New("webhook address is not public")).Once()
+
+	_, err := s.svc.CreateAlert(ctx, dto.CreateAlert{
+		From:      "BTC",
+		To:        "USD",
+		Kind:      "cross",
+		Threshold: decimal.NewFromInt(70000),
+		URL:       "http://169.254.169.254/latest",
+	})
+	require.ErrorIs(s.T(), err, alertentity.ErrInvalidWebhookURL)
+	require.ErrorContains(s.T(), err, "webhook address is not public")
+
+	s.mockAlertRepo.AssertNotCalled(s.T(), "CreateAlert", mock.Anything, mock.Anything)
+}
+
+func (s *AlertServiceTestSuite) TestCreateAlert_PairNotFetched() {
+	ctx := context.Background()
+
+	s.mockFetchedPairs(ctx)
+
+	// BTC/ETH is not registered, so it is derived from the legs and not fetched
+	_, err := s.svc.CreateAlert(ctx, dto.CreateAlert{
+		From:      "BTC",
+		To:        "ETH",
+		Kind:      "cross",
+		Threshold: decimal.NewFromInt(20),
+		URL:       "https://example.com/hook",
+	})
+	require.ErrorIs(s.T(), err, alertentity.ErrAlertPairNotFetched)
+
+	s.mockSender.AssertNotCalled(s.T(), "Validate", mock.Anything, mock.Anything)
+	s.mockAlertRepo.AssertNotCalled(s.T(), "CreateAlert", mock.Anything, mock.Anything)
+}
+
+// mockFetchedPairs sets up USD as the pivot with BTC and ETH fetched to and from it.
+func (s *AlertServiceTestSuite) mockFetchedPairs(ctx context.Context) {
+	s.mockCurrRepo.On("GetCurrencies", ctx).Return(entity.Currencies{
+		{ID: uuid.New(), Name: "USD", Code: "USD", Type: entity.TypeFiat, IsAvailable: true},
+		{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true},
+		{ID: uuid.New(), Name: "ETH", Code: "ETH", Type: entity.TypeCrypto, IsAvailable: true},
+	}, nil).Once()
+	s.mockPairRepo.On("GetPairs", ctx).Return(pairentity.Pairs{}, nil).Once()
+}
+
+func (s *AlertServiceTestSuite) TestGetAlerts_HidesSecret() {
+	ctx := context.Background()
+
+	s.mockAlertRepo.On("GetAlerts", ctx).
+		Return(alertentity.Alerts{{
+			ID:        uuid.New(),
+			CodeFrom:  "BTC",
+			CodeTo:    "USD",
+			Kind:      alertentity.KindCross,
+			Threshold: decimal.NewFromInt(70000),
+			Secret:    "secret",
+		}}, nil).Once()
+
+	res, err := s.svc.GetAlerts(ctx)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), res, 1)
+	require.Empty(s.T(), res[0].Secret)
+	require.Empty(s.T(), res[0].Window)
+}
+
+func (s *AlertServiceTestSuite) TestEvaluate_Cross() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	alert := alertentity.Alert{
+		ID:         uuid.New(),
+		CodeFrom:   "BTC",
+		CodeTo:     "USD",
+		Kind:       alertentity.KindCross,
+		Threshold:  decimal.NewFromInt(70000),
+		WebhookURL: "https://example.com/hook",
+		Secret:     "secret",
+	}
+
+	s.mockAlertRepo.On("GetAlertsByPair", ctx, "BTC", "USD").
+		Return(alertentity.Alerts{alert}, nil).Times(3)
+
+	s.mockAlertRepo.On("MarkTriggered", ctx, alert.ID, now.Add(time.Minute)).
+		Return(nil).Once()
+
+	s.mockSender.On("Send", mock.Anything, alert.WebhookURL, alert.Secret, mock.MatchedBy(func(body []byte) bool {
+		var event dto.AlertEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return false
+		}
+
+		return event.AlertID == alert.ID && event.Kind == "cross" &&
+			event.Rate.Equal(decimal.NewFromInt(70500)) && event.BaseRate.Equal(decimal.NewFromInt(69000))
+	})).Return(200, nil).Once()
+
+	s.mockAlertRepo.On("SaveDelivery", mock.Anything, mock.MatchedBy(func(d alertentity.Delivery) bool {
+		return d.AlertID == alert.ID && d.Attempt == 1 && d.StatusCode == 200 && d.Error == ""
+	})).Return(nil).Once()
+
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(69000), At: now})
+	s.svc.Evaluate(ctx, dto.RateTick{
+		From: "BTC", To: "USD", Rate: decimal.NewFromInt(70500), At: now.Add(time.Minute),
+	})
+	s.svc.Evaluate(ctx, dto.RateTick{
+		From: "BTC", To: "USD", Rate: decimal.NewFromInt(71000), At: now.Add(2 * time.Minute),
+	})
+	s.svc.Stop()
+}
+
+func (s *AlertServiceTestSuite) TestEvaluate_Change() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	alert := alertentity.Alert{
+		ID:            uuid.New(),
+		CodeFrom:      "BTC",
+		CodeTo:        "USD",
+		Kind:          alertentity.KindChange,
+		ChangePercent: decimal.NewFromInt(5),
+		Window:        time.Hour,
+		WebhookURL:    "https://example.com/hook",
+		Secret:        "secret",
+	}
+
+	s.mockAlertRepo.On("GetAlertsByPair", ctx, "BTC", "USD").
+		Return(alertentity.Alerts{alert}, nil).Once()
+
+	s.mockRatesRepo.On("GetRateAt", ctx, "BTC", "USD", now.Add(-time.Hour)).
+		Return(rateentity.Rate{Rate: decimal.NewFromInt(66000)}, nil).Once()
+
+	s.mockAlertRepo.On("MarkTriggered", ctx, alert.ID, now).
+		Return(nil).Once()
+
+	s.mockSender.On("Send", mock.Anything, alert.WebhookURL, alert.Secret, mock.Anything).
+		Return(200, nil).Once()
+
+	s.mockAlertRepo.On("SaveDelivery", mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(70000), At: now})
+	s.svc.Stop()
+}
+
+func (s *AlertServiceTestSuite) TestEvaluate_ChangeNotTriggered() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	triggeredAt := now.Add(-30 * time.Minute)
+
+	cooldown := alertentity.Alert{
+		ID:              uuid.New(),
+		Kind:            alertentity.KindChange,
+		ChangePercent:   decimal.NewFromInt(5),
+		Window:          time.Hour,
+		LastTriggeredAt: &triggeredAt,
+	}
+	small := alertentity.Alert{
+		ID:            uuid.New(),
+		Kind:          alertentity.KindChange,
+		ChangePercent: decimal.NewFromInt(5),
+		Window:        2 * time.Hour,
+	}
+	noHistory := alertentity.Alert{
+		ID:            uuid.New(),
+		Kind:          alertentity.KindChange,
+		ChangePercent: decimal.NewFromInt(5),
+		Window:        3 * time.Hour,
+	}
+
+	s.mockAlertRepo.On("GetAlertsByPair", ctx, "BTC", "USD").
+		Return(alertentity.Alerts{cooldown, small, noHistory}, nil).Once()
+
+	s.mockRatesRepo.On("GetRateAt", ctx, "BTC", "USD", now.Add(-2*time.Hour)).
+		Return(rateentity.Rate{Rate: decimal.NewFromInt(68000)}, nil).Once()
+
+	s.mockRatesRepo.On("GetRateAt", ctx, "BTC", "USD", now.Add(-3*time.Hour)).
+		Return(rateentity.Rate{}, entity.ErrEntityNotFound).Once()
+
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(70000), At: now})
+	s.svc.Stop()
+
+	require.Empty(s.T(), s.buf.String())
+}
+
+func (s *AlertServiceTestSuite) TestEvaluate_RetryDelivery() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	alert := alertentity.Alert{
+		ID:         uuid.New(),
+		Kind:       alertentity.KindCross,
+		Threshold:  decimal.NewFromInt(70000),
+		WebhookURL: "https://example.com/hook",
+		Secret:     "secret",
+	}
+
+	s.mockAlertRepo.On("GetAlertsByPair", ctx, "BTC", "USD").
+		Return(alertentity.Alerts{alert}, nil).Twice()
+
+	s.mockAlertRepo.On("MarkTriggered", ctx, alert.ID, now).
+		Return(nil).Once()
+
+	s.mockSender.On("Send", mock.Anything, alert.WebhookURL, alert.Secret, mock.Anything).
+		Return(0, errors.New("connection refused")).Once()
+	s.mockSender.On("Send", mock.Anything, alert.WebhookURL, alert.Secret, mock.Anything).
+		Return(503, errors.New("response status not ok")).Once()
+	s.mockSender.On("Send", mock.Anything, alert.WebhookURL, alert.Secret, mock.Anything).
+		Return(200, nil).Once()
+
+	saved := make(chan struct{}, 3)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		s.mockAlertRepo.On("SaveDelivery", mock.Anything, mock.MatchedBy(func(d alertentity.Delivery) bool {
+			return d.AlertID == alert.ID && d.Attempt == attempt
+		})).Return(nil).Run(func(mock.Arguments) { saved <- struct{}{} }).Once()
+	}
+
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(71000), At: now.Add(-time.Minute)})
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(69000), At: now})
+
+	waitDeliveries(s.T(), saved, 3)
+	s.svc.Stop()
+}
+
+func (s *AlertServiceTestSuite) TestEvaluate_GiveUpDelivery() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	alert := alertentity.Alert{
+		ID:         uuid.New(),
+		Kind:       alertentity.KindCross,
+		Threshold:  decimal.NewFromInt(70000),
+		WebhookURL: "https://example.com/hook",
+		Secret:     "secret",
+	}
+
+	s.mockAlertRepo.On("GetAlertsByPair", ctx, "BTC", "USD").
+		Return(alertentity.Alerts{alert}, nil).Twice()
+
+	s.mockAlertRepo.On("MarkTriggered", ctx, alert.ID, now).
+		Return(nil).Once()
+
+	s.mockSender.On("Send", mock.Anything, alert.WebhookURL, alert.Secret, mock.Anything).
+		Return(500, errors.New("response status not ok")).Times(3)
+
+	saved := make(chan struct{}, 3)
+
+	s.mockAlertRepo.On("SaveDelivery", mock.Anything, mock.MatchedBy(func(d alertentity.Delivery) bool {
+		return d.StatusCode == 500 && d.Error == "response status not ok"
+	})).Return(nil).Run(func(mock.Arguments) { saved <- struct{}{} }).Times(3)
+
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(69000), At: now.Add(-time.Minute)})
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(70000), At: now})
+
+	waitDeliveries(s.T(), saved, 3)
+	s.svc.Stop()
+
+	require.Contains(s.T(), s.buf.String(), "give up after 3 attempts")
+}
+
+func (s *AlertServiceTestSuite) TestEvaluate_Stopped() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	alert := alertentity.Alert{
+		ID:         uuid.New(),
+		Kind:       alertentity.KindCross,
+		Threshold:  decimal.NewFromInt(70000),
+		WebhookURL: "https://example.com/hook",
+		Secret:     "secret",
+	}
+
+	s.mockAlertRepo.On("GetAlertsByPair", ctx, "BTC", "USD").
+		Return(alertentity.Alerts{alert}, nil).Twice()
+
+	s.mockAlertRepo.On("MarkTriggered", ctx, alert.ID, now).
+		Return(nil).Once()
+
+	s.svc.Stop()
+	s.svc.Stop()
+
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(69000), At: now.Add(-time.Minute)})
+	s.svc.Evaluate(ctx, dto.RateTick{From: "BTC", To: "USD", Rate: decimal.NewFromInt(70000), At: now})
+
+	s.mockSender.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.Contains(s.T(), s.buf.String(), "service is stopped")
+}
+
+func waitDeliveries(t *testing.T, saved <-chan struct{}, n int) {
+	t.Helper()
+
+	for range n {
+		select {
+		case <-saved:
+		case <-time.After(time.Second):
+			t.Fatal("delivery attempts are not saved")
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Kind string
+
+const (
+	// KindCross fires when the rate crosses the threshold in any direction
+	KindCross Kind = "cross"
+	// KindChange fires when the rate moves by change percent or more within the window
+	KindChange Kind = "change"
+)
+
+type Alert struct {
+	ID              uuid.UUID
+	CodeFrom        string
+	CodeTo          string
+	Kind            Kind
+	Threshold       decimal.Decimal
+	ChangePercent   decimal.Decimal
+	Window          time.Duration
+	WebhookURL      string
+	Secret          string
+	LastTriggeredAt *time.Time
+	CreatedAt       time.Time
+}
+
+type Alerts []Alert
+
+func (a Alert) Validate() error {
+	switch a.Kind {
+	case KindCross:
+		if !a.Threshold.IsPositive() {
+			return ErrInvalidAlertValue
+		}
+	case KindChange:
+		if !a.ChangePercent.IsPositive() || a.Window < time.Second {
+			return ErrInvalidAlertValue
+		}
+	default:
+		return ErrInvalidAlertKind
+	}
+
+	return nil
+}
+
+// IsCrossed reports whether the rate moved from one side of the threshold to the other
+// or reached it exactly.
+func (a Alert) IsCrossed(prev, cur decimal.Decimal) bool {
+	if prev.LessThan(a.Threshold) {
+		return cur.GreaterThanOrEqual(a.Threshold)
+	}
+
+	if prev.GreaterThan(a.Threshold) {
+		return cur.LessThanOrEqual(a.Threshold)
+	}
+
+	return false
+}
+
+// IsChanged reports whether the rate moved from base by change percent or more.
+func (a Alert) IsChanged(base, cur decimal.Decimal) bool {
+	if base.IsZero() {
+		return false
+	}
+
+	change := cur.Sub(base).Abs().Mul(decimal.NewFromInt(100)).Div(base)
+
+	return change.GreaterThanOrEqual(a.ChangePercent)
+}
+
+// InCooldown reports whether a change alert has already fired within the current window.
+func (a Alert) InCooldown(at time.Time) bool {
+	return a.LastTriggeredAt != nil && at.Sub(*a.LastTriggeredAt) < a.Window
+}
+
+// Delivery is one attempt to deliver an alert webhook.
+type Delivery struct {
+	ID         uuid.UUID
+	AlertID    uuid.UUID
+	Attempt    int
+	StatusCode int
+	Error      string
+	CreatedAt  time.Time
+}
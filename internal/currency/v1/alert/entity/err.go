@@ -0,0 +1,10 @@
+package entity
+
+import "errors"
+
+var (
+	ErrInvalidAlertKind    = errors.New("alert kind must be cross or change")
+	ErrInvalidAlertValue   = errors.New("threshold and change percent must be positive, window must be at least a second")
+	ErrInvalidWebhookURL   = errors.New("webhook url must be http or https url of a public host")
+	ErrAlertPairNotFetched = errors.New("alerts may be set only on enabled pairs which are fetched directly")
+)
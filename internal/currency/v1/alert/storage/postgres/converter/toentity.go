@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/veleton777/test_work_blum/internal/currency/v1/alert/entity"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/alert/storage/postgres/entity"
+	"github.com/veleton777/test_work_blum/internal/pkg/pgutil"
+)
+
+func AlertsToEntity(alerts storageentity.Alerts) entity.Alerts {
+	res := make(entity.Alerts, 0, len(alerts))
+
+	for _, a := range alerts {
+		res = append(res, AlertToEntity(a))
+	}
+
+	return res
+}
+
+func AlertToEntity(a storageentity.Alert) entity.Alert {
+	return entity.Alert{
+		ID:              a.ID,
+		CodeFrom:        a.CodeFrom,
+		CodeTo:          a.CodeTo,
+		Kind:            entity.Kind(a.Kind),
+		Threshold:       pgutil.NumericToDecimal(a.Threshold),
+		ChangePercent:   pgutil.NumericToDecimal(a.ChangePercent),
+		Window:          time.Duration(a.WindowSeconds) * time.Second,
+		WebhookURL:      a.WebhookURL,
+		Secret:          a.Secret,
+		LastTriggeredAt: a.LastTriggeredAt,
+		CreatedAt:       a.CreatedAt,
+	}
+}
+
+func DeliveriesToEntity(deliveries storageentity.Deliveries) []entity.Delivery {
+	res := make([]entity.Delivery, 0, len(deliveries))
+
+	for _, d := range deliveries {
+		res = append(res, entity.Delivery{
+			ID:         d.ID,
+			AlertID:    d.AlertID,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Error:      d.Error,
+			CreatedAt:  d.CreatedAt,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Alert struct {
+	ID              uuid.UUID      `db:"id"`
+	CodeFrom        string         `db:"code_from"`
+	CodeTo          string         `db:"code_to"`
+	Kind            string         `db:"kind"`
+	Threshold       pgtype.Numeric `db:"threshold"`
+	ChangePercent   pgtype.Numeric `db:"change_percent"`
+	WindowSeconds   int64          `db:"window_seconds"`
+	WebhookURL      string         `db:"webhook_url"`
+	Secret          string         `db:"secret"`
+	LastTriggeredAt *time.Time     `db:"last_triggered_at"`
+	CreatedAt       time.Time      `db:"created_at"`
+}
+
+type Alerts []Alert
+
+type Delivery struct {
+	ID         uuid.UUID `db:"id"`
+	AlertID    uuid.UUID `db:"alert_id"`
+	Attempt    int       `db:"attempt"`
+	StatusCode int       `db:"status_code"`
+	Error      string    `db:"error"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+type Deliveries []Delivery
@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/alert/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/alert/storage/postgres/converter"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/alert/storage/postgres/entity"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+)
+
+const (
+	alertsTable     = "alerts"
+	deliveriesTable = "webhook_deliveries"
+)
+
+type RepoPostgres struct {
+	pgClient *pgxpool.Pool
+	timeout  time.Duration
+}
+
+func NewRepoPostgres(pgClient *pgxpool.Pool, timeout time.Duration) *RepoPostgres {
+	return &RepoPostgres{pgClient: pgClient, timeout: timeout}
+}
+
+func (r *RepoPostgres) CreateAlert(ctx context.Context, alert entity.Alert) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Insert(alertsTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns(
+			"id", "code_from", "code_to", "kind", "threshold", "change_percent", "window_seconds",
+			"webhook_url", "secret", "created_at",
+		).
+		Values(
+			alert.ID,
+			alert.CodeFrom,
+			alert.CodeTo,
+			string(alert.Kind),
+			alert.Threshold,
+			alert.ChangePercent,
+			int64(alert.Window/time.Second),
+			alert.WebhookURL,
+			alert.Secret,
+			alert.CreatedAt,
+		)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	if _, err = r.pgClient.Exec(ctx, query, v...); err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) GetAlerts(ctx context.Context) (entity.Alerts, error) {
+	return r.getAlerts(ctx, nil)
+}
+
+func (r *RepoPostgres) GetAlertsByPair(ctx context.Context, codeFrom, codeTo string) (entity.Alerts, error) {
+	return r.getAlerts(ctx, squirrel.Eq{"code_from": codeFrom, "code_to": codeTo})
+}
+
+func (r *RepoPostgres) getAlerts(ctx context.Context, where squirrel.Sqlizer) (entity.Alerts, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select(
+		"id", "code_from", "code_to", "kind", "threshold", "change_percent", "window_seconds",
+		"webhook_url", "secret", "last_triggered_at", "created_at",
+	).
+		From(alertsTable).
+		PlaceholderFormat(squirrel.Dollar).
+		OrderBy("created_at")
+
+	if where != nil {
+		builder = builder.Where(where)
+	}
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx query")
+	}
+	defer rows.Close()
+
+	alerts, err := pgx.CollectRows(rows, pgx.RowToStructByName[storageentity.Alert])
+	if err != nil {
+		return nil, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.AlertsToEntity(alerts), nil
+}
+
+func (r *RepoPostgres) DeleteAlert(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Delete(alertsTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"id": id})
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	if err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return currencyentity.ErrEntityNotFound
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) MarkTriggered(ctx context.Context, id uuid.UUID, triggeredAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Update(alertsTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Set("last_triggered_at", triggeredAt).
+		Where(squirrel.Eq{"id": id})
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	if err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return currencyentity.ErrEntityNotFound
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) SaveDelivery(ctx context.Context, delivery entity.Delivery) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Insert(deliveriesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns("id", "alert_id", "attempt", "status_code", "error", "created_at").
+		Values(
+			delivery.ID,
+			delivery.AlertID,
+			delivery.Attempt,
+			delivery.StatusCode,
+			delivery.Error,
+			delivery.CreatedAt,
+		)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	if _, err = r.pgClient.Exec(ctx, query, v...); err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) GetDeliveries(ctx context.Context, alertID uuid.UUID) ([]entity.Delivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select("id", "alert_id", "attempt", "status_code", "error", "created_at").
+		From(deliveriesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"alert_id": alertID}).
+		OrderBy("created_at", "attempt")
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx query")
+	}
+	defer rows.Close()
+
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[storageentity.Delivery])
+	if err != nil {
+		return nil, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.DeliveriesToEntity(deliveries), nil
+}
@@ -0,0 +1,176 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/config"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/alert/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/alert/storage/postgres"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	repo      *postgres.RepoPostgres
+	pgxClient *pgxpool.Pool
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) SetupSuite() {
+	ctx := context.Background()
+	conf, err := config.Load()
+	s.Require().NoError(err)
+
+	pgCfg, err := pgxpool.ParseConfig(
+		fmt.Sprintf(
+			"host=%s port=%d dbname=%s user=%s password=%s",
+			conf.PgHost(),
+			conf.PgPort(),
+			conf.PgDB(),
+			conf.PgUser(),
+			conf.PgPassword(),
+		),
+	)
+	s.Require().NoError(err)
+
+	pgClient, err := pgxpool.NewWithConfig(ctx, pgCfg)
+	s.Require().NoError(err)
+
+	err = pgClient.Ping(ctx)
+	s.Require().NoError(err)
+
+	s.repo = postgres.NewRepoPostgres(pgClient, 1*time.Second)
+	s.pgxClient = pgClient
+
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownSuite() {
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownTest() {
+	s.clearCollection()
+}
+
+func (s *Suite) clearCollection() {
+	ctx := context.Background()
+
+	_, err := s.pgxClient.Exec(ctx, "TRUNCATE TABLE alerts CASCADE")
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestCreateAlert_GetAlerts_NoErr() {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	cross := entity.Alert{
+		ID:         uuid.New(),
+		CodeFrom:   "BTC",
+		CodeTo:     "USD",
+		Kind:       entity.KindCross,
+		Threshold:  decimal.NewFromInt(70000),
+		WebhookURL: "https://example.com/hook",
+		Secret:     "secret",
+		CreatedAt:  now,
+	}
+	change := entity.Alert{
+		ID:            uuid.New(),
+		CodeFrom:      "ETH",
+		CodeTo:        "USD",
+		Kind:          entity.KindChange,
+		ChangePercent: decimal.NewFromInt(5),
+		Window:        time.Hour,
+		WebhookURL:    "https://example.com/hook",
+		Secret:        "secret",
+		CreatedAt:     now.Add(time.Second),
+	}
+
+	s.Require().NoError(s.repo.CreateAlert(ctx, cross))
+	s.Require().NoError(s.repo.CreateAlert(ctx, change))
+
+	res, err := s.repo.GetAlerts(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(res, 2)
+	s.Require().Equal(cross.ID, res[0].ID)
+	s.Require().True(cross.Threshold.Equal(res[0].Threshold))
+	s.Require().Equal(change.Kind, res[1].Kind)
+	s.Require().Equal(change.Window, res[1].Window)
+	s.Require().True(change.ChangePercent.Equal(res[1].ChangePercent))
+
+	res, err = s.repo.GetAlertsByPair(ctx, "ETH", "USD")
+	s.Require().NoError(err)
+	s.Require().Len(res, 1)
+	s.Require().Equal(change.ID, res[0].ID)
+}
+
+func (s *Suite) TestMarkTriggered_SaveDelivery() {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	alert := entity.Alert{
+		ID:         uuid.New(),
+		CodeFrom:   "BTC",
+		CodeTo:     "USD",
+		Kind:       entity.KindCross,
+		Threshold:  decimal.NewFromInt(70000),
+		WebhookURL: "https://example.com/hook",
+		Secret:     "secret",
+		CreatedAt:  now,
+	}
+	s.Require().NoError(s.repo.CreateAlert(ctx, alert))
+
+	s.Require().NoError(s.repo.MarkTriggered(ctx, alert.ID, now.Add(time.Minute)))
+
+	res, err := s.repo.GetAlertsByPair(ctx, "BTC", "USD")
+	s.Require().NoError(err)
+	s.Require().Len(res, 1)
+	s.Require().NotNil(res[0].LastTriggeredAt)
+	s.Require().True(now.Add(time.Minute).Equal(*res[0].LastTriggeredAt))
+
+	s.Require().NoError(s.repo.SaveDelivery(ctx, entity.Delivery{
+		ID:         uuid.New(),
+		AlertID:    alert.ID,
+		Attempt:    1,
+		StatusCode: 500,
+		Error:      "response status not ok",
+		CreatedAt:  now.Add(time.Minute),
+	}))
+	s.Require().NoError(s.repo.SaveDelivery(ctx, entity.Delivery{
+		ID:         uuid.New(),
+		AlertID:    alert.ID,
+		Attempt:    2,
+		StatusCode: 200,
+		CreatedAt:  now.Add(2 * time.Minute),
+	}))
+
+	deliveries, err := s.repo.GetDeliveries(ctx, alert.ID)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 2)
+	s.Require().Equal(1, deliveries[0].Attempt)
+	s.Require().Equal(500, deliveries[0].StatusCode)
+	s.Require().Equal(200, deliveries[1].StatusCode)
+}
+
+func (s *Suite) TestDeleteAlert() {
+	ctx := context.Background()
+
+	err := s.repo.DeleteAlert(ctx, uuid.New())
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+
+	err = s.repo.MarkTriggered(ctx, uuid.New(), time.Now())
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
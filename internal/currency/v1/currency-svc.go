@@ -2,36 +2,91 @@ package currency
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
-	"github.com/shopspring/decimal"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	pairentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	rateentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
 	"github.com/veleton777/test_work_blum/internal/dto"
 )
 
 type Svc struct {
 	currencyStorage Repo
-	currenciesAPI   CurrenciesAPI
+	providers       *Providers
 	courseStorage   CourseStorage
-	wg              *sync.WaitGroup
+	ratesRepo       RatesRepo
+	pricingRepo     PricingRepo
+	pairRepo        PairRepo
+	alerts          AlertEvaluator
+	opts            Options
 	l               *zerolog.Logger
+
+	// updating is set while the update courses cycle runs, overlapping cycles are rejected
+	updating atomic.Bool
+
+	mu sync.Mutex
+	// currencies are the ones read by the last update courses cycle or by pricing, they resolve
+	// pricing by types; writes to the catalogue drop them, so they are read again
+	currencies entity.Currencies
+	// lastFetched is when every registered pair was fetched last time, it keeps refresh intervals
+	lastFetched map[string]time.Time
+}
+
+const (
+	defaultCurrenciesLimit = 50
+	defaultUpdateWorkers   = 4
+)
+
+var ErrUpdateInProgress = errors.New("update courses cycle is already in progress")
+
+type Options struct {
+	// PivotCode is the currency used to derive pairs which are not fetched directly
+	PivotCode string
+	// StaleMaxAge is how long the last known course is served after the provider fails
+	StaleMaxAge time.Duration
+	// HistoryMaxGap is how old a persisted rate may be at the requested moment before it is backfilled
+	HistoryMaxGap time.Duration
+	// UpdateWorkers is how many batches of pairs are fetched from the providers at once
+	UpdateWorkers int
+	// CycleTimeout limits fetching of one update courses cycle, no limit when zero
+	CycleTimeout time.Duration
+	// PairingRules decide which registered pairs are fetched, pairs with the pivot are always fetched
+	PairingRules entity.PairingRules
 }
 
 func NewCurrencySvc(
 	currencyStorage Repo,
-	currenciesAPI CurrenciesAPI,
+	providers *Providers,
 	courseStorage CourseStorage,
+	ratesRepo RatesRepo,
+	pricingRepo PricingRepo,
+	pairRepo PairRepo,
+	alerts AlertEvaluator,
+	opts Options,
 	l *zerolog.Logger,
 ) *Svc {
 	return &Svc{
 		currencyStorage: currencyStorage,
-		currenciesAPI:   currenciesAPI,
+		providers:       providers,
 		courseStorage:   courseStorage,
-		wg:              &sync.WaitGroup{},
+		ratesRepo:       ratesRepo,
+		pricingRepo:     pricingRepo,
+		pairRepo:        pairRepo,
+		alerts:          alerts,
+		opts:            opts,
 		l:               l,
+		lastFetched:     make(map[string]time.Time),
 	}
 }
 
@@ -53,13 +108,16 @@ func (s *Svc) CreateCurrency(ctx context.Context, dto dto.Currency) error {
 		return errors.Wrap(err, "save currency to storage")
 	}
 
+	s.invalidateCurrencies()
+
 	return nil
 }
 
-func (s *Svc) UpdateCurrency(ctx context.Context, dto dto.Currency) error {
+// UpdateCurrency saves the currency of the given version and returns the new version.
+func (s *Svc) UpdateCurrency(ctx context.Context, dto dto.Currency) (int64, error) {
 	t, err := entity.IntToCurrencyType(dto.Type)
 	if err != nil {
-		return errors.Wrap(err, "int to currency type")
+		return 0, errors.Wrap(err, "int to currency type")
 	}
 
 	currency := entity.Currency{
@@ -68,92 +126,1090 @@ func (s *Svc) UpdateCurrency(ctx context.Context, dto dto.Currency) error {
 		Code:        dto.Code,
 		Type:        t,
 		IsAvailable: dto.IsAvailable,
+		Version:     dto.Version,
 	}
 
-	if err = s.currencyStorage.UpdateCurrency(ctx, currency); err != nil {
-		return errors.Wrap(err, "update currency in storage")
+	version, err := s.currencyStorage.UpdateCurrency(ctx, currency)
+	if err != nil {
+		return 0, errors.Wrap(err, "update currency in storage")
 	}
 
-	return nil
+	s.invalidateCurrencies()
+
+	return version, nil
+}
+
+// PatchCurrency saves the supplied fields of the currency of the given version and returns the new version.
+func (s *Svc) PatchCurrency(ctx context.Context, dto dto.CurrencyPatch) (int64, error) {
+	patch := entity.CurrencyPatch{
+		ID:          dto.ID,
+		Name:        dto.Name,
+		Code:        dto.Code,
+		IsAvailable: dto.IsAvailable,
+		Version:     dto.Version,
+	}
+
+	if dto.Type != nil {
+		t, err := entity.IntToCurrencyType(*dto.Type)
+		if err != nil {
+			return 0, errors.Wrap(err, "int to currency type")
+		}
+
+		patch.Type = &t
+	}
+
+	version, err := s.currencyStorage.PatchCurrency(ctx, patch)
+	if err != nil {
+		return 0, errors.Wrap(err, "patch currency in storage")
+	}
+
+	s.invalidateCurrencies()
+
+	return version, nil
 }
 
-func (s *Svc) DeleteCurrency(ctx context.Context, id uuid.UUID) error {
-	if err := s.currencyStorage.DeleteCurrency(ctx, id); err != nil {
+// ImportCurrencies upserts the records by code. When any record is invalid the errors
+// are reported and nothing is saved.
+func (s *Svc) ImportCurrencies(ctx context.Context, req dto.ImportCurrenciesReq) (dto.ImportReport, error) {
+	report := dto.ImportReport{DryRun: req.DryRun}
+
+	currencies := make(entity.Currencies, 0, len(req.Records))
+	rows := make(map[string]int, len(req.Records))
+
+	for i, record := range req.Records {
+		row := i + 1
+
+		t, err := entity.IntToCurrencyType(record.Type)
+		if err != nil {
+			report.Errors = append(report.Errors, dto.ImportRowError{Row: row, Code: record.Code, Error: err.Error()})
+
+			continue
+		}
+
+		if first, ok := rows[record.Code]; ok {
+			report.Errors = append(report.Errors, dto.ImportRowError{
+				Row:   row,
+				Code:  record.Code,
+				Error: fmt.Sprintf("duplicate code of row %d", first),
+			})
+
+			continue
+		}
+
+		rows[record.Code] = row
+
+		currencies = append(currencies, entity.Currency{
+			ID:          uuid.New(),
+			Name:        record.Name,
+			Code:        record.Code,
+			Type:        t,
+			IsAvailable: record.IsAvailable,
+		})
+	}
+
+	if len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	actions, err := s.currencyStorage.ImportCurrencies(ctx, currencies, req.DryRun)
+	if err != nil {
+		return dto.ImportReport{}, errors.Wrap(err, "import currencies to storage")
+	}
+
+	if !req.DryRun {
+		s.invalidateCurrencies()
+	}
+
+	for _, action := range actions {
+		switch action {
+		case entity.ImportCreated:
+			report.Created++
+		case entity.ImportUpdated:
+			report.Updated++
+		case entity.ImportUnchanged:
+			report.Unchanged++
+		}
+	}
+
+	return report, nil
+}
+
+// ExportCurrencies returns the whole catalogue ordered by code.
+func (s *Svc) ExportCurrencies(ctx context.Context) ([]dto.CurrencyRecord, error) {
+	currencies, err := s.currencyStorage.GetCurrencies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get currencies from storage")
+	}
+
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i].Code < currencies[j].Code
+	})
+
+	res := make([]dto.CurrencyRecord, 0, len(currencies))
+	for _, c := range currencies {
+		res = append(res, dto.CurrencyRecord{
+			Code:        c.Code,
+			Name:        c.Name,
+			Type:        int(c.Type),
+			IsAvailable: c.IsAvailable,
+		})
+	}
+
+	return res, nil
+}
+
+// DeleteCurrency soft deletes the currency and drops its courses, so it is not
+// converted until it is restored and fetched again.
+func (s *Svc) DeleteCurrency(ctx context.Context, id uuid.UUID, version int64) error {
+	currency, err := s.currencyStorage.GetCurrency(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "get currency from storage")
+	}
+
+	if err = s.currencyStorage.DeleteCurrency(ctx, id, version); err != nil {
 		return errors.Wrap(err, "delete currency from storage")
 	}
 
+	s.courseStorage.RemoveCurrency(ctx, currency.Code)
+	s.invalidateCurrencies()
+
 	return nil
 }
 
-func (s *Svc) Convert(ctx context.Context, dto dto.ConvertCurrencyReq) (float64, error) {
-	v, ok := s.courseStorage.Get(ctx, dto.From, dto.To)
-	if !ok {
-		return 0, entity.ErrCurrencyNotAvailable
+func (s *Svc) RestoreCurrency(ctx context.Context, id uuid.UUID) (dto.CurrencyResp, error) {
+	currency, err := s.currencyStorage.RestoreCurrency(ctx, id)
+	if err != nil {
+		return dto.CurrencyResp{}, errors.Wrap(err, "restore currency in storage")
 	}
 
-	res, _ := v.Mul(decimal.NewFromFloat(dto.Amount)).Float64()
+	s.invalidateCurrencies()
+
+	return currencyToDTO(currency), nil
+}
+
+func (s *Svc) PurgeCurrency(ctx context.Context, id uuid.UUID) error {
+	if err := s.currencyStorage.PurgeCurrency(ctx, id); err != nil {
+		return errors.Wrap(err, "purge currency from storage")
+	}
+
+	s.invalidateCurrencies()
+
+	return nil
+}
+
+// invalidateCurrencies drops the cached currencies after the catalogue is changed,
+// so pricing by types doesn't use the type the currency had before.
+func (s *Svc) invalidateCurrencies() {
+	s.mu.Lock()
+	s.currencies = nil
+	s.mu.Unlock()
+}
+
+func (s *Svc) GetCurrency(ctx context.Context, id uuid.UUID) (dto.CurrencyResp, error) {
+	currency, err := s.currencyStorage.GetCurrency(ctx, id)
+	if err != nil {
+		return dto.CurrencyResp{}, errors.Wrap(err, "get currency from storage")
+	}
+
+	return currencyToDTO(currency), nil
+}
+
+func (s *Svc) GetCurrencyByCode(ctx context.Context, code string) (dto.CurrencyResp, error) {
+	currency, err := s.currencyStorage.GetCurrencyByCode(ctx, code)
+	if err != nil {
+		return dto.CurrencyResp{}, errors.Wrap(err, "get currency by code from storage")
+	}
+
+	return currencyToDTO(currency), nil
+}
+
+// GetCurrencyAudit returns changes of the currency from the oldest one. The audit
+// of the deleted currency is still returned.
+func (s *Svc) GetCurrencyAudit(ctx context.Context, id uuid.UUID) ([]dto.CurrencyAuditRecord, error) {
+	audit, err := s.currencyStorage.GetCurrencyAudit(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get currency audit from storage")
+	}
+
+	res := make([]dto.CurrencyAuditRecord, 0, len(audit))
+
+	for _, a := range audit {
+		record := dto.CurrencyAuditRecord{
+			ID:        a.ID,
+			Action:    string(a.Action),
+			Actor:     a.Actor,
+			RequestID: a.RequestID,
+			CreatedAt: a.CreatedAt,
+		}
+
+		if a.Before != nil {
+			before := currencyToDTO(*a.Before)
+			record.Before = &before
+		}
+
+		if a.After != nil {
+			after := currencyToDTO(*a.After)
+			record.After = &after
+		}
+
+		res = append(res, record)
+	}
 
 	return res, nil
 }
 
-func (s *Svc) UpdateCourses(ctx context.Context) error {
+// ListCurrencies returns a page of currencies. One extra currency is requested
+// to find out whether the next page exists.
+func (s *Svc) ListCurrencies(ctx context.Context, req dto.ListCurrenciesReq) (dto.CurrenciesPage, error) {
+	filter := entity.CurrencyFilter{
+		Type:        entity.CurrencyType(req.Type),
+		IsAvailable: req.IsAvailable,
+		Search:      req.Search,
+		SortBy:      entity.SortField(strings.TrimPrefix(req.Sort, "-")),
+		Desc:        strings.HasPrefix(req.Sort, "-"),
+		Limit:       req.Limit,
+	}
+
+	if filter.SortBy == "" {
+		filter.SortBy = entity.SortByCode
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultCurrenciesLimit
+	}
+
+	if req.Cursor != "" {
+		after, err := decodeCursor(req.Cursor, filter)
+		if err != nil {
+			return dto.CurrenciesPage{}, err
+		}
+
+		filter.After = &after
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	currencies, err := s.currencyStorage.ListCurrencies(ctx, filter)
+	if err != nil {
+		return dto.CurrenciesPage{}, errors.Wrap(err, "list currencies from storage")
+	}
+
+	res := dto.CurrenciesPage{
+		Currencies: make([]dto.CurrencyResp, 0, min(len(currencies), limit)),
+	}
+
+	for i, c := range currencies {
+		if i == limit {
+			res.NextCursor = encodeCursor(currencies[i-1], filter)
+
+			break
+		}
+
+		res.Currencies = append(res.Currencies, currencyToDTO(c))
+	}
+
+	return res, nil
+}
+
+func (s *Svc) Convert(ctx context.Context, req dto.Conversion) (dto.ConversionResult, error) {
 	var (
-		fiatCur   entity.Currencies
-		cryptoCur entity.Currencies
+		res dto.ConversionResult
+		err error
 	)
 
+	if req.At != nil {
+		res, err = s.historicalConversionResult(ctx, req)
+	} else {
+		v, ok := s.courseStorage.Get(ctx, req.From, req.To)
+		res, err = s.conversionResult(req, v, ok)
+	}
+
+	if err != nil {
+		return dto.ConversionResult{}, err
+	}
+
+	pricings, currencies, err := s.loadPricing(ctx)
+	if err != nil {
+		return dto.ConversionResult{}, err
+	}
+
+	return applyPricing(res, pricings, currencies, req), nil
+}
+
+// ConvertBatch converts every current item against one snapshot of the course storage
+// and every item with a moment against the rates history. An item which can't be
+// converted gets its own error instead of failing the batch.
+func (s *Svc) ConvertBatch(ctx context.Context, reqs []dto.Conversion) ([]dto.BatchConversionResult, error) {
+	pairs := make([]dto.CurrencyPair, 0, len(reqs))
+	for _, req := range reqs {
+		if req.At == nil {
+			pairs = append(pairs, dto.CurrencyPair{From: req.From, To: req.To})
+		}
+	}
+
+	snapshot := s.courseStorage.GetMany(ctx, pairs)
+
+	pricings, currencies, err := s.loadPricing(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.BatchConversionResult, 0, len(reqs))
+
+	for _, req := range reqs {
+		var r dto.ConversionResult
+
+		if req.At != nil {
+			r, err = s.historicalConversionResult(ctx, req)
+			if err != nil && !errors.Is(err, entity.ErrCurrencyNotAvailable) {
+				return nil, err
+			}
+		} else {
+			v, ok := snapshot[dto.CurrencyPair{From: req.From, To: req.To}]
+			r, err = s.conversionResult(req, v, ok)
+		}
+
+		if err != nil {
+			res = append(res, dto.BatchConversionResult{Err: err})
+
+			continue
+		}
+
+		res = append(res, dto.BatchConversionResult{Result: applyPricing(r, pricings, currencies, req)})
+	}
+
+	return res, nil
+}
+
+// historicalConversionResult converts by the rate valid at the moment. The history
+// outlives the currencies, so a deleted currency is rejected before it is looked up.
+func (s *Svc) historicalConversionResult(ctx context.Context, req dto.Conversion) (dto.ConversionResult, error) {
+	for _, code := range []string{req.From, req.To} {
+		if _, err := s.currencyStorage.GetCurrencyByCode(ctx, code); err != nil {
+			if errors.Is(err, entity.ErrEntityNotFound) {
+				return dto.ConversionResult{}, entity.ErrCurrencyNotAvailable
+			}
+
+			return dto.ConversionResult{}, errors.Wrap(err, "get currency from storage")
+		}
+	}
+
+	at := req.At.UTC()
+
+	rate, ok, err := s.persistedRateAt(ctx, req.From, req.To, at)
+	if err != nil {
+		return dto.ConversionResult{}, err
+	}
+
+	// synthetic pairs are not persisted, they are derived from the persisted legs
+	// before the provider is asked
+	var via string
+
+	if !ok && s.isSyntheticPair(req.From, req.To) {
+		rate, ok, err = s.syntheticRateAt(ctx, req.From, req.To, at)
+		if err != nil {
+			return dto.ConversionResult{}, err
+		}
+
+		if ok {
+			via = s.opts.PivotCode
+		}
+	}
+
+	if !ok {
+		rate, err = s.backfillRate(ctx, req.From, req.To, at)
+		if err != nil {
+			return dto.ConversionResult{}, err
+		}
+	}
+
+	return dto.ConversionResult{
+		Rate:        rate.Rate,
+		Amount:      req.Amount,
+		Result:      rate.Rate.Mul(req.Amount),
+		AsOf:        rate.CreatedAt,
+		Source:      rate.Provider,
+		CycleID:     rate.CycleID,
+		IsSynthetic: via != "",
+		Via:         via,
+	}, nil
+}
+
+// persistedRateAt returns the persisted rate which was valid at the moment,
+// it is not found when the history has a gap there.
+func (s *Svc) persistedRateAt(ctx context.Context, codeFrom, codeTo string, at time.Time) (rateentity.Rate, bool, error) {
+	rate, err := s.ratesRepo.GetRateAt(ctx, codeFrom, codeTo, at)
+	if err != nil {
+		if errors.Is(err, entity.ErrEntityNotFound) {
+			return rateentity.Rate{}, false, nil
+		}
+
+		return rateentity.Rate{}, false, errors.Wrap(err, "get rate from history")
+	}
+
+	if at.Sub(rate.CreatedAt) > s.opts.HistoryMaxGap {
+		return rateentity.Rate{}, false, nil
+	}
+
+	return rate, true, nil
+}
+
+// isSyntheticPair reports whether the pair is derived through the pivot when it is not fetched directly.
+func (s *Svc) isSyntheticPair(codeFrom, codeTo string) bool {
+	return s.opts.PivotCode != "" && codeFrom != s.opts.PivotCode && codeTo != s.opts.PivotCode
+}
+
+// syntheticRateAt derives the rate at the moment from the persisted from→pivot and
+// pivot→to legs the same way the update cycle derives the synthetic courses.
+func (s *Svc) syntheticRateAt(ctx context.Context, codeFrom, codeTo string, at time.Time) (rateentity.Rate, bool, error) {
+	first, ok, err := s.persistedRateAt(ctx, codeFrom, s.opts.PivotCode, at)
+	if err != nil || !ok {
+		return rateentity.Rate{}, false, err
+	}
+
+	second, ok, err := s.persistedRateAt(ctx, s.opts.PivotCode, codeTo, at)
+	if err != nil || !ok {
+		return rateentity.Rate{}, false, err
+	}
+
+	rate := rateentity.Rate{
+		CodeFrom:  codeFrom,
+		CodeTo:    codeTo,
+		Rate:      first.Rate.Mul(second.Rate),
+		Provider:  first.Provider,
+		CreatedAt: first.CreatedAt,
+	}
+
+	if second.Provider != first.Provider {
+		rate.Provider = first.Provider + "," + second.Provider
+	}
+
+	if second.CreatedAt.Before(first.CreatedAt) {
+		rate.CreatedAt = second.CreatedAt
+	}
+
+	if first.CycleID == second.CycleID {
+		rate.CycleID = first.CycleID
+	}
+
+	return rate, true, nil
+}
+
+// backfillRate fills a gap in the history with the rate from the courses providers.
+// It is persisted as backfilled, so the next conversion at the moment finds it.
+func (s *Svc) backfillRate(ctx context.Context, codeFrom, codeTo string, at time.Time) (rateentity.Rate, error) {
+	course, provider, err := s.providers.Historical(ctx, codeFrom, codeTo, at)
+	if err != nil {
+		s.l.Err(err).Msgf("get historical rate through api: from %s to %s at %s", codeFrom, codeTo, at)
+
+		return rateentity.Rate{}, entity.ErrCurrencyNotAvailable
+	}
+
+	rate := rateentity.Rate{
+		CodeFrom:     codeFrom,
+		CodeTo:       codeTo,
+		Rate:         course,
+		Provider:     provider,
+		CreatedAt:    at,
+		IsBackfilled: true,
+	}
+
+	if err = s.ratesRepo.SaveRate(ctx, rate); err != nil {
+		s.l.Err(err).Msgf("save backfilled rate to history: from %s to %s", codeFrom, codeTo)
+	}
+
+	return rate, nil
+}
+
+// loadPricing returns the pricing rules, the currencies are resolved only when
+// some rules are set for currency types. They are taken from the last update courses
+// cycle, the storage is read before the first cycle and after the catalogue is changed.
+func (s *Svc) loadPricing(ctx context.Context) (pricingentity.Pricings, entity.Currencies, error) {
+	pricings, err := s.pricingRepo.GetPricings(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get pricings from storage")
+	}
+
+	if !pricings.HasTypeRules() {
+		return pricings, nil, nil
+	}
+
+	s.mu.Lock()
+	currencies := s.currencies
+	s.mu.Unlock()
+
+	if currencies != nil {
+		return pricings, currencies, nil
+	}
+
+	currencies, err = s.currencyStorage.GetCurrencies(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get currencies from storage")
+	}
+
+	s.mu.Lock()
+	s.currencies = currencies
+	s.mu.Unlock()
+
+	return pricings, currencies, nil
+}
+
+func applyPricing(
+	res dto.ConversionResult,
+	pricings pricingentity.Pricings,
+	currencies entity.Currencies,
+	req dto.Conversion,
+) dto.ConversionResult {
+	from, ok := currencies.ByCode(req.From)
+	if !ok {
+		from = entity.Currency{Code: req.From}
+	}
+
+	to, ok := currencies.ByCode(req.To)
+	if !ok {
+		to = entity.Currency{Code: req.To}
+	}
+
+	pricing, _ := pricings.Find(from, to)
+	charge := pricing.Apply(res.Result)
+
+	res.SpreadPercent = pricing.SpreadPercent
+	res.SpreadAmount = charge.SpreadAmount
+	res.Fee = charge.Fee
+	res.Net = charge.Net
+	res.FixedFee = pricing.FixedFee
+	res.MinFee = pricing.MinFee
+
+	return res
+}
+
+func (s *Svc) conversionResult(
+	req dto.Conversion,
+	v dto.CurrencyStorageDTO,
+	ok bool,
+) (dto.ConversionResult, error) {
+	if !ok || (v.LastError != "" && s.isExpired(v)) {
+		return dto.ConversionResult{}, entity.ErrCurrencyNotAvailable
+	}
+
+	resp := dto.ConversionResult{
+		Rate:        v.Course,
+		Amount:      req.Amount,
+		Result:      v.Course.Mul(req.Amount),
+		AsOf:        v.FetchedAt,
+		Source:      v.Provider,
+		CycleID:     v.CycleID,
+		IsSynthetic: v.IsSynthetic,
+		IsStale:     v.LastError != "",
+	}
+
+	if v.IsSynthetic {
+		resp.Via = v.Pivot
+	}
+
+	return resp, nil
+}
+
+// UpdateCourses runs the update courses cycle and logs its summary.
+func (s *Svc) UpdateCourses(ctx context.Context) error {
+	summary, err := s.RunUpdateCycle(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.l.Info().
+		Str("cycleId", summary.CycleID.String()).
+		Int("succeeded", summary.Succeeded).
+		Int("failed", summary.Failed).
+		Int("skipped", summary.Skipped).
+		Dur("duration", summary.Duration).
+		Msg("update courses cycle finished")
+
+	return nil
+}
+
+// RunUpdateCycle fetches the due pairs by the pool of workers and derives synthetic pairs.
+// Fetching is limited by the cycle timeout, pairs which were not fetched in time are failed.
+// The cycle is rejected with ErrUpdateInProgress while the previous one is still running.
+func (s *Svc) RunUpdateCycle(ctx context.Context) (dto.UpdateCycleSummary, error) {
+	if !s.updating.CompareAndSwap(false, true) {
+		return dto.UpdateCycleSummary{}, ErrUpdateInProgress
+	}
+	defer s.updating.Store(false)
+
+	start := time.Now()
+
 	currencies, err := s.currencyStorage.GetCurrencies(ctx)
 	if err != nil {
-		return errors.Wrap(err, "get currencies from storage")
+		return dto.UpdateCycleSummary{}, errors.Wrap(err, "get currencies from storage")
+	}
+
+	s.mu.Lock()
+	s.currencies = currencies
+	s.mu.Unlock()
+
+	registered, err := s.pairRepo.GetPairs(ctx)
+	if err != nil {
+		return dto.UpdateCycleSummary{}, errors.Wrap(err, "get pairs from storage")
+	}
+
+	summary := dto.UpdateCycleSummary{CycleID: uuid.New()}
+	pairs, known := directPairs(currencies, registered, s.opts.PivotCode, s.opts.PairingRules)
+	due := s.duePairs(pairs, time.Now())
+	summary.Skipped = len(pairs) - len(due)
+
+	fetchCtx := ctx
+
+	if s.opts.CycleTimeout > 0 {
+		var cancel context.CancelFunc
+
+		fetchCtx, cancel = context.WithTimeout(ctx, s.opts.CycleTimeout)
+		defer cancel()
+	}
+
+	for res := range s.updateBatches(ctx, fetchCtx, summary.CycleID, pairBatches(due)) {
+		summary.Succeeded += res.Succeeded
+		summary.Failed += res.Failed
+		summary.Skipped += res.Skipped
+	}
+
+	synthetic := s.updateSyntheticCourses(ctx, summary.CycleID, currencies, pairs, known)
+
+	// courses of deleted currencies, of disabled or removed pairs and of pairs the rules
+	// don't allow are neither fetched nor derived, they are dropped instead of being served
+	current := make([]dto.CurrencyPair, 0, len(pairs)+len(synthetic))
+	for _, p := range pairs {
+		current = append(current, dto.CurrencyPair{From: p.from.Code, To: p.to.Code})
+	}
+
+	s.courseStorage.Retain(ctx, append(current, synthetic...))
+
+	summary.Duration = time.Since(start)
+
+	return summary, nil
+}
+
+// updateBatches hands the batches to the pool of workers and returns the channel
+// of their results which is closed when every batch is done.
+func (s *Svc) updateBatches(
+	ctx, fetchCtx context.Context,
+	cycleID uuid.UUID,
+	batches []pairBatch,
+) <-chan dto.UpdateCycleSummary {
+	jobs := make(chan pairBatch)
+	results := make(chan dto.UpdateCycleSummary, len(batches))
+
+	workers := s.opts.UpdateWorkers
+	if workers <= 0 {
+		workers = defaultUpdateWorkers
+	}
+
+	wg := &sync.WaitGroup{}
+
+	for range min(workers, len(batches)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for b := range jobs {
+				results <- s.updateBatch(ctx, fetchCtx, cycleID, b)
+			}
+		}()
+	}
+
+	go func() {
+		for _, b := range batches {
+			jobs <- b
+		}
+
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+type currencyPair struct {
+	from entity.Currency
+	to   entity.Currency
+	pair pairentity.Pair
+}
+
+// pairBatch is the pairs of one base currency fetched from the same preferred provider by one request.
+type pairBatch struct {
+	base     string
+	provider string
+	pairs    []currencyPair
+}
+
+// pairBatches groups the pairs by their base currency and preferred provider keeping the order of pairs.
+func pairBatches(pairs []currencyPair) []pairBatch {
+	var batches []pairBatch
+
+	idx := make(map[string]int)
+
+	for _, p := range pairs {
+		key := p.from.Code + "_" + p.pair.Provider
+
+		i, ok := idx[key]
+		if !ok {
+			i = len(batches)
+			idx[key] = i
+			batches = append(batches, pairBatch{base: p.from.Code, provider: p.pair.Provider})
+		}
+
+		batches[i].pairs = append(batches[i].pairs, p)
+	}
+
+	return batches
+}
+
+// directPairs returns the enabled registered pairs fetched from the courses providers,
+// pairs of currencies which are not in the catalogue or whose types are not paired by
+// the rules are skipped. Every currency is fetched to and from the pivot, since synthetic
+// pairs are derived from these legs; a registered leg keeps its own settings, so a
+// disabled one is not fetched. The keys of all registered pairs, enabled or not, are
+// returned as well.
+func directPairs(
+	currencies entity.Currencies,
+	registered pairentity.Pairs,
+	pivotCode string,
+	rules entity.PairingRules,
+) ([]currencyPair, map[string]struct{}) {
+	pairs := make([]currencyPair, 0, len(registered))
+	known := make(map[string]struct{}, len(registered))
+
+	for _, p := range registered {
+		known[pairKey(p.CodeFrom, p.CodeTo)] = struct{}{}
+
+		if !p.Enabled {
+			continue
+		}
+
+		from, ok := currencies.ByCode(p.CodeFrom)
+		if !ok {
+			continue
+		}
+
+		to, ok := currencies.ByCode(p.CodeTo)
+		if !ok {
+			continue
+		}
+
+		if from.Code != pivotCode && to.Code != pivotCode && !rules.Pairs(from.Type, to.Type) {
+			continue
+		}
+
+		pairs = append(pairs, currencyPair{from: from, to: to, pair: p})
+	}
+
+	pivot, ok := currencies.ByCode(pivotCode)
+	if !ok {
+		return pairs, known
 	}
 
 	for _, c := range currencies {
-		if c.IsFiat() {
-			fiatCur = append(fiatCur, c)
+		if c.Code == pivot.Code {
+			continue
+		}
+
+		for _, leg := range [][2]entity.Currency{{c, pivot}, {pivot, c}} {
+			from, to := leg[0], leg[1]
+			if _, ok = known[pairKey(from.Code, to.Code)]; ok {
+				continue
+			}
+
+			pairs = append(pairs, currencyPair{
+				from: from,
+				to:   to,
+				pair: pairentity.Pair{CodeFrom: from.Code, CodeTo: to.Code, Enabled: true},
+			})
+		}
+	}
+
+	return pairs, known
+}
 
+// duePairs returns the pairs whose refresh interval has passed since
+// the last fetch and marks them fetched at now.
+func (s *Svc) duePairs(pairs []currencyPair, now time.Time) []currencyPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []currencyPair
+
+	for _, p := range pairs {
+		key := pairKey(p.from.Code, p.to.Code)
+		if !p.pair.IsDue(s.lastFetched[key], now) {
 			continue
 		}
 
-		cryptoCur = append(cryptoCur, c)
+		s.lastFetched[key] = now
+		res = append(res, p)
 	}
 
-	for _, f := range fiatCur {
-		for _, c := range cryptoCur {
-			s.wg.Add(2) //nolint:mnd
-			go s.updateCourse(ctx, f, c, s.wg)
-			go s.updateCourse(ctx, c, f, s.wg)
+	return res
+}
+
+// updateSyntheticCourses derives every pair that is neither registered nor fetched
+// directly as the product of the from→pivot and pivot→to legs and returns the derived
+// pairs. A registered pair is served only as configured, so a disabled one is not derived
+// either; pairs whose types are not paired by the rules are not derived, and neither are
+// pairs with a leg which is not fetched.
+func (s *Svc) updateSyntheticCourses(
+	ctx context.Context,
+	cycleID uuid.UUID,
+	currencies entity.Currencies,
+	direct []currencyPair,
+	registered map[string]struct{},
+) []dto.CurrencyPair {
+	if _, ok := currencies.ByCode(s.opts.PivotCode); !ok {
+		return nil
+	}
+
+	var synthetic []dto.CurrencyPair
+
+	fetched := make(map[string]struct{}, len(direct))
+	for _, p := range direct {
+		fetched[pairKey(p.from.Code, p.to.Code)] = struct{}{}
+	}
+
+	for _, from := range currencies {
+		for _, to := range currencies {
+			if from.Code == to.Code || from.Code == s.opts.PivotCode || to.Code == s.opts.PivotCode {
+				continue
+			}
+
+			key := pairKey(from.Code, to.Code)
+			if _, ok := fetched[key]; ok {
+				continue
+			}
+
+			if _, ok := registered[key]; ok {
+				continue
+			}
+
+			if !s.opts.PairingRules.Pairs(from.Type, to.Type) {
+				continue
+			}
+
+			_, firstLeg := fetched[pairKey(from.Code, s.opts.PivotCode)]
+			_, secondLeg := fetched[pairKey(s.opts.PivotCode, to.Code)]
+
+			if !firstLeg || !secondLeg {
+				continue
+			}
+
+			data := dto.CurrencyStorageDTO{
+				IsSynthetic: true,
+				Pivot:       s.opts.PivotCode,
+				CycleID:     cycleID,
+			}
+
+			first, firstOk := s.courseStorage.Get(ctx, from.Code, s.opts.PivotCode)
+			second, secondOk := s.courseStorage.Get(ctx, s.opts.PivotCode, to.Code)
+
+			if firstOk && secondOk {
+				data.Course = first.Course.Mul(second.Course)
+				data.IsAvailable = true
+				data.FetchedAt = first.FetchedAt
+				data.LastError = first.LastError
+				data.Provider = first.Provider
+				data.Sources = min(first.Sources, second.Sources)
+
+				if second.Provider != first.Provider {
+					data.Provider = first.Provider + "," + second.Provider
+				}
+
+				if second.FetchedAt.Before(first.FetchedAt) {
+					data.FetchedAt = second.FetchedAt
+				}
+
+				if data.LastError == "" {
+					data.LastError = second.LastError
+				}
+			}
+
+			s.courseStorage.Set(ctx, from.Code, to.Code, data)
+
+			synthetic = append(synthetic, dto.CurrencyPair{From: from.Code, To: to.Code})
 		}
 	}
 
-	s.wg.Wait()
+	return synthetic
+}
 
-	return nil
+func pairKey(codeFrom, codeTo string) string {
+	return codeFrom + "_" + codeTo
 }
 
-func (s *Svc) updateCourse(ctx context.Context, from entity.Currency, to entity.Currency, wg *sync.WaitGroup) {
-	defer wg.Done()
+// updateBatch fetches the courses of the batch by one request to a provider,
+// pairs of unavailable currencies are disabled without being asked. Courses are
+// fetched with fetchCtx and saved with ctx, so the cycle deadline does not drop
+// the courses which are already fetched.
+func (s *Svc) updateBatch(
+	ctx, fetchCtx context.Context,
+	cycleID uuid.UUID,
+	b pairBatch,
+) dto.UpdateCycleSummary {
+	var res dto.UpdateCycleSummary
+
+	targets := make([]string, 0, len(b.pairs))
+
+	for _, p := range b.pairs {
+		if !p.from.IsAvailable || !p.to.IsAvailable {
+			s.courseStorage.Set(ctx, p.from.Code, p.to.Code, dto.CurrencyStorageDTO{
+				IsAvailable: false,
+			})
+
+			res.Skipped++
+
+			continue
+		}
+
+		targets = append(targets, p.to.Code)
+	}
+
+	if len(targets) == 0 {
+		return res
+	}
 
 	var (
-		course      float64
-		isAvailable bool
-		err         error
+		courses map[string]Course
+		errs    map[string]error
 	)
 
-	if from.IsAvailable && to.IsAvailable {
-		isAvailable = true
+	if err := fetchCtx.Err(); err != nil {
+		errs = make(map[string]error, len(targets))
+		for _, code := range targets {
+			errs[code] = errors.Wrap(err, "update courses cycle timeout")
+		}
+	} else {
+		courses, errs = s.providers.FetchMulti(fetchCtx, b.base, targets, b.provider)
 	}
 
-	if isAvailable {
-		course, err = s.currenciesAPI.Convert(ctx, from.Code, to.Code, 1)
-		if err != nil {
-			isAvailable = false
+	now := time.Now().UTC()
+
+	for _, code := range targets {
+		if err, ok := errs[code]; ok {
+			s.l.Err(err).Msgf("convert currencies through api: from %s to %s", b.base, code)
+
+			s.keepLastKnownCourse(ctx, b.base, code, err)
 
-			s.l.Err(err).Msgf("convert currencies through api: from %s to %s", from.Code, to.Code)
+			res.Failed++
+
+			continue
 		}
+
+		s.saveCourse(ctx, cycleID, b.base, code, courses[code], now)
+
+		res.Succeeded++
 	}
 
-	s.courseStorage.Set(ctx, from.Code, to.Code, dto.CurrencyStorageDTO{
-		Course:      decimal.NewFromFloat(course),
-		IsAvailable: isAvailable,
+	return res
+}
+
+func (s *Svc) saveCourse(ctx context.Context, cycleID uuid.UUID, codeFrom, codeTo string, course Course, now time.Time) {
+	s.courseStorage.Set(ctx, codeFrom, codeTo, dto.CurrencyStorageDTO{
+		Course:      course.Value,
+		IsAvailable: true,
+		FetchedAt:   now,
+		Provider:    course.Provider,
+		CycleID:     cycleID,
+		Sources:     course.Sources,
 	})
+
+	err := s.ratesRepo.SaveRate(ctx, rateentity.Rate{
+		CodeFrom:  codeFrom,
+		CodeTo:    codeTo,
+		Rate:      course.Value,
+		Provider:  course.Provider,
+		CycleID:   cycleID,
+		CreatedAt: now,
+	})
+	if err != nil {
+		s.l.Err(err).Msgf("save rate to history: from %s to %s", codeFrom, codeTo)
+	}
+
+	s.alerts.Evaluate(ctx, dto.RateTick{
+		From: codeFrom,
+		To:   codeTo,
+		Rate: course.Value,
+		At:   now,
+	})
+}
+
+// keepLastKnownCourse leaves the previous course available and marks it stale
+// until it is older than the stale max age, after that the pair is disabled.
+func (s *Svc) keepLastKnownCourse(ctx context.Context, codeFrom, codeTo string, err error) {
+	prev, ok := s.courseStorage.Get(ctx, codeFrom, codeTo)
+	if ok && !s.isExpired(prev) {
+		prev.LastError = err.Error()
+		s.courseStorage.Set(ctx, codeFrom, codeTo, prev)
+
+		return
+	}
+
+	s.courseStorage.Set(ctx, codeFrom, codeTo, dto.CurrencyStorageDTO{
+		IsAvailable: false,
+		LastError:   err.Error(),
+	})
+}
+
+func (s *Svc) isExpired(v dto.CurrencyStorageDTO) bool {
+	return time.Since(v.FetchedAt) > s.opts.StaleMaxAge
+}
+
+// pageCursor is the position behind the last currency of a page. Sort is kept
+// to reject the cursor when it is used with another order.
+type pageCursor struct {
+	Sort  entity.SortField `json:"s"`
+	Desc  bool             `json:"d"`
+	Value string           `json:"v"`
+	ID    uuid.UUID        `json:"id"`
+}
+
+func encodeCursor(last entity.Currency, filter entity.CurrencyFilter) string {
+	// marshaling of the struct with string, bool and uuid fields never fails
+	b, _ := json.Marshal(pageCursor{
+		Sort:  filter.SortBy,
+		Desc:  filter.Desc,
+		Value: last.SortValue(filter.SortBy),
+		ID:    last.ID,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(cursor string, filter entity.CurrencyFilter) (entity.CurrencyCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return entity.CurrencyCursor{}, entity.ErrInvalidCursor
+	}
+
+	var c pageCursor
+	if err = json.Unmarshal(b, &c); err != nil {
+		return entity.CurrencyCursor{}, entity.ErrInvalidCursor
+	}
+
+	if c.Sort != filter.SortBy || c.Desc != filter.Desc {
+		return entity.CurrencyCursor{}, entity.ErrInvalidCursor
+	}
+
+	return entity.CurrencyCursor{Value: c.Value, ID: c.ID}, nil
+}
+
+func currencyToDTO(c entity.Currency) dto.CurrencyResp {
+	return dto.CurrencyResp{
+		ID:          c.ID,
+		Name:        c.Name,
+		Code:        c.Code,
+		Type:        int(c.Type),
+		IsAvailable: c.IsAvailable,
+		Version:     c.Version,
+		DeletedAt:   c.DeletedAt,
+	}
 }
@@ -12,9 +12,15 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/veleton777/test_work_blum/internal/currency/v1"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/storage/memory"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	pairentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	rateentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
 	"github.com/veleton777/test_work_blum/internal/dto"
+	"strings"
 	"testing"
+	"time"
 )
 
 type CurrencyServiceTestSuite struct {
@@ -23,6 +29,10 @@ type CurrencyServiceTestSuite struct {
 	mockCourseStorage *mocks.CourseStorage
 	mockCurrencyRepo  *mocks.Repo
 	mockCurrencyAPI   *mocks.CurrenciesAPI
+	mockRatesRepo     *mocks.RatesRepo
+	mockPricingRepo   *mocks.PricingRepo
+	mockPairRepo      *mocks.PairRepo
+	mockAlerts        *mocks.AlertEvaluator
 
 	buf *bytes.Buffer
 }
@@ -34,10 +44,22 @@ func (s *CurrencyServiceTestSuite) SetupTest() {
 	s.mockCourseStorage = mocks.NewCourseStorage(s.T())
 	s.mockCurrencyRepo = mocks.NewRepo(s.T())
 	s.mockCurrencyAPI = mocks.NewCurrenciesAPI(s.T())
+	s.mockRatesRepo = mocks.NewRatesRepo(s.T())
+	s.mockPricingRepo = mocks.NewPricingRepo(s.T())
+	s.mockPairRepo = mocks.NewPairRepo(s.T())
+	s.mockAlerts = mocks.NewAlertEvaluator(s.T())
+	s.mockCurrencyAPI.On("Name").Return("fastforex").Maybe()
+	s.mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return().Maybe()
+	s.mockCourseStorage.On("Retain", mock.Anything, mock.Anything).Return().Maybe()
 	s.svc = currency.NewCurrencySvc(
 		s.mockCurrencyRepo,
-		s.mockCurrencyAPI,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI),
 		s.mockCourseStorage,
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{StaleMaxAge: 10 * time.Minute, HistoryMaxGap: 5 * time.Minute},
 		&l,
 	)
 }
@@ -46,6 +68,18 @@ func TestServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(CurrencyServiceTestSuite))
 }
 
+// registeredPairs returns enabled pairs fetched every cycle, each pair is given as "FROM/TO".
+func registeredPairs(pairs ...string) pairentity.Pairs {
+	res := make(pairentity.Pairs, 0, len(pairs))
+
+	for _, p := range pairs {
+		from, to, _ := strings.Cut(p, "/")
+		res = append(res, pairentity.Pair{ID: uuid.New(), CodeFrom: from, CodeTo: to, Enabled: true})
+	}
+
+	return res
+}
+
 func (s *CurrencyServiceTestSuite) TestCreateCurrency_NoErr() {
 	ctx := context.Background()
 
@@ -123,17 +157,20 @@ func (s *CurrencyServiceTestSuite) TestUpdateCurrency_NoErr() {
 		Code:        "code-1",
 		Type:        1,
 		IsAvailable: true,
+		Version:     3,
 	}).
-		Return(nil).Once()
+		Return(int64(4), nil).Once()
 
-	err := s.svc.UpdateCurrency(ctx, dto.Currency{
+	version, err := s.svc.UpdateCurrency(ctx, dto.Currency{
 		ID:          id,
 		Name:        "test-1",
 		Code:        "code-1",
 		Type:        1,
 		IsAvailable: true,
+		Version:     3,
 	})
 	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(4), version)
 
 	s.mockCurrencyRepo.AssertExpectations(s.T())
 }
@@ -170,7 +207,7 @@ func (s *CurrencyServiceTestSuite) TestUpdateCurrency_Err() {
 					Type:        1,
 					IsAvailable: true,
 				}).
-					Return(errors.New("pg err")).Once()
+					Return(int64(0), errors.New("pg err")).Once()
 			},
 			req: dto.Currency{
 				ID:          testID,
@@ -181,13 +218,31 @@ func (s *CurrencyServiceTestSuite) TestUpdateCurrency_Err() {
 			},
 			expErr: errors.New("pg err"),
 		},
+		{
+			name: "version_conflict",
+			mockFunc: func() {
+				s.mockCurrencyRepo.On("UpdateCurrency", ctx, mock.MatchedBy(func(c entity.Currency) bool {
+					return c.ID == testID && c.Version == 1
+				})).
+					Return(int64(0), entity.ErrVersionConflict).Once()
+			},
+			req: dto.Currency{
+				ID:          testID,
+				Name:        "test-1",
+				Code:        "code-1",
+				Type:        1,
+				IsAvailable: true,
+				Version:     1,
+			},
+			expErr: entity.ErrVersionConflict,
+		},
 	}
 
 	for _, tc := range testCases {
 		s.T().Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			err := s.svc.UpdateCurrency(ctx, tc.req)
+			_, err := s.svc.UpdateCurrency(ctx, tc.req)
 			require.Error(s.T(), err)
 			require.ErrorContains(t, err, tc.expErr.Error())
 
@@ -196,18 +251,140 @@ func (s *CurrencyServiceTestSuite) TestUpdateCurrency_Err() {
 	}
 }
 
+func (s *CurrencyServiceTestSuite) TestPatchCurrency_NoErr() {
+	ctx := context.Background()
+
+	id := uuid.New()
+	isAvailable := false
+	currencyType := 2
+	fiat := entity.TypeFiat
+
+	s.mockCurrencyRepo.On("PatchCurrency", ctx, entity.CurrencyPatch{
+		ID:          id,
+		Type:        &fiat,
+		IsAvailable: &isAvailable,
+		Version:     3,
+	}).
+		Return(int64(4), nil).Once()
+
+	version, err := s.svc.PatchCurrency(ctx, dto.CurrencyPatch{
+		ID:          id,
+		Type:        &currencyType,
+		IsAvailable: &isAvailable,
+		Version:     3,
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(4), version)
+
+	s.mockCurrencyRepo.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestPatchCurrency_Err() {
+	ctx := context.Background()
+
+	id := uuid.New()
+	name := "test-1"
+	invalidType := 555
+
+	_, err := s.svc.PatchCurrency(ctx, dto.CurrencyPatch{ID: id, Type: &invalidType, Version: 1})
+	require.ErrorIs(s.T(), err, entity.ErrInvalidCurrencyType)
+
+	s.mockCurrencyRepo.On("PatchCurrency", ctx, entity.CurrencyPatch{ID: id, Name: &name, Version: 1}).
+		Return(int64(0), entity.ErrVersionConflict).Once()
+
+	_, err = s.svc.PatchCurrency(ctx, dto.CurrencyPatch{ID: id, Name: &name, Version: 1})
+	require.ErrorIs(s.T(), err, entity.ErrVersionConflict)
+
+	s.mockCurrencyRepo.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestImportCurrencies_NoErr() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("ImportCurrencies", ctx, mock.MatchedBy(func(c entity.Currencies) bool {
+		return len(c) == 3 && c[0].Code == "BTC" && c[0].Type == entity.TypeCrypto && c[0].ID != uuid.Nil &&
+			c[2].Code == "USD" && !c[2].IsAvailable
+	}), true).
+		Return([]entity.ImportAction{entity.ImportCreated, entity.ImportUnchanged, entity.ImportUpdated}, nil).Once()
+
+	res, err := s.svc.ImportCurrencies(ctx, dto.ImportCurrenciesReq{
+		Records: []dto.CurrencyRecord{
+			{Code: "BTC", Name: "Bitcoin", Type: 1, IsAvailable: true},
+			{Code: "ETH", Name: "Ethereum", Type: 1, IsAvailable: true},
+			{Code: "USD", Name: "Dollar", Type: 2},
+		},
+		DryRun: true,
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), dto.ImportReport{DryRun: true, Created: 1, Updated: 1, Unchanged: 1}, res)
+
+	s.mockCurrencyRepo.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestImportCurrencies_RowErrors() {
+	ctx := context.Background()
+
+	res, err := s.svc.ImportCurrencies(ctx, dto.ImportCurrenciesReq{
+		Records: []dto.CurrencyRecord{
+			{Code: "BTC", Name: "Bitcoin", Type: 1},
+			{Code: "XXX", Name: "Unknown", Type: 9},
+			{Code: "BTC", Name: "Bitcoin", Type: 1},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []dto.ImportRowError{
+		{Row: 2, Code: "XXX", Error: "invalid currency type"},
+		{Row: 3, Code: "BTC", Error: "duplicate code of row 1"},
+	}, res.Errors)
+
+	s.mockCurrencyRepo.AssertNotCalled(s.T(), "ImportCurrencies", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CurrencyServiceTestSuite) TestImportCurrencies_Err() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("ImportCurrencies", ctx, mock.Anything, false).
+		Return(nil, errors.New("pg err")).Once()
+
+	_, err := s.svc.ImportCurrencies(ctx, dto.ImportCurrenciesReq{
+		Records: []dto.CurrencyRecord{{Code: "BTC", Name: "Bitcoin", Type: 1}},
+	})
+	require.ErrorContains(s.T(), err, "pg err")
+}
+
+func (s *CurrencyServiceTestSuite) TestExportCurrencies() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "Dollar", Code: "USD", Type: entity.TypeFiat, Version: 2},
+			{ID: uuid.New(), Name: "Bitcoin", Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true},
+		}, nil).Once()
+
+	res, err := s.svc.ExportCurrencies(ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []dto.CurrencyRecord{
+		{Code: "BTC", Name: "Bitcoin", Type: 1, IsAvailable: true},
+		{Code: "USD", Name: "Dollar", Type: 2},
+	}, res)
+}
+
 func (s *CurrencyServiceTestSuite) TestDeleteCurrency_NoErr() {
 	ctx := context.Background()
 
 	id := uuid.New()
 
-	s.mockCurrencyRepo.On("DeleteCurrency", ctx, id).
+	s.mockCurrencyRepo.On("GetCurrency", ctx, id).
+		Return(entity.Currency{ID: id, Code: "BTC"}, nil).Once()
+	s.mockCurrencyRepo.On("DeleteCurrency", ctx, id, int64(2)).
 		Return(nil).Once()
+	s.mockCourseStorage.On("RemoveCurrency", ctx, "BTC").Return().Once()
 
-	err := s.svc.DeleteCurrency(ctx, id)
+	err := s.svc.DeleteCurrency(ctx, id, 2)
 	require.NoError(s.T(), err)
 
 	s.mockCurrencyRepo.AssertExpectations(s.T())
+	s.mockCourseStorage.AssertExpectations(s.T())
 }
 
 func (s *CurrencyServiceTestSuite) TestDeleteCurrency_Err() {
@@ -215,16 +392,202 @@ func (s *CurrencyServiceTestSuite) TestDeleteCurrency_Err() {
 
 	id := uuid.New()
 
-	s.mockCurrencyRepo.On("DeleteCurrency", ctx, id).
+	s.mockCurrencyRepo.On("GetCurrency", ctx, id).
+		Return(entity.Currency{ID: id, Code: "BTC"}, nil).Once()
+	s.mockCurrencyRepo.On("DeleteCurrency", ctx, id, int64(2)).
 		Return(errors.New("pg err")).Once()
 
-	err := s.svc.DeleteCurrency(ctx, id)
+	err := s.svc.DeleteCurrency(ctx, id, 2)
 	require.Error(s.T(), err)
 	require.ErrorContains(s.T(), err, "pg err")
 
 	s.mockCurrencyRepo.AssertExpectations(s.T())
 }
 
+func (s *CurrencyServiceTestSuite) TestRestoreCurrency() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockCurrencyRepo.On("RestoreCurrency", ctx, id).
+		Return(entity.Currency{ID: id, Name: "Bitcoin", Code: "BTC", Type: entity.TypeCrypto, Version: 3}, nil).Once()
+
+	res, err := s.svc.RestoreCurrency(ctx, id)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), dto.CurrencyResp{ID: id, Name: "Bitcoin", Code: "BTC", Type: 1, Version: 3}, res)
+
+	s.mockCurrencyRepo.On("RestoreCurrency", ctx, id).
+		Return(entity.Currency{}, entity.ErrCurrencyNotDeleted).Once()
+
+	_, err = s.svc.RestoreCurrency(ctx, id)
+	require.ErrorIs(s.T(), err, entity.ErrCurrencyNotDeleted)
+}
+
+func (s *CurrencyServiceTestSuite) TestPurgeCurrency() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockCurrencyRepo.On("PurgeCurrency", ctx, id).Return(nil).Once()
+
+	require.NoError(s.T(), s.svc.PurgeCurrency(ctx, id))
+
+	s.mockCurrencyRepo.On("PurgeCurrency", ctx, id).Return(errors.New("pg err")).Once()
+
+	require.ErrorContains(s.T(), s.svc.PurgeCurrency(ctx, id), "pg err")
+}
+
+func (s *CurrencyServiceTestSuite) TestGetCurrency_NotFound() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockCurrencyRepo.On("GetCurrency", ctx, id).
+		Return(entity.Currency{}, entity.ErrEntityNotFound).Once()
+
+	_, err := s.svc.GetCurrency(ctx, id)
+	require.ErrorIs(s.T(), err, entity.ErrEntityNotFound)
+}
+
+func (s *CurrencyServiceTestSuite) TestGetCurrencyByCode_NoErr() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockCurrencyRepo.On("GetCurrencyByCode", ctx, "BTC").
+		Return(entity.Currency{ID: id, Name: "Bitcoin", Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true}, nil).
+		Once()
+
+	res, err := s.svc.GetCurrencyByCode(ctx, "BTC")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), dto.CurrencyResp{ID: id, Name: "Bitcoin", Code: "BTC", Type: 1, IsAvailable: true}, res)
+}
+
+func (s *CurrencyServiceTestSuite) TestGetCurrencyAudit() {
+	ctx := context.Background()
+
+	id := uuid.New()
+	createdAt := time.Date(2024, 6, 14, 10, 0, 0, 0, time.UTC)
+
+	before := entity.Currency{ID: id, Name: "Bitcoin", Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true, Version: 1}
+	after := before
+	after.IsAvailable = false
+	after.Version = 2
+
+	s.mockCurrencyRepo.On("GetCurrencyAudit", ctx, id).
+		Return([]entity.AuditRecord{
+			{ID: id, CurrencyID: id, Action: entity.AuditCreate, Actor: "admin", After: &before, CreatedAt: createdAt},
+			{
+				ID:         id,
+				CurrencyID: id,
+				Action:     entity.AuditUpdate,
+				Actor:      "admin",
+				RequestID:  "req-1",
+				Before:     &before,
+				After:      &after,
+				CreatedAt:  createdAt,
+			},
+		}, nil).Once()
+
+	res, err := s.svc.GetCurrencyAudit(ctx, id)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), res, 2)
+
+	require.Equal(s.T(), "create", res[0].Action)
+	require.Nil(s.T(), res[0].Before)
+	require.Equal(s.T(), dto.CurrencyResp{ID: id, Name: "Bitcoin", Code: "BTC", Type: 1, IsAvailable: true, Version: 1}, *res[0].After)
+
+	require.Equal(s.T(), "update", res[1].Action)
+	require.Equal(s.T(), "req-1", res[1].RequestID)
+	require.True(s.T(), res[1].Before.IsAvailable)
+	require.False(s.T(), res[1].After.IsAvailable)
+	require.Equal(s.T(), int64(2), res[1].After.Version)
+}
+
+func (s *CurrencyServiceTestSuite) TestListCurrencies_Pages() {
+	ctx := context.Background()
+
+	isAvailable := true
+	btc := entity.Currency{ID: uuid.New(), Name: "Bitcoin", Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true}
+	eth := entity.Currency{ID: uuid.New(), Name: "Ethereum", Code: "ETH", Type: entity.TypeCrypto, IsAvailable: true}
+	usdt := entity.Currency{ID: uuid.New(), Name: "Tether", Code: "USDT", Type: entity.TypeCrypto, IsAvailable: true}
+
+	s.mockCurrencyRepo.On("ListCurrencies", ctx, entity.CurrencyFilter{
+		Type:        entity.TypeCrypto,
+		IsAvailable: &isAvailable,
+		SortBy:      entity.SortByName,
+		Desc:        true,
+		Limit:       3,
+	}).Return(entity.Currencies{usdt, eth, btc}, nil).Once()
+
+	page, err := s.svc.ListCurrencies(ctx, dto.ListCurrenciesReq{
+		Type:        1,
+		IsAvailable: &isAvailable,
+		Sort:        "-name",
+		Limit:       2,
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page.Currencies, 2)
+	require.Equal(s.T(), "USDT", page.Currencies[0].Code)
+	require.Equal(s.T(), "ETH", page.Currencies[1].Code)
+	require.NotEmpty(s.T(), page.NextCursor)
+
+	s.mockCurrencyRepo.On("ListCurrencies", ctx, entity.CurrencyFilter{
+		Type:        entity.TypeCrypto,
+		IsAvailable: &isAvailable,
+		SortBy:      entity.SortByName,
+		Desc:        true,
+		After:       &entity.CurrencyCursor{Value: "Ethereum", ID: eth.ID},
+		Limit:       3,
+	}).Return(entity.Currencies{btc}, nil).Once()
+
+	page, err = s.svc.ListCurrencies(ctx, dto.ListCurrenciesReq{
+		Type:        1,
+		IsAvailable: &isAvailable,
+		Sort:        "-name",
+		Cursor:      page.NextCursor,
+		Limit:       2,
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page.Currencies, 1)
+	require.Equal(s.T(), "BTC", page.Currencies[0].Code)
+	require.Empty(s.T(), page.NextCursor)
+}
+
+func (s *CurrencyServiceTestSuite) TestListCurrencies_DefaultFilter() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("ListCurrencies", ctx, entity.CurrencyFilter{
+		SortBy: entity.SortByCode,
+		Limit:  51,
+	}).Return(entity.Currencies{}, nil).Once()
+
+	page, err := s.svc.ListCurrencies(ctx, dto.ListCurrenciesReq{})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), page.Currencies)
+	require.NotNil(s.T(), page.Currencies)
+	require.Empty(s.T(), page.NextCursor)
+}
+
+func (s *CurrencyServiceTestSuite) TestListCurrencies_InvalidCursor() {
+	ctx := context.Background()
+
+	btc := entity.Currency{ID: uuid.New(), Name: "Bitcoin", Code: "BTC", Type: entity.TypeCrypto}
+	eth := entity.Currency{ID: uuid.New(), Name: "Ethereum", Code: "ETH", Type: entity.TypeCrypto}
+
+	s.mockCurrencyRepo.On("ListCurrencies", ctx, mock.Anything).
+		Return(entity.Currencies{btc, eth}, nil).Once()
+
+	page, err := s.svc.ListCurrencies(ctx, dto.ListCurrenciesReq{Limit: 1})
+	require.NoError(s.T(), err)
+
+	_, err = s.svc.ListCurrencies(ctx, dto.ListCurrenciesReq{Cursor: "not a cursor"})
+	require.ErrorIs(s.T(), err, entity.ErrInvalidCursor)
+
+	_, err = s.svc.ListCurrencies(ctx, dto.ListCurrenciesReq{Cursor: page.NextCursor, Sort: "-code"})
+	require.ErrorIs(s.T(), err, entity.ErrInvalidCursor)
+}
+
 func (s *CurrencyServiceTestSuite) TestUpdateCourses_NoErr() {
 	ctx := context.Background()
 
@@ -253,36 +616,51 @@ func (s *CurrencyServiceTestSuite) TestUpdateCourses_NoErr() {
 			},
 		}, nil).Once()
 
-	s.mockCurrencyAPI.On("Convert", ctx, "USD", "BTC", float64(1)).
-		Return(0.00045634, nil).Once()
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("USD/BTC", "BTC/USD", "ETH/USD", "USD/ETH"), nil).Once()
 
-	s.mockCurrencyAPI.On("Convert", ctx, "BTC", "USD", float64(1)).
-		Return(float64(70000), nil).Once()
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"BTC"}).
+		Return(map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.00045634")}, nil).Once()
 
-	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", dto.CurrencyStorageDTO{
-		Course:      decimal.NewFromFloat(0.00045634),
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(70000)}, nil).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.RequireFromString("0.00045634"),
 		IsAvailable: true,
-	}).Return().Once()
+		Provider:    "fastforex",
+	})).Return().Once()
 
-	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", dto.CurrencyStorageDTO{
-		Course:      decimal.NewFromFloat(70000),
+	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.NewFromInt(70000),
 		IsAvailable: true,
-	}).Return().Once()
+		Provider:    "fastforex",
+	})).Return().Once()
+
+	s.mockRatesRepo.On("SaveRate", ctx, mock.MatchedBy(func(r rateentity.Rate) bool {
+		return r.CodeFrom == "USD" && r.CodeTo == "BTC" && r.Rate.Equal(decimal.RequireFromString("0.00045634")) &&
+			r.Provider == "fastforex" && r.CycleID != uuid.Nil
+	})).Return(nil).Once()
 
-	s.mockCourseStorage.On("Set", ctx, "ETH", "USD", dto.CurrencyStorageDTO{
-		Course:      decimal.NewFromFloat(0),
+	s.mockRatesRepo.On("SaveRate", ctx, mock.MatchedBy(func(r rateentity.Rate) bool {
+		return r.CodeFrom == "BTC" && r.CodeTo == "USD" && r.Rate.Equal(decimal.NewFromFloat(70000))
+	})).Return(nil).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "ETH", "USD", storedCourse(dto.CurrencyStorageDTO{
 		IsAvailable: false,
-	}).Return().Once()
+	})).Return().Once()
 
-	s.mockCourseStorage.On("Set", ctx, "USD", "ETH", dto.CurrencyStorageDTO{
-		Course:      decimal.NewFromFloat(0),
+	s.mockCourseStorage.On("Set", ctx, "USD", "ETH", storedCourse(dto.CurrencyStorageDTO{
 		IsAvailable: false,
-	}).Return().Once()
+	})).Return().Once()
 
 	err := s.svc.UpdateCourses(ctx)
 	require.NoError(s.T(), err)
 
 	s.mockCourseStorage.AssertExpectations(s.T())
+	s.mockAlerts.AssertCalled(s.T(), "Evaluate", ctx, mock.MatchedBy(func(t dto.RateTick) bool {
+		return t.From == "BTC" && t.To == "USD" && t.Rate.Equal(decimal.NewFromInt(70000)) && !t.At.IsZero()
+	}))
+	s.mockAlerts.AssertNumberOfCalls(s.T(), "Evaluate", 2)
 }
 
 func (s *CurrencyServiceTestSuite) TestUpdateCourses_StorageErr() {
@@ -298,6 +676,180 @@ func (s *CurrencyServiceTestSuite) TestUpdateCourses_StorageErr() {
 	s.mockCourseStorage.AssertExpectations(s.T())
 }
 
+func (s *CurrencyServiceTestSuite) TestUpdateCourses_PairsStorageErr() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).Return(entity.Currencies{}, nil).Once()
+	s.mockPairRepo.On("GetPairs", ctx).Return(nil, errors.New("pg err")).Once()
+
+	err := s.svc.UpdateCourses(ctx)
+	require.ErrorContains(s.T(), err, "pg err")
+}
+
+func (s *CurrencyServiceTestSuite) TestUpdateCourses_RegisteredPairs() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1},
+			{ID: uuid.New(), Name: "ETH", Code: "ETH", Type: 1},
+		}, nil).Twice()
+
+	s.mockPairRepo.On("GetPairs", ctx).
+		Return(pairentity.Pairs{
+			{ID: uuid.New(), CodeFrom: "BTC", CodeTo: "USD", Enabled: true, RefreshInterval: time.Hour},
+			{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC", Enabled: true},
+			{ID: uuid.New(), CodeFrom: "ETH", CodeTo: "USD", Enabled: false},
+			{ID: uuid.New(), CodeFrom: "BTC", CodeTo: "EUR", Enabled: true},
+		}, nil).Twice()
+
+	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", storedCourse(dto.CurrencyStorageDTO{})).Return().Once()
+	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", storedCourse(dto.CurrencyStorageDTO{})).Return().Twice()
+
+	require.NoError(s.T(), s.svc.UpdateCourses(ctx))
+	require.NoError(s.T(), s.svc.UpdateCourses(ctx))
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestUpdateCourses_Batches() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "EUR", Code: "EUR", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1, IsAvailable: true},
+			{ID: uuid.New(), Name: "ETH", Code: "ETH", Type: 1, IsAvailable: true},
+		}, nil).Once()
+
+	pairs := registeredPairs("USD/BTC", "USD/ETH", "USD/EUR")
+	pairs[2].Provider = "cryptocompare"
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(pairs, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"BTC", "ETH"}).
+		Return(map[string]decimal.Decimal{
+			"BTC": decimal.RequireFromString("0.0000145"),
+			"ETH": decimal.RequireFromString("0.0003"),
+		}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"EUR"}).
+		Return(map[string]decimal.Decimal{"EUR": decimal.RequireFromString("0.93")}, nil).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "USD", mock.Anything, mock.Anything).Return().Times(3)
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil).Times(3)
+
+	err := s.svc.UpdateCourses(ctx)
+	require.NoError(s.T(), err)
+
+	s.mockCourseStorage.AssertCalled(s.T(), "Set", ctx, "USD", "ETH", storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.RequireFromString("0.0003"),
+		IsAvailable: true,
+		Provider:    "fastforex",
+	}))
+}
+
+func (s *CurrencyServiceTestSuite) TestUpdateCourses_ProviderFallback() {
+	ctx := context.Background()
+
+	fallbackAPI := mocks.NewCurrenciesAPI(s.T())
+	fallbackAPI.On("Name").Return("cryptocompare").Maybe()
+
+	l := zerolog.New(s.buf)
+	svc := currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI, fallbackAPI),
+		s.mockCourseStorage,
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{},
+		&l,
+	)
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1, IsAvailable: true},
+		}, nil).Once()
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("BTC/USD"), nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(nil, errors.New("timeout")).Once()
+
+	fallbackAPI.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(69000)}, nil).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.NewFromInt(69000),
+		IsAvailable: true,
+		Provider:    "cryptocompare",
+	})).Return().Once()
+
+	s.mockRatesRepo.On("SaveRate", ctx, mock.MatchedBy(func(r rateentity.Rate) bool {
+		return r.CodeFrom == "BTC" && r.CodeTo == "USD" && r.Provider == "cryptocompare"
+	})).Return(nil).Once()
+
+	err := svc.UpdateCourses(ctx)
+	require.NoError(s.T(), err)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestUpdateCourses_Aggregated() {
+	ctx := context.Background()
+
+	secondAPI := mocks.NewCurrenciesAPI(s.T())
+	secondAPI.On("Name").Return("cryptocompare").Maybe()
+
+	l := zerolog.New(s.buf)
+	svc := currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{
+			Aggregate: true,
+			Tolerance: decimal.NewFromInt(1),
+		}, s.mockCurrencyAPI, secondAPI),
+		s.mockCourseStorage,
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{},
+		&l,
+	)
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1, IsAvailable: true},
+		}, nil).Once()
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("BTC/USD"), nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(69000)}, nil).Once()
+
+	secondAPI.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(69100)}, nil).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", mock.MatchedBy(func(v dto.CurrencyStorageDTO) bool {
+		return v.Course.Equal(decimal.NewFromInt(69050)) &&
+			v.Provider == "fastforex,cryptocompare" &&
+			v.Sources == 2
+	})).Return().Once()
+
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil).Once()
+
+	err := svc.UpdateCourses(ctx)
+	require.NoError(s.T(), err)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
 func (s *CurrencyServiceTestSuite) TestUpdateCourses_CurrencyAPIErr() {
 	ctx := context.Background()
 
@@ -319,68 +871,1106 @@ func (s *CurrencyServiceTestSuite) TestUpdateCourses_CurrencyAPIErr() {
 			},
 		}, nil).Once()
 
-	s.mockCurrencyAPI.On("Convert", ctx, "USD", "BTC", float64(1)).
-		Return(0.00045634, nil).Once()
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("USD/BTC", "BTC/USD"), nil).Once()
 
-	s.mockCurrencyAPI.On("Convert", ctx, "BTC", "USD", float64(1)).
-		Return(float64(0), errors.New("api err")).Once()
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"BTC"}).
+		Return(map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.00045634")}, nil).Once()
 
-	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", dto.CurrencyStorageDTO{
-		Course:      decimal.NewFromFloat(0.00045634),
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(nil, errors.New("api err")).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.RequireFromString("0.00045634"),
 		IsAvailable: true,
-	}).Return().Once()
+		Provider:    "fastforex",
+	})).Return().Once()
 
-	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", dto.CurrencyStorageDTO{
-		Course:      decimal.NewFromFloat(0),
+	s.mockCourseStorage.On("Get", ctx, "BTC", "USD").
+		Return(dto.CurrencyStorageDTO{}, false).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", storedCourse(dto.CurrencyStorageDTO{
 		IsAvailable: false,
-	}).Return().Once()
+		LastError:   "api err",
+	})).Return().Once()
+
+	s.mockRatesRepo.On("SaveRate", ctx, mock.MatchedBy(func(r rateentity.Rate) bool {
+		return r.CodeFrom == "USD" && r.CodeTo == "BTC"
+	})).Return(errors.New("pg err")).Once()
 
 	err := s.svc.UpdateCourses(ctx)
 	require.NoError(s.T(), err)
 
+	require.Contains(s.T(), string(s.buf.Bytes()), "save rate to history: from USD to BTC")
 	require.Contains(s.T(), string(s.buf.Bytes()), "convert currencies through api: from BTC to USD")
 	require.Contains(s.T(), string(s.buf.Bytes()), "api err")
 
+	// pricing by types is resolved against the currencies read by the cycle
+	s.mockCourseStorage.On("Get", ctx, "USD", "BTC").
+		Return(dto.CurrencyStorageDTO{Course: decimal.RequireFromString("0.02"), IsAvailable: true}, true).Once()
+	s.mockPricingRepo.On("GetPricings", ctx).
+		Return(pricingentity.Pricings{{TypeFrom: entity.TypeFiat, TypeTo: entity.TypeCrypto, SpreadPercent: decimal.NewFromInt(2)}}, nil).Once()
+
+	res, err := s.svc.Convert(ctx, dto.Conversion{From: "USD", To: "BTC", Amount: decimal.NewFromInt(100)})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "0.04", res.SpreadAmount.String())
+
 	s.mockCourseStorage.AssertExpectations(s.T())
 }
 
-func (s *CurrencyServiceTestSuite) TestConvert_NoErr() {
+func (s *CurrencyServiceTestSuite) TestUpdateCourses_KeepLastKnownCourse() {
 	ctx := context.Background()
-	data := dto.ConvertCurrencyReq{
-		From:   "USD",
+
+	fetchedAt := time.Now().Add(-time.Minute)
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1, IsAvailable: true},
+		}, nil).Once()
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("USD/BTC", "BTC/USD"), nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, mock.Anything, mock.Anything).
+		Return(nil, errors.New("timeout")).Twice()
+
+	s.mockCourseStorage.On("Get", ctx, "USD", "BTC").
+		Return(dto.CurrencyStorageDTO{
+			Course:      decimal.RequireFromString("0.00045634"),
+			IsAvailable: true,
+			FetchedAt:   fetchedAt,
+		}, true).Once()
+
+	s.mockCourseStorage.On("Get", ctx, "BTC", "USD").
+		Return(dto.CurrencyStorageDTO{
+			Course:      decimal.NewFromInt(70000),
+			IsAvailable: true,
+			FetchedAt:   time.Now().Add(-time.Hour),
+		}, true).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", dto.CurrencyStorageDTO{
+		Course:      decimal.RequireFromString("0.00045634"),
+		IsAvailable: true,
+		FetchedAt:   fetchedAt,
+		LastError:   "timeout",
+	}).Return().Once()
+
+	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", dto.CurrencyStorageDTO{
+		IsAvailable: false,
+		LastError:   "timeout",
+	}).Return().Once()
+
+	err := s.svc.UpdateCourses(ctx)
+	require.NoError(s.T(), err)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestRunUpdateCycle_PairingRules() {
+	ctx := context.Background()
+
+	l := zerolog.New(s.buf)
+	svc := currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI),
+		s.mockCourseStorage,
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{PairingRules: entity.PairingRules{entity.TypeCrypto: {entity.TypeFiat}}},
+		&l,
+	)
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: entity.TypeFiat, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true},
+			{ID: uuid.New(), Name: "ETH", Code: "ETH", Type: entity.TypeCrypto, IsAvailable: true},
+		}, nil).Once()
+
+	// crypto is not paired with crypto by the rules, BTC/ETH is not fetched
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("USD/BTC", "BTC/ETH"), nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"BTC"}).
+		Return(map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.0000145")}, nil).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", mock.Anything).Return().Once()
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil).Once()
+
+	summary, err := svc.RunUpdateCycle(ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, summary.Succeeded)
+
+	s.mockCurrencyAPI.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestRunUpdateCycle_Summary() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1, IsAvailable: true},
+			{ID: uuid.New(), Name: "ETH", Code: "ETH", Type: 1, IsAvailable: false},
+		}, nil).Once()
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("USD/BTC", "USD/ETH", "BTC/USD"), nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"BTC"}).
+		Return(map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.0000145")}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(nil, errors.New("api err")).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", mock.Anything).Return().Once()
+	s.mockCourseStorage.On("Set", ctx, "USD", "ETH", dto.CurrencyStorageDTO{IsAvailable: false}).Return().Once()
+	s.mockCourseStorage.On("Get", ctx, "BTC", "USD").Return(dto.CurrencyStorageDTO{}, false).Once()
+	s.mockCourseStorage.On("Set", ctx, "BTC", "USD", mock.Anything).Return().Once()
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil).Once()
+
+	summary, err := s.svc.RunUpdateCycle(ctx)
+	require.NoError(s.T(), err)
+
+	require.NotEqual(s.T(), uuid.Nil, summary.CycleID)
+	require.Equal(s.T(), 1, summary.Succeeded)
+	require.Equal(s.T(), 1, summary.Failed)
+	require.Equal(s.T(), 1, summary.Skipped)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestRunUpdateCycle_Timeout() {
+	ctx := context.Background()
+
+	l := zerolog.New(s.buf)
+	svc := currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI),
+		s.mockCourseStorage,
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{CycleTimeout: time.Nanosecond},
+		&l,
+	)
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1, IsAvailable: true},
+		}, nil).Once()
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("USD/BTC"), nil).Once()
+
+	s.mockCourseStorage.On("Get", ctx, "USD", "BTC").Return(dto.CurrencyStorageDTO{}, false).Once()
+	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", storedCourse(dto.CurrencyStorageDTO{
+		IsAvailable: false,
+		LastError:   "update courses cycle timeout: context deadline exceeded",
+	})).Return().Once()
+
+	summary, err := svc.RunUpdateCycle(ctx)
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), 0, summary.Succeeded)
+	require.Equal(s.T(), 1, summary.Failed)
+
+	s.mockCurrencyAPI.AssertNotCalled(s.T(), "FetchMulti", mock.Anything, mock.Anything, mock.Anything)
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestRunUpdateCycle_InProgress() {
+	ctx := context.Background()
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1, IsAvailable: true},
+		}, nil).Once()
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("USD/BTC"), nil).Once()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"BTC"}).
+		Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.0000145")}, nil).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "USD", "BTC", mock.Anything).Return().Once()
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil).Once()
+
+	done := make(chan error)
+
+	go func() {
+		_, err := s.svc.RunUpdateCycle(ctx)
+		done <- err
+	}()
+
+	<-started
+
+	_, err := s.svc.RunUpdateCycle(ctx)
+	require.ErrorIs(s.T(), err, currency.ErrUpdateInProgress)
+
+	close(release)
+	require.NoError(s.T(), <-done)
+}
+
+func (s *CurrencyServiceTestSuite) TestUpdateCourses_SyntheticPairs() {
+	ctx := context.Background()
+
+	l := zerolog.New(s.buf)
+	svc := currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI),
+		s.mockCourseStorage,
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{PivotCode: "USD"},
+		&l,
+	)
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "EUR", Code: "EUR", Type: 2, IsAvailable: true},
+		}, nil).Once()
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("EUR/USD", "USD/EUR"), nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "EUR", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.RequireFromString("1.07")}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"EUR"}).
+		Return(map[string]decimal.Decimal{"EUR": decimal.RequireFromString("0.93")}, nil).Once()
+
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).
+		Return(nil).Twice()
+
+	s.mockCourseStorage.On("Set", ctx, "EUR", "USD", storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.RequireFromString("1.07"),
+		IsAvailable: true,
+		Provider:    "fastforex",
+	})).Return().Once()
+
+	s.mockCourseStorage.On("Set", ctx, "USD", "EUR", storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.RequireFromString("0.93"),
+		IsAvailable: true,
+		Provider:    "fastforex",
+	})).Return().Once()
+
+	err := svc.UpdateCourses(ctx)
+	require.NoError(s.T(), err)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestUpdateCourses_SyntheticCryptoPair() {
+	ctx := context.Background()
+
+	l := zerolog.New(s.buf)
+	svc := currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI),
+		s.mockCourseStorage,
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{PivotCode: "USD"},
+		&l,
+	)
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: 1, IsAvailable: true},
+			{ID: uuid.New(), Name: "ETH", Code: "ETH", Type: 1, IsAvailable: true},
+		}, nil).Once()
+
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("BTC/USD", "USD/BTC", "ETH/USD", "USD/ETH"), nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"BTC", "ETH"}).
+		Return(map[string]decimal.Decimal{"BTC": decimal.NewFromInt(2), "ETH": decimal.NewFromInt(2)}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, mock.Anything, []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(2)}, nil).Twice()
+
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).
+		Return(nil).Times(4)
+
+	s.mockCourseStorage.On("Set", ctx, mock.Anything, mock.Anything, storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.NewFromInt(2),
+		IsAvailable: true,
+		Provider:    "fastforex",
+	})).Return().Times(4)
+
+	s.mockCourseStorage.On("Get", ctx, "BTC", "USD").
+		Return(dto.CurrencyStorageDTO{Course: decimal.NewFromFloat(70000), IsAvailable: true}, true).Once()
+
+	s.mockCourseStorage.On("Get", ctx, "USD", "ETH").
+		Return(dto.CurrencyStorageDTO{Course: decimal.NewFromFloat(0.0003), IsAvailable: true}, true).Once()
+
+	s.mockCourseStorage.On("Get", ctx, "ETH", "USD").
+		Return(dto.CurrencyStorageDTO{}, false).Once()
+
+	s.mockCourseStorage.On("Get", ctx, "USD", "BTC").
+		Return(dto.CurrencyStorageDTO{Course: decimal.NewFromFloat(0.00001), IsAvailable: true}, true).Once()
+
+	s.mockCourseStorage.On("Set", ctx, "BTC", "ETH", storedCourse(dto.CurrencyStorageDTO{
+		Course:      decimal.NewFromFloat(70000).Mul(decimal.NewFromFloat(0.0003)),
+		IsAvailable: true,
+		IsSynthetic: true,
+		Pivot:       "USD",
+	})).Return().Once()
+
+	s.mockCourseStorage.On("Set", ctx, "ETH", "BTC", storedCourse(dto.CurrencyStorageDTO{
+		IsAvailable: false,
+		IsSynthetic: true,
+		Pivot:       "USD",
+	})).Return().Once()
+
+	err := svc.UpdateCourses(ctx)
+	require.NoError(s.T(), err)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_FiatThroughPivotLegs() {
+	ctx := context.Background()
+
+	l := zerolog.New(s.buf)
+	svc := currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI),
+		memory.NewStorage(),
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{PivotCode: "USD"},
+		&l,
+	)
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).
+		Return(entity.Currencies{
+			{ID: uuid.New(), Name: "USD", Code: "USD", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "EUR", Code: "EUR", Type: 2, IsAvailable: true},
+			{ID: uuid.New(), Name: "GBP", Code: "GBP", Type: 2, IsAvailable: true},
+		}, nil).Once()
+
+	// no pair is registered, the pivot legs are fetched anyway
+	s.mockPairRepo.On("GetPairs", ctx).Return(pairentity.Pairs{}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "EUR", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.RequireFromString("1.07")}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "GBP", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.RequireFromString("1.27")}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, "USD", []string{"EUR", "GBP"}).
+		Return(map[string]decimal.Decimal{
+			"EUR": decimal.RequireFromString("0.93"),
+			"GBP": decimal.RequireFromString("0.79"),
+		}, nil).Once()
+
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil).Times(4)
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+
+	require.NoError(s.T(), svc.UpdateCourses(ctx))
+
+	res, err := svc.Convert(ctx, dto.Conversion{From: "EUR", To: "GBP", Amount: decimal.NewFromInt(100)})
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), "84.53", res.Result.String())
+	require.True(s.T(), res.IsSynthetic)
+	require.Equal(s.T(), "USD", res.Via)
+}
+
+// cryptoCatalogue is USD as the pivot with two crypto currencies.
+func cryptoCatalogue() entity.Currencies {
+	return entity.Currencies{
+		{ID: uuid.New(), Name: "USD", Code: "USD", Type: entity.TypeFiat, IsAvailable: true},
+		{ID: uuid.New(), Name: "BTC", Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true},
+		{ID: uuid.New(), Name: "ETH", Code: "ETH", Type: entity.TypeCrypto, IsAvailable: true},
+	}
+}
+
+func (s *CurrencyServiceTestSuite) memorySvc(opts currency.Options) *currency.Svc {
+	l := zerolog.New(s.buf)
+
+	return currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI),
+		memory.NewStorage(),
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		opts,
+		&l,
+	)
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_DisabledPairNotDerived() {
+	ctx := context.Background()
+
+	svc := s.memorySvc(currency.Options{PivotCode: "USD"})
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).Return(cryptoCatalogue(), nil).Once()
+	s.mockPairRepo.On("GetPairs", ctx).
+		Return(pairentity.Pairs{{ID: uuid.New(), CodeFrom: "BTC", CodeTo: "ETH", Enabled: false}}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, mock.Anything, mock.Anything).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(2), "BTC": decimal.NewFromInt(2), "ETH": decimal.NewFromInt(2)}, nil)
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil)
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+
+	require.NoError(s.T(), svc.UpdateCourses(ctx))
+
+	// the disabled pair is out of service, the opposite one is not registered and is derived
+	_, err := svc.Convert(ctx, dto.Conversion{From: "BTC", To: "ETH", Amount: decimal.NewFromInt(1)})
+	require.ErrorIs(s.T(), err, entity.ErrCurrencyNotAvailable)
+
+	res, err := svc.Convert(ctx, dto.Conversion{From: "ETH", To: "BTC", Amount: decimal.NewFromInt(1)})
+	require.NoError(s.T(), err)
+	require.True(s.T(), res.IsSynthetic)
+}
+
+func (s *CurrencyServiceTestSuite) TestRunUpdateCycle_DropsDisabledPair() {
+	ctx := context.Background()
+
+	svc := s.memorySvc(currency.Options{PivotCode: "USD"})
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).Return(cryptoCatalogue(), nil).Twice()
+	s.mockPairRepo.On("GetPairs", ctx).Return(registeredPairs("BTC/ETH"), nil).Once()
+	s.mockPairRepo.On("GetPairs", ctx).
+		Return(pairentity.Pairs{{ID: uuid.New(), CodeFrom: "BTC", CodeTo: "ETH", Enabled: false}}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, mock.Anything, mock.Anything).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(2), "BTC": decimal.NewFromInt(2), "ETH": decimal.NewFromInt(2)}, nil)
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil)
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+
+	_, err := svc.RunUpdateCycle(ctx)
+	require.NoError(s.T(), err)
+
+	res, err := svc.Convert(ctx, dto.Conversion{From: "BTC", To: "ETH", Amount: decimal.NewFromInt(1)})
+	require.NoError(s.T(), err)
+	require.False(s.T(), res.IsSynthetic)
+
+	_, err = svc.RunUpdateCycle(ctx)
+	require.NoError(s.T(), err)
+
+	_, err = svc.Convert(ctx, dto.Conversion{From: "BTC", To: "ETH", Amount: decimal.NewFromInt(1)})
+	require.ErrorIs(s.T(), err, entity.ErrCurrencyNotAvailable)
+}
+
+func (s *CurrencyServiceTestSuite) TestRunUpdateCycle_PairingRulesLimitSynthetic() {
+	ctx := context.Background()
+
+	svc := s.memorySvc(currency.Options{
+		PivotCode:    "USD",
+		PairingRules: entity.PairingRules{entity.TypeCrypto: {entity.TypeFiat}},
+	})
+
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).Return(cryptoCatalogue(), nil).Once()
+	s.mockPairRepo.On("GetPairs", ctx).Return(pairentity.Pairs{}, nil).Once()
+
+	s.mockCurrencyAPI.On("FetchMulti", ctx, mock.Anything, mock.Anything).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(2), "BTC": decimal.NewFromInt(2), "ETH": decimal.NewFromInt(2)}, nil)
+	s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).Return(nil)
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+
+	_, err := svc.RunUpdateCycle(ctx)
+	require.NoError(s.T(), err)
+
+	// crypto is not paired with crypto, so BTC/ETH is neither fetched nor derived
+	_, err = svc.Convert(ctx, dto.Conversion{From: "BTC", To: "ETH", Amount: decimal.NewFromInt(1)})
+	require.ErrorIs(s.T(), err, entity.ErrCurrencyNotAvailable)
+
+	_, err = svc.Convert(ctx, dto.Conversion{From: "BTC", To: "USD", Amount: decimal.NewFromInt(1)})
+	require.NoError(s.T(), err)
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_NoErr() {
+	ctx := context.Background()
+	data := dto.Conversion{
+		From:   "USD",
 		To:     "BTC",
-		Amount: 70000,
+		Amount: decimal.NewFromInt(70000),
 	}
 
-	exp := decimal.NewFromFloat(0.00001441066)
+	exp := decimal.RequireFromString("0.00001441066")
+	fetchedAt := time.Now().Add(-time.Second).UTC()
+	cycleID := uuid.New()
 
 	s.mockCourseStorage.On("Get", ctx, data.From, data.To).
-		Return(exp, true).Once()
+		Return(dto.CurrencyStorageDTO{
+			Course:      exp,
+			IsAvailable: true,
+			FetchedAt:   fetchedAt,
+			Provider:    "fastforex",
+			CycleID:     cycleID,
+		}, true).Once()
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
 
 	res, err := s.svc.Convert(ctx, data)
 
 	require.NoError(s.T(), err)
-	require.Equal(s.T(), res, 1.0087462)
+	require.Equal(s.T(), "1.0087462", res.Result.String())
+	require.Equal(s.T(), exp, res.Rate)
+	require.Equal(s.T(), data.Amount, res.Amount)
+	require.Equal(s.T(), fetchedAt, res.AsOf)
+	require.Equal(s.T(), "fastforex", res.Source)
+	require.Equal(s.T(), cycleID, res.CycleID)
+	require.False(s.T(), res.IsSynthetic)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_SmallAmountPrecision() {
+	ctx := context.Background()
+	data := dto.Conversion{
+		From:   "BTC",
+		To:     "USD",
+		Amount: decimal.RequireFromString("0.00000003"),
+	}
+
+	s.mockCourseStorage.On("Get", ctx, data.From, data.To).
+		Return(dto.CurrencyStorageDTO{Course: decimal.RequireFromString("69220.1"), IsAvailable: true}, true).Once()
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+
+	res, err := s.svc.Convert(ctx, data)
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "0.002076603", res.Result.String())
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_Synthetic() {
+	ctx := context.Background()
+	data := dto.Conversion{
+		From:   "BTC",
+		To:     "ETH",
+		Amount: decimal.NewFromInt(2),
+	}
+
+	s.mockCourseStorage.On("Get", ctx, data.From, data.To).
+		Return(dto.CurrencyStorageDTO{
+			Course:      decimal.NewFromInt(21),
+			IsAvailable: true,
+			IsSynthetic: true,
+			Pivot:       "USD",
+		}, true).Once()
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+
+	res, err := s.svc.Convert(ctx, data)
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "42", res.Result.String())
+	require.True(s.T(), res.IsSynthetic)
+	require.Equal(s.T(), "USD", res.Via)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_Stale() {
+	ctx := context.Background()
+	data := dto.Conversion{
+		From:   "USD",
+		To:     "BTC",
+		Amount: decimal.NewFromInt(2),
+	}
+
+	s.mockCourseStorage.On("Get", ctx, data.From, data.To).
+		Return(dto.CurrencyStorageDTO{
+			Course:      decimal.RequireFromString("0.5"),
+			IsAvailable: true,
+			FetchedAt:   time.Now().Add(-time.Minute),
+			LastError:   "timeout",
+		}, true).Once()
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+
+	res, err := s.svc.Convert(ctx, data)
+
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "1", res.Result.String())
+	require.True(s.T(), res.IsStale)
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_StaleExpired() {
+	ctx := context.Background()
+	data := dto.Conversion{
+		From:   "USD",
+		To:     "BTC",
+		Amount: decimal.NewFromInt(2),
+	}
+
+	s.mockCourseStorage.On("Get", ctx, data.From, data.To).
+		Return(dto.CurrencyStorageDTO{
+			Course:      decimal.RequireFromString("0.5"),
+			IsAvailable: true,
+			FetchedAt:   time.Now().Add(-time.Hour),
+			LastError:   "timeout",
+		}, true).Once()
+
+	_, err := s.svc.Convert(ctx, data)
+
+	require.ErrorIs(s.T(), err, entity.ErrCurrencyNotAvailable)
 
 	s.mockCourseStorage.AssertExpectations(s.T())
 }
 
 func (s *CurrencyServiceTestSuite) TestConvert_Err() {
 	ctx := context.Background()
-	data := dto.ConvertCurrencyReq{
+	data := dto.Conversion{
 		From:   "USD",
 		To:     "BTC",
-		Amount: 70000,
+		Amount: decimal.NewFromInt(70000),
 	}
 
 	s.mockCourseStorage.On("Get", ctx, data.From, data.To).
-		Return(decimal.Decimal{}, false).Once()
+		Return(dto.CurrencyStorageDTO{}, false).Once()
 
 	res, err := s.svc.Convert(ctx, data)
 
 	require.Error(s.T(), err)
 	require.ErrorIs(s.T(), err, entity.ErrCurrencyNotAvailable)
-	require.Equal(s.T(), res, float64(0))
+	require.Equal(s.T(), res, dto.ConversionResult{})
 
 	s.mockCourseStorage.AssertExpectations(s.T())
 }
+
+func (s *CurrencyServiceTestSuite) TestConvert_Pricing() {
+	ctx := context.Background()
+
+	currencies := entity.Currencies{
+		{Code: "USD", Type: entity.TypeFiat, IsAvailable: true},
+		{Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true},
+	}
+
+	byTypes := pricingentity.Pricing{
+		TypeFrom:      entity.TypeFiat,
+		TypeTo:        entity.TypeCrypto,
+		SpreadPercent: decimal.NewFromInt(2),
+	}
+
+	testCases := []struct {
+		name      string
+		pricings  pricingentity.Pricings
+		amount    decimal.Decimal
+		expSpread string
+		expFee    string
+		expNet    string
+	}{
+		{
+			name:      "no_pricing",
+			amount:    decimal.NewFromInt(1000),
+			expSpread: "0",
+			expFee:    "0",
+			expNet:    "2",
+		},
+		{
+			name:      "by_types",
+			pricings:  pricingentity.Pricings{byTypes},
+			amount:    decimal.NewFromInt(1000),
+			expSpread: "0.04",
+			expFee:    "0",
+			expNet:    "1.96",
+		},
+		{
+			name: "exact_pair_over_types",
+			pricings: pricingentity.Pricings{byTypes, {
+				CodeFrom:      "USD",
+				CodeTo:        "BTC",
+				SpreadPercent: decimal.RequireFromString("0.5"),
+				FixedFee:      decimal.RequireFromString("0.001"),
+			}},
+			amount:    decimal.NewFromInt(1000),
+			expSpread: "0.01",
+			expFee:    "0.001",
+			expNet:    "1.989",
+		},
+		{
+			name: "min_fee",
+			pricings: pricingentity.Pricings{{
+				CodeFrom:      "USD",
+				CodeTo:        "BTC",
+				SpreadPercent: decimal.NewFromInt(1),
+				FixedFee:      decimal.RequireFromString("0.001"),
+				MinFee:        decimal.RequireFromString("0.005"),
+			}},
+			amount:    decimal.NewFromInt(100),
+			expSpread: "0.002",
+			expFee:    "0.003",
+			expNet:    "0.195",
+		},
+		{
+			name: "fee_above_result",
+			pricings: pricingentity.Pricings{{
+				CodeFrom: "USD",
+				CodeTo:   "BTC",
+				FixedFee: decimal.NewFromInt(1),
+			}},
+			amount:    decimal.NewFromInt(1),
+			expSpread: "0",
+			expFee:    "1",
+			expNet:    "0",
+		},
+	}
+
+	// the currencies are read by the first conversion priced by types and cached
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).Return(currencies, nil).Once()
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			s.mockCourseStorage.On("Get", ctx, "USD", "BTC").
+				Return(dto.CurrencyStorageDTO{Course: decimal.RequireFromString("0.002"), IsAvailable: true}, true).Once()
+
+			s.mockPricingRepo.On("GetPricings", ctx).Return(tc.pricings, nil).Once()
+
+			res, err := s.svc.Convert(ctx, dto.Conversion{From: "USD", To: "BTC", Amount: tc.amount})
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expSpread, res.SpreadAmount.String())
+			require.Equal(t, tc.expFee, res.Fee.String())
+			require.Equal(t, tc.expNet, res.Net.String())
+		})
+	}
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_PricingAfterTypeChange() {
+	ctx := context.Background()
+
+	usd := entity.Currency{ID: uuid.New(), Code: "USD", Type: entity.TypeFiat, IsAvailable: true}
+	btc := entity.Currency{ID: uuid.New(), Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true}
+	pricings := pricingentity.Pricings{{
+		TypeFrom:      entity.TypeFiat,
+		TypeTo:        entity.TypeCrypto,
+		SpreadPercent: decimal.NewFromInt(2),
+	}}
+
+	s.mockCourseStorage.On("Get", ctx, "USD", "BTC").
+		Return(dto.CurrencyStorageDTO{Course: decimal.RequireFromString("0.002"), IsAvailable: true}, true).Twice()
+	s.mockPricingRepo.On("GetPricings", ctx).Return(pricings, nil).Twice()
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).Return(entity.Currencies{usd, btc}, nil).Once()
+
+	res, err := s.svc.Convert(ctx, dto.Conversion{From: "USD", To: "BTC", Amount: decimal.NewFromInt(1000)})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "0.04", res.SpreadAmount.String())
+
+	fiat := int(entity.TypeFiat)
+
+	s.mockCurrencyRepo.On("PatchCurrency", ctx, mock.Anything).Return(int64(2), nil).Once()
+
+	_, err = s.svc.PatchCurrency(ctx, dto.CurrencyPatch{ID: btc.ID, Type: &fiat, Version: 1})
+	require.NoError(s.T(), err)
+
+	// the patched type is read again instead of the cached one
+	btc.Type = entity.TypeFiat
+	s.mockCurrencyRepo.On("GetCurrencies", ctx).Return(entity.Currencies{usd, btc}, nil).Once()
+
+	res, err = s.svc.Convert(ctx, dto.Conversion{From: "USD", To: "BTC", Amount: decimal.NewFromInt(1000)})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "0", res.SpreadAmount.String())
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_PricingErr() {
+	ctx := context.Background()
+
+	s.mockCourseStorage.On("Get", ctx, "USD", "BTC").
+		Return(dto.CurrencyStorageDTO{Course: decimal.RequireFromString("0.002"), IsAvailable: true}, true).Once()
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, errors.New("pg err")).Once()
+
+	_, err := s.svc.Convert(ctx, dto.Conversion{From: "USD", To: "BTC", Amount: decimal.NewFromInt(1)})
+	require.ErrorContains(s.T(), err, "pg err")
+	require.NotErrorIs(s.T(), err, entity.ErrCurrencyNotAvailable)
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_At() {
+	ctx := context.Background()
+
+	at := time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC)
+	cycleID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		mockFunc  func()
+		expRate   string
+		expAsOf   time.Time
+		expSource string
+		expCycle  uuid.UUID
+		deleted   bool
+		expErr    error
+	}{
+		{
+			name: "from_history",
+			mockFunc: func() {
+				s.mockRatesRepo.On("GetRateAt", ctx, "USD", "BTC", at).
+					Return(rateentity.Rate{
+						CodeFrom:  "USD",
+						CodeTo:    "BTC",
+						Rate:      decimal.RequireFromString("0.0000144"),
+						Provider:  "fastforex",
+						CycleID:   cycleID,
+						CreatedAt: at.Add(-time.Minute),
+					}, nil).Once()
+			},
+			expRate:   "0.0000144",
+			expAsOf:   at.Add(-time.Minute),
+			expSource: "fastforex",
+			expCycle:  cycleID,
+		},
+		{
+			name: "gap_in_history",
+			mockFunc: func() {
+				s.mockRatesRepo.On("GetRateAt", ctx, "USD", "BTC", at).
+					Return(rateentity.Rate{Rate: decimal.RequireFromString("0.0000144"), CreatedAt: at.Add(-time.Hour)}, nil).Once()
+				s.mockCurrencyAPI.On("Historical", ctx, "USD", "BTC", at).
+					Return(decimal.RequireFromString("0.0000145"), nil).Once()
+				s.mockRatesRepo.On("SaveRate", ctx, rateentity.Rate{
+					CodeFrom:     "USD",
+					CodeTo:       "BTC",
+					Rate:         decimal.RequireFromString("0.0000145"),
+					Provider:     "fastforex",
+					CreatedAt:    at,
+					IsBackfilled: true,
+				}).Return(nil).Once()
+			},
+			expRate:   "0.0000145",
+			expAsOf:   at,
+			expSource: "fastforex",
+		},
+		{
+			name: "no_history",
+			mockFunc: func() {
+				s.mockRatesRepo.On("GetRateAt", ctx, "USD", "BTC", at).
+					Return(rateentity.Rate{}, entity.ErrEntityNotFound).Once()
+				s.mockCurrencyAPI.On("Historical", ctx, "USD", "BTC", at).
+					Return(decimal.RequireFromString("0.0000145"), nil).Once()
+				s.mockRatesRepo.On("SaveRate", ctx, mock.Anything).
+					Return(errors.New("pg err")).Once()
+			},
+			expRate:   "0.0000145",
+			expAsOf:   at,
+			expSource: "fastforex",
+		},
+		{
+			name: "api_err",
+			mockFunc: func() {
+				s.mockRatesRepo.On("GetRateAt", ctx, "USD", "BTC", at).
+					Return(rateentity.Rate{}, entity.ErrEntityNotFound).Once()
+				s.mockCurrencyAPI.On("Historical", ctx, "USD", "BTC", at).
+					Return(decimal.Decimal{}, errors.New("api err")).Once()
+			},
+			expErr: entity.ErrCurrencyNotAvailable,
+		},
+		{
+			name: "storage_err",
+			mockFunc: func() {
+				s.mockRatesRepo.On("GetRateAt", ctx, "USD", "BTC", at).
+					Return(rateentity.Rate{}, errors.New("pg err")).Once()
+			},
+			expErr: errors.New("pg err"),
+		},
+		{
+			name:     "deleted_currency",
+			deleted:  true,
+			mockFunc: func() {},
+			expErr:   entity.ErrCurrencyNotAvailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			s.mockCurrencyRepo.On("GetCurrencyByCode", ctx, "USD").
+				Return(entity.Currency{Code: "USD"}, nil).Once()
+
+			if tc.deleted {
+				s.mockCurrencyRepo.On("GetCurrencyByCode", ctx, "BTC").
+					Return(entity.Currency{}, entity.ErrEntityNotFound).Once()
+			} else {
+				s.mockCurrencyRepo.On("GetCurrencyByCode", ctx, "BTC").
+					Return(entity.Currency{Code: "BTC"}, nil).Once()
+			}
+
+			tc.mockFunc()
+
+			if tc.expErr == nil {
+				s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+			}
+
+			res, err := s.svc.Convert(ctx, dto.Conversion{From: "USD", To: "BTC", Amount: decimal.NewFromInt(2), At: &at})
+			if tc.expErr != nil {
+				require.ErrorContains(t, err, tc.expErr.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expRate, res.Rate.String())
+			require.True(t, res.Rate.Mul(decimal.NewFromInt(2)).Equal(res.Result))
+			require.Equal(t, tc.expAsOf, res.AsOf)
+			require.Equal(t, tc.expSource, res.Source)
+			require.Equal(t, tc.expCycle, res.CycleID)
+			require.False(t, res.IsStale)
+		})
+	}
+}
+
+func (s *CurrencyServiceTestSuite) TestConvert_AtSynthetic() {
+	ctx := context.Background()
+
+	l := zerolog.New(s.buf)
+	svc := currency.NewCurrencySvc(
+		s.mockCurrencyRepo,
+		currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockCurrencyAPI),
+		s.mockCourseStorage,
+		s.mockRatesRepo,
+		s.mockPricingRepo,
+		s.mockPairRepo,
+		s.mockAlerts,
+		currency.Options{PivotCode: "USD", HistoryMaxGap: 5 * time.Minute},
+		&l,
+	)
+
+	at := time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC)
+	cycleID := uuid.New()
+
+	s.mockCurrencyRepo.On("GetCurrencyByCode", ctx, "BTC").Return(entity.Currency{Code: "BTC"}, nil).Twice()
+	s.mockCurrencyRepo.On("GetCurrencyByCode", ctx, "ETH").Return(entity.Currency{Code: "ETH"}, nil).Twice()
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Twice()
+	s.mockRatesRepo.On("GetRateAt", ctx, "BTC", "ETH", at).Return(rateentity.Rate{}, entity.ErrEntityNotFound).Twice()
+
+	// both legs are persisted, the provider is not asked
+	s.mockRatesRepo.On("GetRateAt", ctx, "BTC", "USD", at).
+		Return(rateentity.Rate{
+			Rate:      decimal.RequireFromString("60000"),
+			Provider:  "fastforex",
+			CycleID:   cycleID,
+			CreatedAt: at.Add(-time.Minute),
+		}, nil).Once()
+	s.mockRatesRepo.On("GetRateAt", ctx, "USD", "ETH", at).
+		Return(rateentity.Rate{
+			Rate:      decimal.RequireFromString("0.0003"),
+			Provider:  "fastforex",
+			CycleID:   cycleID,
+			CreatedAt: at.Add(-2 * time.Minute),
+		}, nil).Once()
+
+	res, err := svc.Convert(ctx, dto.Conversion{From: "BTC", To: "ETH", Amount: decimal.NewFromInt(2), At: &at})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "18", res.Rate.String())
+	require.Equal(s.T(), "36", res.Result.String())
+	require.Equal(s.T(), at.Add(-2*time.Minute), res.AsOf)
+	require.Equal(s.T(), cycleID, res.CycleID)
+	require.True(s.T(), res.IsSynthetic)
+	require.Equal(s.T(), "USD", res.Via)
+
+	// a leg has a gap, the rate is backfilled
+	s.mockRatesRepo.On("GetRateAt", ctx, "BTC", "USD", at).
+		Return(rateentity.Rate{}, entity.ErrEntityNotFound).Once()
+	s.mockCurrencyAPI.On("Historical", ctx, "BTC", "ETH", at).
+		Return(decimal.RequireFromString("17"), nil).Once()
+	s.mockRatesRepo.On("SaveRate", ctx, mock.MatchedBy(func(r rateentity.Rate) bool {
+		return r.IsBackfilled && r.CreatedAt.Equal(at)
+	})).Return(nil).Once()
+
+	res, err = svc.Convert(ctx, dto.Conversion{From: "BTC", To: "ETH", Amount: decimal.NewFromInt(2), At: &at})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "17", res.Rate.String())
+	require.False(s.T(), res.IsSynthetic)
+}
+
+func (s *CurrencyServiceTestSuite) TestConvertBatch() {
+	ctx := context.Background()
+	data := []dto.Conversion{
+		{From: "USD", To: "BTC", Amount: decimal.NewFromInt(2)},
+		{From: "USD", To: "ETH", Amount: decimal.NewFromInt(1)},
+		{From: "USD", To: "BTC", Amount: decimal.RequireFromString("0.5")},
+	}
+
+	s.mockCourseStorage.On("GetMany", ctx, []dto.CurrencyPair{
+		{From: "USD", To: "BTC"},
+		{From: "USD", To: "ETH"},
+		{From: "USD", To: "BTC"},
+	}).Return(map[dto.CurrencyPair]dto.CurrencyStorageDTO{
+		{From: "USD", To: "BTC"}: {Course: decimal.RequireFromString("0.00001441066"), IsAvailable: true},
+	}).Once()
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Once()
+
+	res, err := s.svc.ConvertBatch(ctx, data)
+	require.NoError(s.T(), err)
+
+	require.Len(s.T(), res, 3)
+	require.NoError(s.T(), res[0].Err)
+	require.Equal(s.T(), "0.00002882132", res[0].Result.Result.String())
+	require.ErrorIs(s.T(), res[1].Err, entity.ErrCurrencyNotAvailable)
+	require.NoError(s.T(), res[2].Err)
+	require.Equal(s.T(), "0.00000720533", res[2].Result.Result.String())
+
+	s.mockCourseStorage.AssertExpectations(s.T())
+}
+
+func (s *CurrencyServiceTestSuite) TestConvertBatch_At() {
+	ctx := context.Background()
+
+	at := time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC)
+	data := []dto.Conversion{
+		{From: "USD", To: "BTC", Amount: decimal.NewFromInt(2), At: &at},
+		{From: "USD", To: "ETH", Amount: decimal.NewFromInt(1)},
+	}
+
+	s.mockCourseStorage.On("GetMany", ctx, []dto.CurrencyPair{{From: "USD", To: "ETH"}}).
+		Return(map[dto.CurrencyPair]dto.CurrencyStorageDTO{}).Twice()
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, nil).Twice()
+
+	s.mockCurrencyRepo.On("GetCurrencyByCode", ctx, "USD").
+		Return(entity.Currency{Code: "USD"}, nil).Twice()
+	s.mockCurrencyRepo.On("GetCurrencyByCode", ctx, "BTC").
+		Return(entity.Currency{Code: "BTC"}, nil).Twice()
+
+	s.mockRatesRepo.On("GetRateAt", ctx, "USD", "BTC", at).
+		Return(rateentity.Rate{Rate: decimal.RequireFromString("0.5"), CreatedAt: at}, nil).Once()
+
+	res, err := s.svc.ConvertBatch(ctx, data)
+	require.NoError(s.T(), err)
+
+	require.Len(s.T(), res, 2)
+	require.NoError(s.T(), res[0].Err)
+	require.Equal(s.T(), "1", res[0].Result.Result.String())
+	require.ErrorIs(s.T(), res[1].Err, entity.ErrCurrencyNotAvailable)
+
+	s.mockRatesRepo.On("GetRateAt", ctx, "USD", "BTC", at).
+		Return(rateentity.Rate{}, errors.New("pg err")).Once()
+
+	_, err = s.svc.ConvertBatch(ctx, data)
+	require.ErrorContains(s.T(), err, "pg err")
+}
+
+// storedCourse matches a course storage value ignoring its fetch time and cycle.
+func storedCourse(exp dto.CurrencyStorageDTO) interface{} {
+	return mock.MatchedBy(func(v dto.CurrencyStorageDTO) bool {
+		return v.Course.Equal(exp.Course) &&
+			v.IsAvailable == exp.IsAvailable &&
+			v.IsSynthetic == exp.IsSynthetic &&
+			v.Pivot == exp.Pivot &&
+			v.Provider == exp.Provider &&
+			v.LastError == exp.LastError
+	})
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+	AuditPurge   AuditAction = "purge"
+)
+
+// AuditRecord is a change of the currency. Before is nil for the created currency
+// and After is nil for the purged one.
+type AuditRecord struct {
+	ID         uuid.UUID
+	CurrencyID uuid.UUID
+	Action     AuditAction
+	Actor      string
+	RequestID  string
+	Before     *Currency
+	After      *Currency
+	CreatedAt  time.Time
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -10,32 +12,55 @@ type Currency struct {
 	Code        string
 	Type        CurrencyType
 	IsAvailable bool
+	// Version is increased by every update, it guards against lost updates
+	Version int64
+	// DeletedAt is set when the currency is deleted, it is kept until purge
+	DeletedAt *time.Time
+}
+
+// CurrencyPatch holds the fields of the currency to update, nil fields are left unchanged.
+type CurrencyPatch struct {
+	ID          uuid.UUID
+	Name        *string
+	Code        *string
+	Type        *CurrencyType
+	IsAvailable *bool
+	Version     int64
 }
 
 type Currencies []Currency
 
+func (c Currencies) ByCode(code string) (Currency, bool) {
+	for _, cur := range c {
+		if cur.Code == code {
+			return cur, true
+		}
+	}
+
+	return Currency{}, false
+}
+
 type CurrencyType int
 
 const (
-	TypeCrypto CurrencyType = 1
-	TypeFiat   CurrencyType = 2
+	TypeCrypto     CurrencyType = 1
+	TypeFiat       CurrencyType = 2
+	TypeStablecoin CurrencyType = 3
+	// TypeMetal is a precious metal quoted per troy ounce, e.g. XAU or XAG
+	TypeMetal CurrencyType = 4
+	// TypeIndex is a custom index or tokenised asset
+	TypeIndex CurrencyType = 5
 )
 
 func IntToCurrencyType(t int) (CurrencyType, error) {
-	switch CurrencyType(t) {
-	case TypeCrypto:
-		return TypeCrypto, nil
-	case TypeFiat:
-		return TypeFiat, nil
+	switch ct := CurrencyType(t); ct {
+	case TypeCrypto, TypeFiat, TypeStablecoin, TypeMetal, TypeIndex:
+		return ct, nil
 	}
 
 	return 0, ErrInvalidCurrencyType
 }
 
-func (c Currency) IsFiat() bool {
-	return c.Type == TypeFiat
-}
-
-func (c Currency) IsCrypto() bool {
-	return c.Type == TypeCrypto
+func (c Currency) IsDeleted() bool {
+	return c.DeletedAt != nil
 }
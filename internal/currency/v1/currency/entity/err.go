@@ -7,4 +7,7 @@ var (
 	ErrInvalidCurrencyType   = errors.New("invalid currency type")
 	ErrCurrencyNotAvailable  = errors.New("currency not available")
 	ErrCurrencyAlreadyExists = errors.New("currency already exists")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+	ErrVersionConflict       = errors.New("currency was modified by another request")
+	ErrCurrencyNotDeleted    = errors.New("currency is not deleted")
 )
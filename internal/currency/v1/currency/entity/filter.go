@@ -0,0 +1,38 @@
+package entity
+
+import "github.com/google/uuid"
+
+type SortField string
+
+const (
+	SortByCode SortField = "code"
+	SortByName SortField = "name"
+)
+
+// CurrencyFilter selects a page of currencies. Pages are ordered by the sort
+// field and then by id, so After points right behind the last currency of the
+// previous page.
+type CurrencyFilter struct {
+	Type        CurrencyType
+	IsAvailable *bool
+	// Search matches currencies which name contains it, case-insensitive
+	Search string
+	SortBy SortField
+	Desc   bool
+	After  *CurrencyCursor
+	Limit  int
+}
+
+type CurrencyCursor struct {
+	Value string
+	ID    uuid.UUID
+}
+
+// SortValue returns the value of the currency the page is sorted by.
+func (c Currency) SortValue(field SortField) string {
+	if field == SortByName {
+		return c.Name
+	}
+
+	return c.Code
+}
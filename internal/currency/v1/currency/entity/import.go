@@ -0,0 +1,10 @@
+package entity
+
+// ImportAction is what the import did with the currency.
+type ImportAction string
+
+const (
+	ImportCreated   ImportAction = "created"
+	ImportUpdated   ImportAction = "updated"
+	ImportUnchanged ImportAction = "unchanged"
+)
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PairingRules maps currency type to the types it may be paired with for fetching
+// courses. Rules are symmetric: a pair is allowed in both directions when either
+// of its types lists the other one. Nil rules don't restrict pairs.
+type PairingRules map[CurrencyType][]CurrencyType
+
+// currencyTypeNames are the names of currency types used by the configured pairing rules.
+var currencyTypeNames = map[string]CurrencyType{ //nolint:gochecknoglobals
+	"crypto":     TypeCrypto,
+	"fiat":       TypeFiat,
+	"stablecoin": TypeStablecoin,
+	"metal":      TypeMetal,
+	"index":      TypeIndex,
+}
+
+// ParsePairingRules parses the rules given as type name to the names of the types
+// it is paired with separated by "|", e.g. {"crypto": "fiat|stablecoin"}.
+func ParsePairingRules(rules map[string]string) (PairingRules, error) {
+	res := make(PairingRules, len(rules))
+
+	for name, paired := range rules {
+		t, err := parseCurrencyType(name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pairedName := range strings.Split(paired, "|") {
+			pt, err := parseCurrencyType(pairedName)
+			if err != nil {
+				return nil, err
+			}
+
+			res[t] = append(res[t], pt)
+		}
+	}
+
+	return res, nil
+}
+
+func parseCurrencyType(name string) (CurrencyType, error) {
+	t, ok := currencyTypeNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, errors.Wrapf(ErrInvalidCurrencyType, "pairing rules type %q", name)
+	}
+
+	return t, nil
+}
+
+// Pairs reports whether currencies of the types may be paired.
+func (r PairingRules) Pairs(a, b CurrencyType) bool {
+	if r == nil {
+		return true
+	}
+
+	return slices.Contains(r[a], b) || slices.Contains(r[b], a)
+}
@@ -2,26 +2,19 @@ package memory
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
-	"github.com/shopspring/decimal"
 	"github.com/veleton777/test_work_blum/internal/dto"
 )
 
-type value struct {
-	course      decimal.Decimal
-	isAvailable bool
-}
-
 type Storage struct {
-	storage map[string]value
+	storage map[dto.CurrencyPair]dto.CurrencyStorageDTO
 	mu      *sync.RWMutex
 }
 
 func NewStorage() *Storage {
 	return &Storage{
-		storage: make(map[string]value),
+		storage: make(map[dto.CurrencyPair]dto.CurrencyStorageDTO),
 		mu:      &sync.RWMutex{},
 	}
 }
@@ -30,28 +23,75 @@ func (s *Storage) Set(_ context.Context, codeFrom, codeTo string, data dto.Curre
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.storage[s.key(codeFrom, codeTo)] = value{
-		course:      data.Course,
-		isAvailable: data.IsAvailable,
-	}
+	s.storage[s.key(codeFrom, codeTo)] = data
 }
 
-func (s *Storage) Get(_ context.Context, codeFrom, codeTo string) (decimal.Decimal, bool) {
+func (s *Storage) Get(_ context.Context, codeFrom, codeTo string) (dto.CurrencyStorageDTO, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	v, ok := s.storage[s.key(codeFrom, codeTo)]
 	if !ok {
-		return decimal.Decimal{}, false
+		return dto.CurrencyStorageDTO{}, false
+	}
+
+	if !v.IsAvailable {
+		return dto.CurrencyStorageDTO{}, false
+	}
+
+	return v, true
+}
+
+// GetMany reads all requested pairs under a single lock, so the result is
+// a consistent snapshot even while the courses are being updated.
+// Missing and unavailable pairs are left out of the result.
+func (s *Storage) GetMany(_ context.Context, pairs []dto.CurrencyPair) map[dto.CurrencyPair]dto.CurrencyStorageDTO {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make(map[dto.CurrencyPair]dto.CurrencyStorageDTO, len(pairs))
+
+	for _, p := range pairs {
+		v, ok := s.storage[s.key(p.From, p.To)]
+		if !ok || !v.IsAvailable {
+			continue
+		}
+
+		res[p] = v
 	}
 
-	if !v.isAvailable {
-		return decimal.Decimal{}, false
+	return res
+}
+
+// Retain removes the courses of every pair which is not in pairs.
+func (s *Storage) Retain(_ context.Context, pairs []dto.CurrencyPair) {
+	keep := make(map[dto.CurrencyPair]struct{}, len(pairs))
+	for _, p := range pairs {
+		keep[p] = struct{}{}
 	}
 
-	return v.course, true
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for p := range s.storage {
+		if _, ok := keep[p]; !ok {
+			delete(s.storage, p)
+		}
+	}
+}
+
+// RemoveCurrency removes the courses of every pair of the currency.
+func (s *Storage) RemoveCurrency(_ context.Context, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for p := range s.storage {
+		if p.From == code || p.To == code {
+			delete(s.storage, p)
+		}
+	}
 }
 
-func (s *Storage) key(codeFrom, codeTo string) string {
-	return fmt.Sprintf("%s_%s", codeFrom, codeTo)
+func (s *Storage) key(codeFrom, codeTo string) dto.CurrencyPair {
+	return dto.CurrencyPair{From: codeFrom, To: codeTo}
 }
@@ -38,7 +38,7 @@ func (s *MemoryStorageTestSuite) TestGetMethod() {
 
 	v, ok := st.Get(ctx, "USD", "BTC")
 
-	require.Equal(s.T(), v, decimal.Decimal{})
+	require.Equal(s.T(), v, dto.CurrencyStorageDTO{})
 	require.False(s.T(), ok)
 
 	course := decimal.NewFromFloat(123.567)
@@ -50,7 +50,7 @@ func (s *MemoryStorageTestSuite) TestGetMethod() {
 
 	v, ok = st.Get(ctx, "USD", "BTC")
 
-	require.Equal(s.T(), v, course)
+	require.Equal(s.T(), v, dto.CurrencyStorageDTO{Course: course, IsAvailable: true})
 	require.True(s.T(), ok)
 }
 
@@ -60,7 +60,7 @@ func (s *MemoryStorageTestSuite) TestGetMethod_IsNotAvailable() {
 
 	v, ok := st.Get(ctx, "USD", "BTC")
 
-	require.Equal(s.T(), v, decimal.Decimal{})
+	require.Equal(s.T(), v, dto.CurrencyStorageDTO{})
 	require.False(s.T(), ok)
 
 	course := decimal.NewFromFloat(123.567)
@@ -72,6 +72,58 @@ func (s *MemoryStorageTestSuite) TestGetMethod_IsNotAvailable() {
 
 	v, ok = st.Get(ctx, "USD", "BTC")
 
-	require.Equal(s.T(), v, decimal.Decimal{})
+	require.Equal(s.T(), v, dto.CurrencyStorageDTO{})
 	require.False(s.T(), ok)
 }
+
+func (s *MemoryStorageTestSuite) TestGetManyMethod() {
+	ctx := context.Background()
+	st := NewStorage()
+
+	course := decimal.NewFromFloat(123.567)
+
+	st.Set(ctx, "USD", "BTC", dto.CurrencyStorageDTO{Course: course, IsAvailable: true})
+	st.Set(ctx, "BTC", "USD", dto.CurrencyStorageDTO{Course: course, IsAvailable: false})
+
+	v := st.GetMany(ctx, []dto.CurrencyPair{
+		{From: "USD", To: "BTC"},
+		{From: "BTC", To: "USD"},
+		{From: "USD", To: "ETH"},
+	})
+
+	require.Equal(s.T(), map[dto.CurrencyPair]dto.CurrencyStorageDTO{
+		{From: "USD", To: "BTC"}: {Course: course, IsAvailable: true},
+	}, v)
+}
+
+func (s *MemoryStorageTestSuite) TestRetainMethod() {
+	ctx := context.Background()
+	st := NewStorage()
+
+	for _, p := range []string{"USD", "EUR", "BTC"} {
+		st.Set(ctx, p, "GBP", dto.CurrencyStorageDTO{Course: decimal.NewFromInt(1), IsAvailable: true})
+	}
+
+	st.Retain(ctx, []dto.CurrencyPair{{From: "EUR", To: "GBP"}, {From: "ETH", To: "GBP"}})
+
+	require.Equal(s.T(), len(st.storage), 1)
+
+	_, ok := st.Get(ctx, "EUR", "GBP")
+	require.True(s.T(), ok)
+}
+
+func (s *MemoryStorageTestSuite) TestRemoveCurrencyMethod() {
+	ctx := context.Background()
+	st := NewStorage()
+
+	st.Set(ctx, "USD", "BTC", dto.CurrencyStorageDTO{Course: decimal.NewFromInt(1), IsAvailable: true})
+	st.Set(ctx, "BTC", "USD", dto.CurrencyStorageDTO{Course: decimal.NewFromInt(1), IsAvailable: true})
+	st.Set(ctx, "USD", "EUR", dto.CurrencyStorageDTO{Course: decimal.NewFromInt(1), IsAvailable: true})
+
+	st.RemoveCurrency(ctx, "BTC")
+
+	require.Equal(s.T(), len(st.storage), 1)
+
+	_, ok := st.Get(ctx, "USD", "EUR")
+	require.True(s.T(), ok)
+}
@@ -18,6 +18,8 @@ func CurrencyToEntity(cur storageentity.Currency) (entity.Currency, error) {
 		Code:        cur.Code,
 		Type:        t,
 		IsAvailable: cur.IsAvailable,
+		Version:     cur.Version,
+		DeletedAt:   cur.DeletedAt,
 	}, nil
 }
 
@@ -35,3 +37,34 @@ func CurrenciesToEntity(cur []storageentity.Currency) (entity.Currencies, error)
 
 	return res, nil
 }
+
+func AuditToEntity(a storageentity.Audit) (entity.AuditRecord, error) {
+	res := entity.AuditRecord{
+		ID:         a.ID,
+		CurrencyID: a.CurrencyID,
+		Action:     entity.AuditAction(a.Action),
+		Actor:      a.Actor,
+		RequestID:  a.RequestID,
+		CreatedAt:  a.CreatedAt,
+	}
+
+	if a.Before != nil {
+		before, err := CurrencyToEntity(*a.Before)
+		if err != nil {
+			return entity.AuditRecord{}, errors.Wrap(err, "convert before snapshot")
+		}
+
+		res.Before = &before
+	}
+
+	if a.After != nil {
+		after, err := CurrencyToEntity(*a.After)
+		if err != nil {
+			return entity.AuditRecord{}, errors.Wrap(err, "convert after snapshot")
+		}
+
+		res.After = &after
+	}
+
+	return res, nil
+}
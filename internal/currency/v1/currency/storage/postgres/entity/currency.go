@@ -1,13 +1,31 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+)
+
+// Currency is also the snapshot of audit records, so it has json tags.
 type Currency struct {
-	ID          uuid.UUID `db:"id"`
-	Name        string    `db:"name"`
-	Code        string    `db:"code"`
-	Type        int       `db:"type"`
-	IsAvailable bool      `db:"is_available"`
+	ID          uuid.UUID  `db:"id" json:"id"`
+	Name        string     `db:"name" json:"name"`
+	Code        string     `db:"code" json:"code"`
+	Type        int        `db:"type" json:"type"`
+	IsAvailable bool       `db:"is_available" json:"isAvailable"`
+	Version     int64      `db:"version" json:"version"`
+	DeletedAt   *time.Time `db:"deleted_at" json:"deletedAt,omitempty"`
 }
 
 type Currencies []Currency
+
+type Audit struct {
+	ID         uuid.UUID `db:"id"`
+	CurrencyID uuid.UUID `db:"currency_id"`
+	Action     string    `db:"action"`
+	Actor      string    `db:"actor"`
+	RequestID  string    `db:"request_id"`
+	Before     *Currency `db:"before"`
+	After      *Currency `db:"after"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -13,29 +14,41 @@ import (
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/storage/postgres/converter"
 	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/storage/postgres/entity"
+	"github.com/veleton777/test_work_blum/internal/pkg/reqctx"
 )
 
 const (
 	currenciesTable = "currencies"
+	auditTable      = "currency_audit"
+
+	returningCurrency = "RETURNING id, name, code, type, is_available, version, deleted_at"
 
 	pgxDuplicateKeyCode = "23505"
 )
 
+var currencyColumns = []string{ //nolint:gochecknoglobals
+	"id", "name", "code", "type", "is_available", "version", "deleted_at",
+}
+
 type RepoPostgres struct {
 	pgClient *pgxpool.Pool
 	timeout  time.Duration
+	// importTimeout limits ImportCurrencies, which takes longer than the other queries
+	importTimeout time.Duration
 }
 
-func NewRepoPostgres(pgClient *pgxpool.Pool, timeout time.Duration) *RepoPostgres {
-	return &RepoPostgres{pgClient: pgClient, timeout: timeout}
+func NewRepoPostgres(pgClient *pgxpool.Pool, timeout, importTimeout time.Duration) *RepoPostgres {
+	return &RepoPostgres{pgClient: pgClient, timeout: timeout, importTimeout: importTimeout}
 }
 
 func (r *RepoPostgres) GetCurrencies(ctx context.Context) (entity.Currencies, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	builder := squirrel.Select("id", "name", "code", "type", "is_available").
-		From(currenciesTable)
+	builder := squirrel.Select(currencyColumns...).
+		From(currenciesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"deleted_at": nil})
 
 	query, v, err := builder.ToSql()
 	if err != nil {
@@ -61,14 +74,86 @@ func (r *RepoPostgres) GetCurrencies(ctx context.Context) (entity.Currencies, er
 	return res, nil
 }
 
+func (r *RepoPostgres) GetCurrency(ctx context.Context, id uuid.UUID) (entity.Currency, error) {
+	return r.getCurrency(ctx, squirrel.Eq{"id": id, "deleted_at": nil})
+}
+
 func (r *RepoPostgres) GetCurrencyByCode(ctx context.Context, code string) (entity.Currency, error) {
+	return r.getCurrency(ctx, squirrel.Eq{"code": code, "deleted_at": nil})
+}
+
+// ListCurrencies returns a page of currencies matching the filter using keyset pagination.
+func (r *RepoPostgres) ListCurrencies(ctx context.Context, filter entity.CurrencyFilter) (entity.Currencies, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	builder := squirrel.Select("id", "name", "code", "type", "is_available").
+	sortBy := string(entity.SortByCode)
+	if filter.SortBy == entity.SortByName {
+		sortBy = string(entity.SortByName)
+	}
+
+	order, cmp := "ASC", ">"
+	if filter.Desc {
+		order, cmp = "DESC", "<"
+	}
+
+	builder := squirrel.Select(currencyColumns...).
 		From(currenciesTable).
 		PlaceholderFormat(squirrel.Dollar).
-		Where(squirrel.Eq{"code": code})
+		Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy(sortBy+" "+order, "id "+order).
+		Limit(uint64(filter.Limit))
+
+	if filter.Type != 0 {
+		builder = builder.Where(squirrel.Eq{"type": filter.Type})
+	}
+
+	if filter.IsAvailable != nil {
+		builder = builder.Where(squirrel.Eq{"is_available": *filter.IsAvailable})
+	}
+
+	if filter.Search != "" {
+		builder = builder.Where(squirrel.ILike{"name": "%" + escapeLike(filter.Search) + "%"})
+	}
+
+	if filter.After != nil {
+		builder = builder.Where(
+			squirrel.Expr("("+sortBy+", id) "+cmp+" (?, ?)", filter.After.Value, filter.After.ID),
+		)
+	}
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx query")
+	}
+	defer rows.Close()
+
+	currencies, err := pgx.CollectRows(rows, pgx.RowToStructByName[storageentity.Currency])
+	if err != nil {
+		return nil, errors.Wrap(err, "scan resp to struct")
+	}
+
+	res, err := converter.CurrenciesToEntity(currencies)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert currencies to entity")
+	}
+
+	return res, nil
+}
+
+func (r *RepoPostgres) getCurrency(ctx context.Context, where squirrel.Eq) (entity.Currency, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select(currencyColumns...).
+		From(currenciesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(where)
 
 	query, v, err := builder.ToSql()
 	if err != nil {
@@ -82,6 +167,10 @@ func (r *RepoPostgres) GetCurrencyByCode(ctx context.Context, code string) (enti
 
 	currency, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[storageentity.Currency])
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Currency{}, entity.ErrEntityNotFound
+		}
+
 		return entity.Currency{}, errors.Wrap(err, "scan resp to struct")
 	}
 
@@ -93,84 +182,424 @@ func (r *RepoPostgres) GetCurrencyByCode(ctx context.Context, code string) (enti
 	return res, nil
 }
 
+// CreateCurrency saves the currency with its audit record in one transaction.
 func (r *RepoPostgres) CreateCurrency(ctx context.Context, currency entity.Currency) error {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	builder := squirrel.Insert(currenciesTable).
-		PlaceholderFormat(squirrel.Dollar).
-		Columns("id", "name", "code", "type", "is_available").
-		Values(currency.ID, currency.Name, currency.Code, currency.Type, currency.IsAvailable)
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		after, err := r.insertCurrency(ctx, tx, currency)
+		if err != nil {
+			return err
+		}
 
-	query, v, err := builder.ToSql()
+		return r.saveAudit(ctx, tx, entity.AuditCreate, nil, &after)
+	})
+}
+
+// UpdateCurrency saves the currency when its version is still the stored one and
+// returns the increased version. ErrVersionConflict is returned when the currency
+// was updated after the version was read.
+func (r *RepoPostgres) UpdateCurrency(ctx context.Context, currency entity.Currency) (int64, error) {
+	return r.updateCurrency(ctx, currency.ID, currency.Version, map[string]any{
+		"name":         currency.Name,
+		"code":         currency.Code,
+		"type":         currency.Type,
+		"is_available": currency.IsAvailable,
+	})
+}
+
+// PatchCurrency updates only the supplied columns of the currency.
+func (r *RepoPostgres) PatchCurrency(ctx context.Context, patch entity.CurrencyPatch) (int64, error) {
+	values := make(map[string]any)
+
+	if patch.Name != nil {
+		values["name"] = *patch.Name
+	}
+
+	if patch.Code != nil {
+		values["code"] = *patch.Code
+	}
+
+	if patch.Type != nil {
+		values["type"] = *patch.Type
+	}
+
+	if patch.IsAvailable != nil {
+		values["is_available"] = *patch.IsAvailable
+	}
+
+	return r.updateCurrency(ctx, patch.ID, patch.Version, values)
+}
+
+// updateCurrency sets the values when the currency is still of the given version and
+// returns the new version. The change is audited in the same transaction.
+func (r *RepoPostgres) updateCurrency(ctx context.Context, id uuid.UUID, version int64, values map[string]any) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var newVersion int64
+
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.lockCurrency(ctx, tx, squirrel.Eq{"id": id, "deleted_at": nil})
+		if err != nil {
+			return err
+		}
+
+		if before.Version != version {
+			return entity.ErrVersionConflict
+		}
+
+		after, err := r.setCurrency(ctx, tx, id, values)
+		if err != nil {
+			return err
+		}
+
+		newVersion = after.Version
+
+		return r.saveAudit(ctx, tx, entity.AuditUpdate, &before, &after)
+	})
 	if err != nil {
-		return errors.Wrap(err, "query to sql")
+		return 0, err
 	}
 
-	if _, err = r.pgClient.Exec(ctx, query, v...); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == pgxDuplicateKeyCode {
-				return entity.ErrCurrencyAlreadyExists
-			}
+	return newVersion, nil
+}
+
+// ImportCurrencies upserts the currencies by code in one transaction and returns what happened to every
+// currency. Unchanged currencies keep their version and deleted ones are restored. In dry run the
+// transaction is rolled back.
+func (r *RepoPostgres) ImportCurrencies(
+	ctx context.Context,
+	currencies entity.Currencies,
+	dryRun bool,
+) ([]entity.ImportAction, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.importTimeout)
+	defer cancel()
+
+	tx, err := r.pgClient.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin pg tx")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	res := make([]entity.ImportAction, 0, len(currencies))
+
+	for _, currency := range currencies {
+		action, err := r.upsertCurrency(ctx, tx, currency)
+		if err != nil {
+			return nil, errors.Wrapf(err, "upsert currency %s", currency.Code)
 		}
 
-		return errors.Wrap(err, "exec pg query")
+		res = append(res, action)
 	}
 
-	return nil
+	if dryRun {
+		return res, nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit pg tx")
+	}
+
+	return res, nil
 }
 
-func (r *RepoPostgres) UpdateCurrency(ctx context.Context, currency entity.Currency) error {
+func (r *RepoPostgres) upsertCurrency(ctx context.Context, tx pgx.Tx, currency entity.Currency) (entity.ImportAction, error) {
+	before, err := r.lockCurrency(ctx, tx, squirrel.Eq{"code": currency.Code})
+	if errors.Is(err, entity.ErrEntityNotFound) {
+		after, err := r.insertCurrency(ctx, tx, currency)
+		if err != nil {
+			return "", err
+		}
+
+		return entity.ImportCreated, r.saveAudit(ctx, tx, entity.AuditCreate, nil, &after)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if before.DeletedAt == nil && before.Name == currency.Name && before.Type == int(currency.Type) &&
+		before.IsAvailable == currency.IsAvailable {
+		return entity.ImportUnchanged, nil
+	}
+
+	after, err := r.setCurrency(ctx, tx, before.ID, map[string]any{
+		"name":         currency.Name,
+		"type":         currency.Type,
+		"is_available": currency.IsAvailable,
+		"deleted_at":   nil,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	action := entity.AuditUpdate
+	if before.DeletedAt != nil {
+		action = entity.AuditRestore
+	}
+
+	return entity.ImportUpdated, r.saveAudit(ctx, tx, action, &before, &after)
+}
+
+// DeleteCurrency marks the currency deleted when its version is still the stored one.
+// The deleted currency keeps its code and history until it is purged.
+func (r *RepoPostgres) DeleteCurrency(ctx context.Context, id uuid.UUID, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.lockCurrency(ctx, tx, squirrel.Eq{"id": id, "deleted_at": nil})
+		if err != nil {
+			return err
+		}
+
+		if before.Version != version {
+			return entity.ErrVersionConflict
+		}
+
+		after, err := r.setCurrency(ctx, tx, id, map[string]any{"deleted_at": time.Now().UTC()})
+		if err != nil {
+			return err
+		}
+
+		return r.saveAudit(ctx, tx, entity.AuditDelete, &before, &after)
+	})
+}
+
+// RestoreCurrency brings back the deleted currency and returns it.
+func (r *RepoPostgres) RestoreCurrency(ctx context.Context, id uuid.UUID) (entity.Currency, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var after storageentity.Currency
+
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.lockCurrency(ctx, tx, squirrel.Eq{"id": id})
+		if err != nil {
+			return err
+		}
+
+		if before.DeletedAt == nil {
+			return entity.ErrCurrencyNotDeleted
+		}
+
+		if after, err = r.setCurrency(ctx, tx, id, map[string]any{"deleted_at": nil}); err != nil {
+			return err
+		}
+
+		return r.saveAudit(ctx, tx, entity.AuditRestore, &before, &after)
+	})
+	if err != nil {
+		return entity.Currency{}, err
+	}
+
+	res, err := converter.CurrencyToEntity(after)
+	if err != nil {
+		return entity.Currency{}, errors.Wrap(err, "convert currency to entity")
+	}
+
+	return res, nil
+}
+
+// PurgeCurrency removes the deleted currency for good, only its audit is left.
+func (r *RepoPostgres) PurgeCurrency(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.lockCurrency(ctx, tx, squirrel.Eq{"id": id})
+		if err != nil {
+			return err
+		}
+
+		if before.DeletedAt == nil {
+			return entity.ErrCurrencyNotDeleted
+		}
+
+		query, v, err := squirrel.Delete(currenciesTable).
+			PlaceholderFormat(squirrel.Dollar).
+			Where(squirrel.Eq{"id": id}).
+			ToSql()
+		if err != nil {
+			return errors.Wrap(err, "query to sql")
+		}
+
+		if _, err = tx.Exec(ctx, query, v...); err != nil {
+			return errors.Wrap(err, "exec pg query")
+		}
+
+		return r.saveAudit(ctx, tx, entity.AuditPurge, &before, nil)
+	})
+}
+
+// GetCurrencyAudit returns changes of the currency from the oldest one.
+func (r *RepoPostgres) GetCurrencyAudit(ctx context.Context, currencyID uuid.UUID) ([]entity.AuditRecord, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	builder := squirrel.Update(currenciesTable).
+	builder := squirrel.Select(
+		"id", "currency_id", "action", "actor", "request_id", "before", "after", "created_at",
+	).
+		From(auditTable).
 		PlaceholderFormat(squirrel.Dollar).
-		Where(squirrel.Eq{"id": currency.ID}).
-		Set("name", currency.Name).
-		Set("code", currency.Code).
-		Set("type", currency.Type).
-		Set("is_available", currency.IsAvailable)
+		Where(squirrel.Eq{"currency_id": currencyID}).
+		OrderBy("created_at", "id")
 
 	query, v, err := builder.ToSql()
 	if err != nil {
-		return errors.Wrap(err, "query to sql")
+		return nil, errors.Wrap(err, "query to sql")
 	}
 
-	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	rows, err := r.pgClient.Query(ctx, query, v...)
 	if err != nil {
-		return errors.Wrap(err, "exec pg query")
+		return nil, errors.Wrap(err, "pgx query")
+	}
+	defer rows.Close()
+
+	audit, err := pgx.CollectRows(rows, pgx.RowToStructByName[storageentity.Audit])
+	if err != nil {
+		return nil, errors.Wrap(err, "scan resp to struct")
+	}
+
+	res := make([]entity.AuditRecord, 0, len(audit))
+
+	for _, a := range audit {
+		record, err := converter.AuditToEntity(a)
+		if err != nil {
+			return nil, errors.Wrap(err, "convert audit to entity")
+		}
+
+		res = append(res, record)
+	}
+
+	return res, nil
+}
+
+// inTx runs fn in a transaction which is committed when fn succeeds.
+func (r *RepoPostgres) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.pgClient.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "begin pg tx")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err = fn(tx); err != nil {
+		return err
 	}
 
-	if cmd.RowsAffected() == 0 {
-		return entity.ErrEntityNotFound
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "commit pg tx")
 	}
 
 	return nil
 }
 
-func (r *RepoPostgres) DeleteCurrency(ctx context.Context, id uuid.UUID) error {
-	ctx, cancel := context.WithTimeout(ctx, r.timeout)
-	defer cancel()
+// lockCurrency reads the currency and locks it until the end of the transaction.
+func (r *RepoPostgres) lockCurrency(ctx context.Context, tx pgx.Tx, where squirrel.Eq) (storageentity.Currency, error) {
+	return r.queryCurrency(ctx, tx, squirrel.Select(currencyColumns...).
+		From(currenciesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(where).
+		Suffix("FOR UPDATE"))
+}
 
-	builder := squirrel.Delete(currenciesTable).
+func (r *RepoPostgres) insertCurrency(ctx context.Context, tx pgx.Tx, currency entity.Currency) (storageentity.Currency, error) {
+	return r.queryCurrency(ctx, tx, squirrel.Insert(currenciesTable).
 		PlaceholderFormat(squirrel.Dollar).
-		Where(squirrel.Eq{"id": id})
+		Columns("id", "name", "code", "type", "is_available").
+		Values(currency.ID, currency.Name, currency.Code, currency.Type, currency.IsAvailable).
+		Suffix(returningCurrency))
+}
 
+// setCurrency updates the columns of the currency and increases its version.
+func (r *RepoPostgres) setCurrency(
+	ctx context.Context,
+	tx pgx.Tx,
+	id uuid.UUID,
+	values map[string]any,
+) (storageentity.Currency, error) {
+	return r.queryCurrency(ctx, tx, squirrel.Update(currenciesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"id": id}).
+		SetMap(values).
+		Set("version", squirrel.Expr("version + 1")).
+		Suffix(returningCurrency))
+}
+
+func (r *RepoPostgres) queryCurrency(ctx context.Context, tx pgx.Tx, builder squirrel.Sqlizer) (storageentity.Currency, error) {
 	query, v, err := builder.ToSql()
 	if err != nil {
-		return errors.Wrap(err, "query to sql")
+		return storageentity.Currency{}, errors.Wrap(err, "query to sql")
 	}
 
-	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	rows, err := tx.Query(ctx, query, v...)
 	if err != nil {
-		return errors.Wrap(err, "exec pg query")
+		return storageentity.Currency{}, errors.Wrap(err, "pgx query")
+	}
+
+	currency, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[storageentity.Currency])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storageentity.Currency{}, entity.ErrEntityNotFound
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgxDuplicateKeyCode {
+				return storageentity.Currency{}, entity.ErrCurrencyAlreadyExists
+			}
+		}
+
+		return storageentity.Currency{}, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return currency, nil
+}
+
+// saveAudit records the change made by the actor of the request in ctx.
+func (r *RepoPostgres) saveAudit(
+	ctx context.Context,
+	tx pgx.Tx,
+	action entity.AuditAction,
+	before, after *storageentity.Currency,
+) error {
+	currencyID := uuid.Nil
+	if after != nil {
+		currencyID = after.ID
+	} else if before != nil {
+		currencyID = before.ID
+	}
+
+	builder := squirrel.Insert(auditTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns("id", "currency_id", "action", "actor", "request_id", "before", "after", "created_at").
+		Values(
+			uuid.New(),
+			currencyID,
+			string(action),
+			reqctx.Actor(ctx),
+			reqctx.RequestID(ctx),
+			before,
+			after,
+			time.Now().UTC(),
+		)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
 	}
 
-	if cmd.RowsAffected() == 0 {
-		return entity.ErrEntityNotFound
+	if _, err = tx.Exec(ctx, query, v...); err != nil {
+		return errors.Wrap(err, "exec pg query")
 	}
 
 	return nil
 }
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`) //nolint:gochecknoglobals
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
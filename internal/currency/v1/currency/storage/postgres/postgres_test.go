@@ -15,6 +15,7 @@ import (
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/storage/postgres"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/storage/postgres/converter"
 	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/storage/postgres/entity"
+	"github.com/veleton777/test_work_blum/internal/pkg/reqctx"
 	"testing"
 	"time"
 )
@@ -52,7 +53,7 @@ func (s *Suite) SetupSuite() {
 	err = pgClient.Ping(ctx)
 	s.Require().NoError(err)
 
-	s.repo = postgres.NewRepoPostgres(pgClient, 1*time.Second)
+	s.repo = postgres.NewRepoPostgres(pgClient, 1*time.Second, 10*time.Second)
 	s.pgxClient = pgClient
 
 	s.clearCollection()
@@ -69,7 +70,7 @@ func (s *Suite) afterEachTest() {
 func (s *Suite) clearCollection() {
 	ctx := context.Background()
 
-	_, err := s.pgxClient.Exec(ctx, "TRUNCATE TABLE currencies")
+	_, err := s.pgxClient.Exec(ctx, "TRUNCATE TABLE currencies, currency_audit")
 	s.Require().NoError(err)
 }
 
@@ -141,14 +142,16 @@ func (s *Suite) TestUpdateCurrency_NoErr() {
 	currency.Type = 2
 	currency.IsAvailable = false
 
-	err = s.repo.UpdateCurrency(ctx, currency)
+	version, err := s.repo.UpdateCurrency(ctx, currency)
 	s.Require().NoError(err)
+	s.Require().Equal(currency.Version+1, version)
 
 	currencies, err := s.currencies(ctx)
 	s.Require().NoError(err)
 
 	s.Require().Equal(len(currencies), 1)
 
+	s.Require().Equal(version, currencies[0].Version)
 	s.Require().Equal(currency.ID, currencies[0].ID)
 	s.Require().Equal("name-2", currencies[0].Name)
 	s.Require().Equal("code-2", currencies[0].Code)
@@ -159,42 +162,374 @@ func (s *Suite) TestUpdateCurrency_NoErr() {
 func (s *Suite) TestUpdateCurrency_ReturnNotFoundErr() {
 	ctx := context.Background()
 
-	err := s.repo.UpdateCurrency(ctx, entity.Currency{
+	_, err := s.repo.UpdateCurrency(ctx, entity.Currency{
 		ID:          uuid.New(),
 		Name:        "test-1",
 		Code:        "code-1",
 		Type:        1,
 		IsAvailable: true,
+		Version:     1,
 	})
 	s.Require().Error(err)
 	s.Require().ErrorIs(err, entity.ErrEntityNotFound)
 }
 
+func (s *Suite) TestUpdateCurrency_ReturnVersionConflictErr() {
+	ctx := context.Background()
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	_, err = s.repo.UpdateCurrency(ctx, currency)
+	s.Require().NoError(err)
+
+	currency.Name = "name-2"
+
+	_, err = s.repo.UpdateCurrency(ctx, currency)
+	s.Require().ErrorIs(err, entity.ErrVersionConflict)
+
+	currencies, err := s.currencies(ctx)
+	s.Require().NoError(err)
+
+	s.Require().Equal("name-1", currencies[0].Name)
+}
+
+func (s *Suite) TestPatchCurrency_NoErr() {
+	ctx := context.Background()
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	isAvailable := false
+
+	version, err := s.repo.PatchCurrency(ctx, entity.CurrencyPatch{
+		ID:          currency.ID,
+		IsAvailable: &isAvailable,
+		Version:     currency.Version,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(currency.Version+1, version)
+
+	currencies, err := s.currencies(ctx)
+	s.Require().NoError(err)
+
+	s.Require().Equal(len(currencies), 1)
+
+	s.Require().Equal("name-1", currencies[0].Name)
+	s.Require().Equal("code-1", currencies[0].Code)
+	s.Require().Equal(1, currencies[0].Type)
+	s.Require().Equal(false, currencies[0].IsAvailable)
+	s.Require().Equal(version, currencies[0].Version)
+
+	_, err = s.repo.PatchCurrency(ctx, entity.CurrencyPatch{
+		ID:          currency.ID,
+		IsAvailable: &isAvailable,
+		Version:     currency.Version,
+	})
+	s.Require().ErrorIs(err, entity.ErrVersionConflict)
+}
+
+func (s *Suite) TestImportCurrencies() {
+	ctx := context.Background()
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	imported := entity.Currencies{
+		{ID: uuid.New(), Name: "name-1", Code: "code-1", Type: 1, IsAvailable: true},
+		{ID: uuid.New(), Name: "name-2", Code: "code-2", Type: 2, IsAvailable: true},
+	}
+
+	actions, err := s.repo.ImportCurrencies(ctx, imported, true)
+	s.Require().NoError(err)
+	s.Require().Equal([]entity.ImportAction{entity.ImportUnchanged, entity.ImportCreated}, actions)
+
+	currencies, err := s.currencies(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(currencies, 1)
+
+	imported[0].IsAvailable = false
+
+	actions, err = s.repo.ImportCurrencies(ctx, imported, false)
+	s.Require().NoError(err)
+	s.Require().Equal([]entity.ImportAction{entity.ImportUpdated, entity.ImportCreated}, actions)
+
+	updated, err := s.repo.GetCurrency(ctx, currency.ID)
+	s.Require().NoError(err)
+	s.Require().False(updated.IsAvailable)
+	s.Require().Equal(currency.Version+1, updated.Version)
+
+	created, err := s.repo.GetCurrencyByCode(ctx, "code-2")
+	s.Require().NoError(err)
+	s.Require().Equal(imported[1].ID, created.ID)
+}
+
+func (s *Suite) TestImportCurrencies_RollbackOnErr() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	// the last currency breaks primary key, so the first one is not saved either
+	_, err := s.repo.ImportCurrencies(ctx, entity.Currencies{
+		{ID: id, Name: "name-1", Code: "code-1", Type: 1, IsAvailable: true},
+		{ID: id, Name: "name-2", Code: "code-2", Type: 1, IsAvailable: true},
+	}, false)
+	s.Require().Error(err)
+
+	currencies, err := s.currencies(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(currencies, 0)
+}
+
+func (s *Suite) TestGetCurrencyAudit() {
+	ctx := reqctx.WithRequestID(reqctx.WithActor(context.Background(), "admin"), "req-1")
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	isAvailable := false
+
+	version, err := s.repo.PatchCurrency(ctx, entity.CurrencyPatch{
+		ID:          currency.ID,
+		IsAvailable: &isAvailable,
+		Version:     currency.Version,
+	})
+	s.Require().NoError(err)
+
+	err = s.repo.DeleteCurrency(context.Background(), currency.ID, version)
+	s.Require().NoError(err)
+
+	audit, err := s.repo.GetCurrencyAudit(ctx, currency.ID)
+	s.Require().NoError(err)
+	s.Require().Len(audit, 3)
+
+	s.Require().Equal(entity.AuditCreate, audit[0].Action)
+	s.Require().Equal("admin", audit[0].Actor)
+	s.Require().Equal("req-1", audit[0].RequestID)
+	s.Require().Nil(audit[0].Before)
+	s.Require().Equal(currency, *audit[0].After)
+
+	s.Require().Equal(entity.AuditUpdate, audit[1].Action)
+	s.Require().True(audit[1].Before.IsAvailable)
+	s.Require().False(audit[1].After.IsAvailable)
+	s.Require().Equal(version, audit[1].After.Version)
+
+	s.Require().Equal(entity.AuditDelete, audit[2].Action)
+	s.Require().Equal(reqctx.SystemActor, audit[2].Actor)
+	s.Require().Equal(version, audit[2].Before.Version)
+	s.Require().True(audit[2].After.IsDeleted())
+}
+
+func (s *Suite) TestGetCurrencyAudit_NotSavedOnErr() {
+	ctx := context.Background()
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	_, err = s.repo.UpdateCurrency(ctx, entity.Currency{ID: currency.ID, Name: "name-2", Code: "code-2", Type: 1, Version: 5})
+	s.Require().ErrorIs(err, entity.ErrVersionConflict)
+
+	audit, err := s.repo.GetCurrencyAudit(ctx, currency.ID)
+	s.Require().NoError(err)
+	s.Require().Len(audit, 1)
+}
+
 func (s *Suite) TestDeleteCurrency_NoErr() {
 	ctx := context.Background()
 
 	currency, err := s.createCurrency(ctx)
 	s.Require().NoError(err)
 
-	err = s.repo.DeleteCurrency(ctx, currency.ID)
+	err = s.repo.DeleteCurrency(ctx, currency.ID, currency.Version)
 	s.Require().NoError(err)
 
 	currencies, err := s.currencies(ctx)
 	s.Require().NoError(err)
 
-	s.Require().Equal(len(currencies), 0)
+	s.Require().Equal(len(currencies), 1)
+	s.Require().NotNil(currencies[0].DeletedAt)
+	s.Require().Equal(currency.Version+1, currencies[0].Version)
+
+	res, err := s.repo.GetCurrencies(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(res, 0)
+
+	_, err = s.repo.GetCurrency(ctx, currency.ID)
+	s.Require().ErrorIs(err, entity.ErrEntityNotFound)
+
+	currency.Version = currencies[0].Version
+
+	_, err = s.repo.UpdateCurrency(ctx, currency)
+	s.Require().ErrorIs(err, entity.ErrEntityNotFound)
+
+	err = s.repo.CreateCurrency(ctx, entity.Currency{ID: uuid.New(), Name: "name-1", Code: "code-1", Type: 1})
+	s.Require().ErrorIs(err, entity.ErrCurrencyAlreadyExists)
+}
+
+func (s *Suite) TestRestoreCurrency() {
+	ctx := context.Background()
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	_, err = s.repo.RestoreCurrency(ctx, currency.ID)
+	s.Require().ErrorIs(err, entity.ErrCurrencyNotDeleted)
+
+	err = s.repo.DeleteCurrency(ctx, currency.ID, currency.Version)
+	s.Require().NoError(err)
+
+	res, err := s.repo.RestoreCurrency(ctx, currency.ID)
+	s.Require().NoError(err)
+	s.Require().False(res.IsDeleted())
+	s.Require().Equal(currency.Version+2, res.Version)
+
+	restored, err := s.repo.GetCurrency(ctx, currency.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(res, restored)
+
+	_, err = s.repo.RestoreCurrency(ctx, uuid.New())
+	s.Require().ErrorIs(err, entity.ErrEntityNotFound)
+}
+
+func (s *Suite) TestPurgeCurrency() {
+	ctx := context.Background()
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	err = s.repo.PurgeCurrency(ctx, currency.ID)
+	s.Require().ErrorIs(err, entity.ErrCurrencyNotDeleted)
+
+	err = s.repo.DeleteCurrency(ctx, currency.ID, currency.Version)
+	s.Require().NoError(err)
+
+	err = s.repo.PurgeCurrency(ctx, currency.ID)
+	s.Require().NoError(err)
+
+	currencies, err := s.currencies(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(currencies, 0)
+
+	audit, err := s.repo.GetCurrencyAudit(ctx, currency.ID)
+	s.Require().NoError(err)
+	s.Require().Len(audit, 3)
+	s.Require().Equal(entity.AuditPurge, audit[2].Action)
+	s.Require().Nil(audit[2].After)
+}
+
+func (s *Suite) TestImportCurrencies_RestoresDeleted() {
+	ctx := context.Background()
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	err = s.repo.DeleteCurrency(ctx, currency.ID, currency.Version)
+	s.Require().NoError(err)
+
+	actions, err := s.repo.ImportCurrencies(ctx, entity.Currencies{
+		{ID: uuid.New(), Name: currency.Name, Code: currency.Code, Type: currency.Type, IsAvailable: currency.IsAvailable},
+	}, false)
+	s.Require().NoError(err)
+	s.Require().Equal([]entity.ImportAction{entity.ImportUpdated}, actions)
+
+	restored, err := s.repo.GetCurrency(ctx, currency.ID)
+	s.Require().NoError(err)
+	s.Require().False(restored.IsDeleted())
 }
 
 func (s *Suite) TestDeleteCurrency_ReturnNotFoundErr() {
 	ctx := context.Background()
 
-	err := s.repo.DeleteCurrency(ctx, uuid.New())
+	err := s.repo.DeleteCurrency(ctx, uuid.New(), 1)
 	s.Require().Error(err)
 	s.Require().ErrorIs(err, entity.ErrEntityNotFound)
 }
 
+func (s *Suite) TestDeleteCurrency_ReturnVersionConflictErr() {
+	ctx := context.Background()
+
+	currency, err := s.createCurrency(ctx)
+	s.Require().NoError(err)
+
+	err = s.repo.DeleteCurrency(ctx, currency.ID, currency.Version+1)
+	s.Require().ErrorIs(err, entity.ErrVersionConflict)
+
+	currencies, err := s.currencies(ctx)
+	s.Require().NoError(err)
+
+	s.Require().Equal(len(currencies), 1)
+}
+
+func (s *Suite) TestGetCurrency_ReturnNotFoundErr() {
+	ctx := context.Background()
+
+	_, err := s.repo.GetCurrency(ctx, uuid.New())
+	s.Require().ErrorIs(err, entity.ErrEntityNotFound)
+
+	_, err = s.repo.GetCurrencyByCode(ctx, "code-1")
+	s.Require().ErrorIs(err, entity.ErrEntityNotFound)
+}
+
+func (s *Suite) TestListCurrencies_NoErr() {
+	ctx := context.Background()
+
+	for _, c := range []entity.Currency{
+		{ID: uuid.New(), Name: "Bitcoin", Code: "BTC", Type: entity.TypeCrypto, IsAvailable: true},
+		{ID: uuid.New(), Name: "Dogecoin", Code: "DOGE", Type: entity.TypeCrypto, IsAvailable: false},
+		{ID: uuid.New(), Name: "Litecoin", Code: "LTC", Type: entity.TypeCrypto, IsAvailable: true},
+		{ID: uuid.New(), Name: "US Dollar", Code: "USD", Type: entity.TypeFiat, IsAvailable: true},
+		{ID: uuid.New(), Name: "100%_coin", Code: "PCT", Type: entity.TypeCrypto, IsAvailable: true},
+	} {
+		s.Require().NoError(s.repo.CreateCurrency(ctx, c))
+	}
+
+	isAvailable := true
+
+	res, err := s.repo.ListCurrencies(ctx, entity.CurrencyFilter{
+		Type:        entity.TypeCrypto,
+		IsAvailable: &isAvailable,
+		Search:      "COIN",
+		SortBy:      entity.SortByCode,
+		Limit:       2,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(res, 2)
+	s.Require().Equal("BTC", res[0].Code)
+	s.Require().Equal("LTC", res[1].Code)
+
+	res, err = s.repo.ListCurrencies(ctx, entity.CurrencyFilter{
+		Type:        entity.TypeCrypto,
+		IsAvailable: &isAvailable,
+		Search:      "COIN",
+		SortBy:      entity.SortByCode,
+		After:       &entity.CurrencyCursor{Value: res[1].Code, ID: res[1].ID},
+		Limit:       2,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(res, 1)
+	s.Require().Equal("PCT", res[0].Code)
+
+	res, err = s.repo.ListCurrencies(ctx, entity.CurrencyFilter{
+		SortBy: entity.SortByName,
+		Desc:   true,
+		Limit:  10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(res, 5)
+	s.Require().Equal("USD", res[0].Code)
+
+	res, err = s.repo.ListCurrencies(ctx, entity.CurrencyFilter{
+		Search: "%_",
+		SortBy: entity.SortByCode,
+		Limit:  10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(res, 1)
+	s.Require().Equal("PCT", res[0].Code)
+}
+
 func (s *Suite) currencies(ctx context.Context) (storageentity.Currencies, error) {
-	rows, err := s.pgxClient.Query(ctx, "SELECT id, name, code, type, is_available FROM currencies")
+	rows, err := s.pgxClient.Query(ctx, "SELECT id, name, code, type, is_available, version, deleted_at FROM currencies")
 	if err != nil {
 		return nil, errors.Wrap(err, "pgx query")
 	}
@@ -2,34 +2,132 @@ package currency
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	alertentity "github.com/veleton777/test_work_blum/internal/currency/v1/alert/entity"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	pairentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	quoteentity "github.com/veleton777/test_work_blum/internal/currency/v1/quote/entity"
+	rateentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
+	usageentity "github.com/veleton777/test_work_blum/internal/currency/v1/usage/entity"
 	"github.com/veleton777/test_work_blum/internal/dto"
 )
 
 //go:generate mockery --name Repo
 type Repo interface {
 	GetCurrencies(ctx context.Context) (entity.Currencies, error)
+	ListCurrencies(ctx context.Context, filter entity.CurrencyFilter) (entity.Currencies, error)
+	GetCurrency(ctx context.Context, id uuid.UUID) (entity.Currency, error)
+	GetCurrencyByCode(ctx context.Context, code string) (entity.Currency, error)
 	CreateCurrency(ctx context.Context, currency entity.Currency) error
-	UpdateCurrency(ctx context.Context, currency entity.Currency) error
-	DeleteCurrency(ctx context.Context, id uuid.UUID) error
+	UpdateCurrency(ctx context.Context, currency entity.Currency) (int64, error)
+	PatchCurrency(ctx context.Context, patch entity.CurrencyPatch) (int64, error)
+	DeleteCurrency(ctx context.Context, id uuid.UUID, version int64) error
+	RestoreCurrency(ctx context.Context, id uuid.UUID) (entity.Currency, error)
+	PurgeCurrency(ctx context.Context, id uuid.UUID) error
+	ImportCurrencies(ctx context.Context, currencies entity.Currencies, dryRun bool) ([]entity.ImportAction, error)
+	GetCurrencyAudit(ctx context.Context, currencyID uuid.UUID) ([]entity.AuditRecord, error)
+}
+
+//go:generate mockery --name QuoteRepo
+type QuoteRepo interface {
+	CreateQuote(ctx context.Context, quote quoteentity.Quote) error
+	GetQuote(ctx context.Context, id uuid.UUID) (quoteentity.Quote, error)
+	MarkExecuted(ctx context.Context, id uuid.UUID, executedAt time.Time) error
+}
+
+//go:generate mockery --name PricingRepo
+type PricingRepo interface {
+	GetPricings(ctx context.Context) (pricingentity.Pricings, error)
+	CreatePricing(ctx context.Context, pricing pricingentity.Pricing) error
+	UpdatePricing(ctx context.Context, pricing pricingentity.Pricing) error
+	DeletePricing(ctx context.Context, id uuid.UUID) error
+}
+
+//go:generate mockery --name PairRepo
+type PairRepo interface {
+	GetPairs(ctx context.Context) (pairentity.Pairs, error)
+	CreatePair(ctx context.Context, pair pairentity.Pair) error
+	UpdatePair(ctx context.Context, pair pairentity.Pair) error
+	DeletePair(ctx context.Context, id uuid.UUID) error
+}
+
+//go:generate mockery --name AlertRepo
+type AlertRepo interface {
+	CreateAlert(ctx context.Context, alert alertentity.Alert) error
+	GetAlerts(ctx context.Context) (alertentity.Alerts, error)
+	GetAlertsByPair(ctx context.Context, codeFrom, codeTo string) (alertentity.Alerts, error)
+	DeleteAlert(ctx context.Context, id uuid.UUID) error
+	MarkTriggered(ctx context.Context, id uuid.UUID, triggeredAt time.Time) error
+	SaveDelivery(ctx context.Context, delivery alertentity.Delivery) error
+	GetDeliveries(ctx context.Context, alertID uuid.UUID) ([]alertentity.Delivery, error)
+}
+
+//go:generate mockery --name RatesRepo
+type RatesRepo interface {
+	SaveRate(ctx context.Context, rate rateentity.Rate) error
+	GetRates(ctx context.Context, codeFrom, codeTo string, start, end time.Time, limit uint64) (rateentity.Rates, error)
+	GetRateAt(ctx context.Context, codeFrom, codeTo string, at time.Time) (rateentity.Rate, error)
+	GetCandles(
+		ctx context.Context,
+		codeFrom, codeTo string,
+		interval rateentity.CandleInterval,
+		start, end time.Time,
+	) (rateentity.Candles, error)
 }
 
 //go:generate mockery --name CurrenciesAPI
 type CurrenciesAPI interface {
-	Convert(ctx context.Context, from, to string, amount float64) (float64, error)
+	Name() string
+	FetchMulti(ctx context.Context, from string, to []string) (map[string]decimal.Decimal, error)
+	Historical(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, error)
+}
+
+//go:generate mockery --name UsageRepo
+type UsageRepo interface {
+	AddCalls(ctx context.Context, provider string, periodStart time.Time, calls int64) error
+	SetCalls(ctx context.Context, provider string, periodStart time.Time, calls int64) error
+	GetUsage(ctx context.Context, provider string, periodStart time.Time) (usageentity.Usage, error)
+}
+
+//go:generate mockery --name UsageAPI
+type UsageAPI interface {
+	Name() string
+	TakeCalls() int64
+	TakeFetchCalls() int64
+	Usage(ctx context.Context) (int64, error)
+}
+
+//go:generate mockery --name AlertEvaluator
+type AlertEvaluator interface {
+	Evaluate(ctx context.Context, tick dto.RateTick)
+}
+
+//go:generate mockery --name WebhookSender
+type WebhookSender interface {
+	Send(ctx context.Context, url, secret string, body []byte) (int, error)
+	Validate(ctx context.Context, url string) error
 }
 
 //go:generate mockery --name CourseStorage
 type CourseStorage interface {
 	Set(ctx context.Context, codeFrom, codeTo string, data dto.CurrencyStorageDTO)
-	Get(ctx context.Context, codeFrom, codeTo string) (decimal.Decimal, bool)
+	Get(ctx context.Context, codeFrom, codeTo string) (dto.CurrencyStorageDTO, bool)
+	GetMany(ctx context.Context, pairs []dto.CurrencyPair) map[dto.CurrencyPair]dto.CurrencyStorageDTO
+	Retain(ctx context.Context, pairs []dto.CurrencyPair)
+	RemoveCurrency(ctx context.Context, code string)
 }
 
 //go:generate mockery --name Storage
 type Storage interface {
 	Set(codeFrom, codeTo string, data dto.CurrencyStorageDTO)
-	Get(codeFrom, codeTo string) (decimal.Decimal, bool)
+	Get(codeFrom, codeTo string) (dto.CurrencyStorageDTO, bool)
+}
+
+//go:generate mockery --name Converter
+type Converter interface {
+	Convert(ctx context.Context, conversion dto.Conversion) (dto.ConversionResult, error)
 }
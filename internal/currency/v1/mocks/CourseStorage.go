@@ -5,8 +5,6 @@ package mocks
 import (
 	context "context"
 
-	decimal "github.com/shopspring/decimal"
-
 	dto "github.com/veleton777/test_work_blum/internal/dto"
 
 	mock "github.com/stretchr/testify/mock"
@@ -18,22 +16,22 @@ type CourseStorage struct {
 }
 
 // Get provides a mock function with given fields: ctx, codeFrom, codeTo
-func (_m *CourseStorage) Get(ctx context.Context, codeFrom string, codeTo string) (decimal.Decimal, bool) {
+func (_m *CourseStorage) Get(ctx context.Context, codeFrom string, codeTo string) (dto.CurrencyStorageDTO, bool) {
 	ret := _m.Called(ctx, codeFrom, codeTo)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 decimal.Decimal
+	var r0 dto.CurrencyStorageDTO
 	var r1 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (decimal.Decimal, bool)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (dto.CurrencyStorageDTO, bool)); ok {
 		return rf(ctx, codeFrom, codeTo)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) decimal.Decimal); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) dto.CurrencyStorageDTO); ok {
 		r0 = rf(ctx, codeFrom, codeTo)
 	} else {
-		r0 = ret.Get(0).(decimal.Decimal)
+		r0 = ret.Get(0).(dto.CurrencyStorageDTO)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
@@ -45,6 +43,36 @@ func (_m *CourseStorage) Get(ctx context.Context, codeFrom string, codeTo string
 	return r0, r1
 }
 
+// GetMany provides a mock function with given fields: ctx, pairs
+func (_m *CourseStorage) GetMany(ctx context.Context, pairs []dto.CurrencyPair) map[dto.CurrencyPair]dto.CurrencyStorageDTO {
+	ret := _m.Called(ctx, pairs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 map[dto.CurrencyPair]dto.CurrencyStorageDTO
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.CurrencyPair) map[dto.CurrencyPair]dto.CurrencyStorageDTO); ok {
+		r0 = rf(ctx, pairs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[dto.CurrencyPair]dto.CurrencyStorageDTO)
+		}
+	}
+
+	return r0
+}
+
+// RemoveCurrency provides a mock function with given fields: ctx, code
+func (_m *CourseStorage) RemoveCurrency(ctx context.Context, code string) {
+	_m.Called(ctx, code)
+}
+
+// Retain provides a mock function with given fields: ctx, pairs
+func (_m *CourseStorage) Retain(ctx context.Context, pairs []dto.CurrencyPair) {
+	_m.Called(ctx, pairs)
+}
+
 // Set provides a mock function with given fields: ctx, codeFrom, codeTo, data
 func (_m *CourseStorage) Set(ctx context.Context, codeFrom string, codeTo string, data dto.CurrencyStorageDTO) {
 	_m.Called(ctx, codeFrom, codeTo, data)
@@ -5,7 +5,11 @@ package mocks
 import (
 	context "context"
 
+	decimal "github.com/shopspring/decimal"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // CurrenciesAPI is an autogenerated mock type for the CurrenciesAPI type
@@ -13,27 +17,29 @@ type CurrenciesAPI struct {
 	mock.Mock
 }
 
-// Convert provides a mock function with given fields: ctx, from, to, amount
-func (_m *CurrenciesAPI) Convert(ctx context.Context, from string, to string, amount float64) (float64, error) {
-	ret := _m.Called(ctx, from, to, amount)
+// FetchMulti provides a mock function with given fields: ctx, from, to
+func (_m *CurrenciesAPI) FetchMulti(ctx context.Context, from string, to []string) (map[string]decimal.Decimal, error) {
+	ret := _m.Called(ctx, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Convert")
+		panic("no return value specified for FetchMulti")
 	}
 
-	var r0 float64
+	var r0 map[string]decimal.Decimal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64) (float64, error)); ok {
-		return rf(ctx, from, to, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (map[string]decimal.Decimal, error)); ok {
+		return rf(ctx, from, to)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64) float64); ok {
-		r0 = rf(ctx, from, to, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string]decimal.Decimal); ok {
+		r0 = rf(ctx, from, to)
 	} else {
-		r0 = ret.Get(0).(float64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]decimal.Decimal)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, float64) error); ok {
-		r1 = rf(ctx, from, to, amount)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, from, to)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -41,6 +47,52 @@ func (_m *CurrenciesAPI) Convert(ctx context.Context, from string, to string, am
 	return r0, r1
 }
 
+// Historical provides a mock function with given fields: ctx, from, to, at
+func (_m *CurrenciesAPI) Historical(ctx context.Context, from string, to string, at time.Time) (decimal.Decimal, error) {
+	ret := _m.Called(ctx, from, to, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Historical")
+	}
+
+	var r0 decimal.Decimal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (decimal.Decimal, error)); ok {
+		return rf(ctx, from, to, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) decimal.Decimal); ok {
+		r0 = rf(ctx, from, to, at)
+	} else {
+		r0 = ret.Get(0).(decimal.Decimal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, from, to, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with no fields
+func (_m *CurrenciesAPI) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // NewCurrenciesAPI creates a new instance of CurrenciesAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCurrenciesAPI(t interface {
@@ -35,17 +35,17 @@ func (_m *Repo) CreateCurrency(ctx context.Context, _a1 entity.Currency) error {
 	return r0
 }
 
-// DeleteCurrency provides a mock function with given fields: ctx, id
-func (_m *Repo) DeleteCurrency(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// DeleteCurrency provides a mock function with given fields: ctx, id, version
+func (_m *Repo) DeleteCurrency(ctx context.Context, id uuid.UUID, version int64) error {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteCurrency")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -83,22 +83,252 @@ func (_m *Repo) GetCurrencies(ctx context.Context) (entity.Currencies, error) {
 	return r0, r1
 }
 
+// GetCurrency provides a mock function with given fields: ctx, id
+func (_m *Repo) GetCurrency(ctx context.Context, id uuid.UUID) (entity.Currency, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrency")
+	}
+
+	var r0 entity.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (entity.Currency, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) entity.Currency); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.Currency)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrencyAudit provides a mock function with given fields: ctx, currencyID
+func (_m *Repo) GetCurrencyAudit(ctx context.Context, currencyID uuid.UUID) ([]entity.AuditRecord, error) {
+	ret := _m.Called(ctx, currencyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrencyAudit")
+	}
+
+	var r0 []entity.AuditRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]entity.AuditRecord, error)); ok {
+		return rf(ctx, currencyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []entity.AuditRecord); ok {
+		r0 = rf(ctx, currencyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AuditRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, currencyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrencyByCode provides a mock function with given fields: ctx, code
+func (_m *Repo) GetCurrencyByCode(ctx context.Context, code string) (entity.Currency, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrencyByCode")
+	}
+
+	var r0 entity.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Currency, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Currency); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(entity.Currency)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportCurrencies provides a mock function with given fields: ctx, currencies, dryRun
+func (_m *Repo) ImportCurrencies(ctx context.Context, currencies entity.Currencies, dryRun bool) ([]entity.ImportAction, error) {
+	ret := _m.Called(ctx, currencies, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportCurrencies")
+	}
+
+	var r0 []entity.ImportAction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Currencies, bool) ([]entity.ImportAction, error)); ok {
+		return rf(ctx, currencies, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Currencies, bool) []entity.ImportAction); ok {
+		r0 = rf(ctx, currencies, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ImportAction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Currencies, bool) error); ok {
+		r1 = rf(ctx, currencies, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCurrencies provides a mock function with given fields: ctx, filter
+func (_m *Repo) ListCurrencies(ctx context.Context, filter entity.CurrencyFilter) (entity.Currencies, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCurrencies")
+	}
+
+	var r0 entity.Currencies
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.CurrencyFilter) (entity.Currencies, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.CurrencyFilter) entity.Currencies); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(entity.Currencies)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.CurrencyFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchCurrency provides a mock function with given fields: ctx, patch
+func (_m *Repo) PatchCurrency(ctx context.Context, patch entity.CurrencyPatch) (int64, error) {
+	ret := _m.Called(ctx, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchCurrency")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.CurrencyPatch) (int64, error)); ok {
+		return rf(ctx, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.CurrencyPatch) int64); ok {
+		r0 = rf(ctx, patch)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.CurrencyPatch) error); ok {
+		r1 = rf(ctx, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeCurrency provides a mock function with given fields: ctx, id
+func (_m *Repo) PurgeCurrency(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeCurrency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreCurrency provides a mock function with given fields: ctx, id
+func (_m *Repo) RestoreCurrency(ctx context.Context, id uuid.UUID) (entity.Currency, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreCurrency")
+	}
+
+	var r0 entity.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (entity.Currency, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) entity.Currency); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.Currency)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateCurrency provides a mock function with given fields: ctx, _a1
-func (_m *Repo) UpdateCurrency(ctx context.Context, _a1 entity.Currency) error {
+func (_m *Repo) UpdateCurrency(ctx context.Context, _a1 entity.Currency) (int64, error) {
 	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateCurrency")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Currency) error); ok {
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Currency) (int64, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Currency) int64); ok {
 		r0 = rf(ctx, _a1)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Currency) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewRepo creates a new instance of Repo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -3,7 +3,6 @@
 package mocks
 
 import (
-	decimal "github.com/shopspring/decimal"
 	mock "github.com/stretchr/testify/mock"
 	dto "github.com/veleton777/test_work_blum/internal/dto"
 )
@@ -14,22 +13,22 @@ type Storage struct {
 }
 
 // Get provides a mock function with given fields: codeFrom, codeTo
-func (_m *Storage) Get(codeFrom string, codeTo string) (decimal.Decimal, bool) {
+func (_m *Storage) Get(codeFrom string, codeTo string) (dto.CurrencyStorageDTO, bool) {
 	ret := _m.Called(codeFrom, codeTo)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 decimal.Decimal
+	var r0 dto.CurrencyStorageDTO
 	var r1 bool
-	if rf, ok := ret.Get(0).(func(string, string) (decimal.Decimal, bool)); ok {
+	if rf, ok := ret.Get(0).(func(string, string) (dto.CurrencyStorageDTO, bool)); ok {
 		return rf(codeFrom, codeTo)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) decimal.Decimal); ok {
+	if rf, ok := ret.Get(0).(func(string, string) dto.CurrencyStorageDTO); ok {
 		r0 = rf(codeFrom, codeTo)
 	} else {
-		r0 = ret.Get(0).(decimal.Decimal)
+		r0 = ret.Get(0).(dto.CurrencyStorageDTO)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) bool); ok {
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/veleton777/test_work_blum/internal/currency/v1/usage/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UsageRepo is an autogenerated mock type for the UsageRepo type
+type UsageRepo struct {
+	mock.Mock
+}
+
+// AddCalls provides a mock function with given fields: ctx, provider, periodStart, calls
+func (_m *UsageRepo) AddCalls(ctx context.Context, provider string, periodStart time.Time, calls int64) error {
+	ret := _m.Called(ctx, provider, periodStart, calls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCalls")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int64) error); ok {
+		r0 = rf(ctx, provider, periodStart, calls)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUsage provides a mock function with given fields: ctx, provider, periodStart
+func (_m *UsageRepo) GetUsage(ctx context.Context, provider string, periodStart time.Time) (entity.Usage, error) {
+	ret := _m.Called(ctx, provider, periodStart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsage")
+	}
+
+	var r0 entity.Usage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (entity.Usage, error)); ok {
+		return rf(ctx, provider, periodStart)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) entity.Usage); ok {
+		r0 = rf(ctx, provider, periodStart)
+	} else {
+		r0 = ret.Get(0).(entity.Usage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, provider, periodStart)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCalls provides a mock function with given fields: ctx, provider, periodStart, calls
+func (_m *UsageRepo) SetCalls(ctx context.Context, provider string, periodStart time.Time, calls int64) error {
+	ret := _m.Called(ctx, provider, periodStart, calls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCalls")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int64) error); ok {
+		r0 = rf(ctx, provider, periodStart, calls)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewUsageRepo creates a new instance of UsageRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsageRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsageRepo {
+	mock := &UsageRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,154 @@
+package currency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	pairentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+)
+
+type PairSvc struct {
+	pairRepo     PairRepo
+	currencyRepo Repo
+	opts         PairOptions
+}
+
+type PairOptions struct {
+	// PivotCode is the currency which may be paired with any other one regardless of the pairing rules
+	PivotCode string
+	// PairingRules decide which pairs of currency types may be registered, any pair may be when nil
+	PairingRules entity.PairingRules
+}
+
+func NewPairSvc(pairRepo PairRepo, currencyRepo Repo, opts PairOptions) *PairSvc {
+	return &PairSvc{
+		pairRepo:     pairRepo,
+		currencyRepo: currencyRepo,
+		opts:         opts,
+	}
+}
+
+func (s *PairSvc) GetPairs(ctx context.Context) ([]dto.Pair, error) {
+	pairs, err := s.pairRepo.GetPairs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get pairs from storage")
+	}
+
+	res := make([]dto.Pair, 0, len(pairs))
+	for _, p := range pairs {
+		res = append(res, pairToDTO(p))
+	}
+
+	return res, nil
+}
+
+func (s *PairSvc) CreatePair(ctx context.Context, req dto.SavePair) (dto.Pair, error) {
+	pair := pairFromDTO(req)
+	pair.ID = uuid.New()
+	pair.CreatedAt = time.Now().UTC()
+
+	if err := s.checkPair(ctx, pair); err != nil {
+		return dto.Pair{}, err
+	}
+
+	if err := s.pairRepo.CreatePair(ctx, pair); err != nil {
+		return dto.Pair{}, errors.Wrap(err, "save pair to storage")
+	}
+
+	return pairToDTO(pair), nil
+}
+
+func (s *PairSvc) UpdatePair(ctx context.Context, req dto.SavePair) error {
+	pair := pairFromDTO(req)
+
+	if err := s.checkPair(ctx, pair); err != nil {
+		return err
+	}
+
+	if err := s.pairRepo.UpdatePair(ctx, pair); err != nil {
+		return errors.Wrap(err, "update pair in storage")
+	}
+
+	return nil
+}
+
+func (s *PairSvc) DeletePair(ctx context.Context, id uuid.UUID) error {
+	if err := s.pairRepo.DeletePair(ctx, id); err != nil {
+		return errors.Wrap(err, "delete pair from storage")
+	}
+
+	return nil
+}
+
+// checkPair validates the pair and allows it only between existing currencies
+// whose types are paired by the rules, pairs with the pivot are always allowed.
+func (s *PairSvc) checkPair(ctx context.Context, pair pairentity.Pair) error {
+	if err := pair.Validate(); err != nil {
+		return err
+	}
+
+	from, err := s.pairCurrency(ctx, pair.CodeFrom)
+	if err != nil {
+		return err
+	}
+
+	to, err := s.pairCurrency(ctx, pair.CodeTo)
+	if err != nil {
+		return err
+	}
+
+	if from.Code == s.opts.PivotCode || to.Code == s.opts.PivotCode {
+		return nil
+	}
+
+	if !s.opts.PairingRules.Pairs(from.Type, to.Type) {
+		return pairentity.ErrPairNotAllowed
+	}
+
+	return nil
+}
+
+func (s *PairSvc) pairCurrency(ctx context.Context, code string) (entity.Currency, error) {
+	currency, err := s.currencyRepo.GetCurrencyByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, entity.ErrEntityNotFound) {
+			return entity.Currency{}, pairentity.ErrUnknownCurrency
+		}
+
+		return entity.Currency{}, errors.Wrap(err, "get currency from storage")
+	}
+
+	return currency, nil
+}
+
+func pairFromDTO(p dto.SavePair) pairentity.Pair {
+	return pairentity.Pair{
+		ID:              p.ID,
+		CodeFrom:        p.From,
+		CodeTo:          p.To,
+		Enabled:         p.Enabled,
+		RefreshInterval: p.RefreshInterval,
+		Provider:        p.Provider,
+	}
+}
+
+func pairToDTO(p pairentity.Pair) dto.Pair {
+	res := dto.Pair{
+		ID:        p.ID,
+		From:      p.CodeFrom,
+		To:        p.CodeTo,
+		Enabled:   p.Enabled,
+		Provider:  p.Provider,
+		CreatedAt: p.CreatedAt,
+	}
+
+	if p.RefreshInterval > 0 {
+		res.RefreshInterval = p.RefreshInterval.String()
+	}
+
+	return res
+}
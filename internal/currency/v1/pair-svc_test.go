@@ -0,0 +1,246 @@
+package currency_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	pairentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"testing"
+	"time"
+)
+
+type PairServiceTestSuite struct {
+	suite.Suite
+	svc              *currency.PairSvc
+	mockPairRepo     *mocks.PairRepo
+	mockCurrencyRepo *mocks.Repo
+}
+
+func (s *PairServiceTestSuite) SetupTest() {
+	s.mockPairRepo = mocks.NewPairRepo(s.T())
+	s.mockCurrencyRepo = mocks.NewRepo(s.T())
+	s.svc = currency.NewPairSvc(s.mockPairRepo, s.mockCurrencyRepo, currency.PairOptions{
+		PivotCode: "USD",
+		PairingRules: entity.PairingRules{
+			entity.TypeCrypto: {entity.TypeFiat, entity.TypeStablecoin},
+			entity.TypeMetal:  {entity.TypeFiat},
+		},
+	})
+
+	currencies := entity.Currencies{
+		{Code: "USD", Type: entity.TypeFiat},
+		{Code: "EUR", Type: entity.TypeFiat},
+		{Code: "BTC", Type: entity.TypeCrypto},
+		{Code: "ETH", Type: entity.TypeCrypto},
+		{Code: "XAU", Type: entity.TypeMetal},
+	}
+
+	for _, c := range currencies {
+		s.mockCurrencyRepo.On("GetCurrencyByCode", mock.Anything, c.Code).Return(c, nil).Maybe()
+	}
+
+	s.mockCurrencyRepo.On("GetCurrencyByCode", mock.Anything, "XXX").
+		Return(entity.Currency{}, entity.ErrEntityNotFound).Maybe()
+}
+
+func TestPairServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PairServiceTestSuite))
+}
+
+func (s *PairServiceTestSuite) TestGetPairs_NoErr() {
+	ctx := context.Background()
+
+	id := uuid.New()
+	createdAt := time.Now().UTC()
+
+	s.mockPairRepo.On("GetPairs", ctx).
+		Return(pairentity.Pairs{{
+			ID:              id,
+			CodeFrom:        "BTC",
+			CodeTo:          "USD",
+			Enabled:         true,
+			RefreshInterval: 5 * time.Minute,
+			CreatedAt:       createdAt,
+		}}, nil).Once()
+
+	res, err := s.svc.GetPairs(ctx)
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), []dto.Pair{{
+		ID:              id,
+		From:            "BTC",
+		To:              "USD",
+		Enabled:         true,
+		RefreshInterval: "5m0s",
+		CreatedAt:       createdAt,
+	}}, res)
+}
+
+func (s *PairServiceTestSuite) TestCreatePair_NoErr() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		data dto.SavePair
+	}{
+		{name: "by_rules", data: dto.SavePair{From: "BTC", To: "EUR", Enabled: true}},
+		{name: "with_pivot", data: dto.SavePair{From: "BTC", To: "USD", Provider: "fastforex"}},
+		{name: "pivot_with_same_type", data: dto.SavePair{From: "EUR", To: "USD"}},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			s.mockPairRepo.On("CreatePair", ctx, mock.MatchedBy(func(p pairentity.Pair) bool {
+				return p.ID != uuid.Nil && p.CodeFrom == tc.data.From && p.CodeTo == tc.data.To &&
+					p.Enabled == tc.data.Enabled && p.Provider == tc.data.Provider && !p.CreatedAt.IsZero()
+			})).Return(nil).Once()
+
+			res, err := s.svc.CreatePair(ctx, tc.data)
+			require.NoError(t, err)
+			require.NotEqual(t, uuid.Nil, res.ID)
+		})
+	}
+}
+
+func (s *PairServiceTestSuite) TestCreatePair_Err() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		data     dto.SavePair
+		mockFunc func()
+		expErr   error
+	}{
+		{
+			name:     "same_codes",
+			data:     dto.SavePair{From: "BTC", To: "BTC"},
+			mockFunc: func() {},
+			expErr:   pairentity.ErrInvalidPair,
+		},
+		{
+			name:     "negative_interval",
+			data:     dto.SavePair{From: "BTC", To: "USD", RefreshInterval: -time.Second},
+			mockFunc: func() {},
+			expErr:   pairentity.ErrInvalidPair,
+		},
+		{
+			name:     "unknown_currency",
+			data:     dto.SavePair{From: "BTC", To: "XXX"},
+			mockFunc: func() {},
+			expErr:   pairentity.ErrUnknownCurrency,
+		},
+		{
+			name:     "not_allowed_by_rules",
+			data:     dto.SavePair{From: "BTC", To: "ETH"},
+			mockFunc: func() {},
+			expErr:   pairentity.ErrPairNotAllowed,
+		},
+		{
+			name:     "metal_and_crypto",
+			data:     dto.SavePair{From: "XAU", To: "BTC"},
+			mockFunc: func() {},
+			expErr:   pairentity.ErrPairNotAllowed,
+		},
+		{
+			name: "already_exists",
+			data: dto.SavePair{From: "BTC", To: "USD"},
+			mockFunc: func() {
+				s.mockPairRepo.On("CreatePair", ctx, mock.Anything).
+					Return(pairentity.ErrPairAlreadyExists).Once()
+			},
+			expErr: pairentity.ErrPairAlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			_, err := s.svc.CreatePair(ctx, tc.data)
+			require.ErrorIs(t, err, tc.expErr)
+		})
+	}
+}
+
+func (s *PairServiceTestSuite) TestCreatePair_CustomRules() {
+	ctx := context.Background()
+
+	svc := currency.NewPairSvc(s.mockPairRepo, s.mockCurrencyRepo, currency.PairOptions{
+		PairingRules: entity.PairingRules{entity.TypeMetal: {entity.TypeCrypto}},
+	})
+
+	s.mockPairRepo.On("CreatePair", ctx, mock.Anything).Return(nil).Once()
+
+	_, err := svc.CreatePair(ctx, dto.SavePair{From: "XAU", To: "BTC"})
+	require.NoError(s.T(), err)
+
+	_, err = svc.CreatePair(ctx, dto.SavePair{From: "BTC", To: "USD"})
+	require.ErrorIs(s.T(), err, pairentity.ErrPairNotAllowed)
+}
+
+func (s *PairServiceTestSuite) TestCreatePair_ConfiguredRules() {
+	ctx := context.Background()
+
+	rules, err := entity.ParsePairingRules(map[string]string{"metal": "crypto|fiat"})
+	require.NoError(s.T(), err)
+
+	svc := currency.NewPairSvc(s.mockPairRepo, s.mockCurrencyRepo, currency.PairOptions{PairingRules: rules})
+
+	s.mockPairRepo.On("CreatePair", ctx, mock.Anything).Return(nil).Once()
+
+	_, err = svc.CreatePair(ctx, dto.SavePair{From: "BTC", To: "XAU"})
+	require.NoError(s.T(), err)
+
+	_, err = svc.CreatePair(ctx, dto.SavePair{From: "BTC", To: "ETH"})
+	require.ErrorIs(s.T(), err, pairentity.ErrPairNotAllowed)
+
+	_, err = entity.ParsePairingRules(map[string]string{"metal": "gold"})
+	require.ErrorIs(s.T(), err, entity.ErrInvalidCurrencyType)
+}
+
+func (s *PairServiceTestSuite) TestUpdatePair() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockPairRepo.On("UpdatePair", ctx, mock.MatchedBy(func(p pairentity.Pair) bool {
+		return p.ID == id && !p.Enabled && p.RefreshInterval == time.Hour
+	})).Return(nil).Once()
+
+	err := s.svc.UpdatePair(ctx, dto.SavePair{ID: id, From: "BTC", To: "USD", RefreshInterval: time.Hour})
+	require.NoError(s.T(), err)
+
+	s.mockPairRepo.On("UpdatePair", ctx, mock.Anything).
+		Return(entity.ErrEntityNotFound).Once()
+
+	err = s.svc.UpdatePair(ctx, dto.SavePair{ID: id, From: "BTC", To: "USD"})
+	require.ErrorIs(s.T(), err, entity.ErrEntityNotFound)
+
+	err = s.svc.UpdatePair(ctx, dto.SavePair{ID: id, From: "BTC", To: "ETH"})
+	require.ErrorIs(s.T(), err, pairentity.ErrPairNotAllowed)
+}
+
+func (s *PairServiceTestSuite) TestDeletePair() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockPairRepo.On("DeletePair", ctx, id).
+		Return(nil).Once()
+
+	err := s.svc.DeletePair(ctx, id)
+	require.NoError(s.T(), err)
+
+	s.mockPairRepo.On("DeletePair", ctx, id).
+		Return(errors.New("pg err")).Once()
+
+	err = s.svc.DeletePair(ctx, id)
+	require.Error(s.T(), err)
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "errors"
+
+var (
+	ErrPairAlreadyExists = errors.New("pair already exists")
+	ErrInvalidPair       = errors.New("pair must have different currencies and non negative refresh interval")
+	ErrUnknownCurrency   = errors.New("pair currency not found")
+	ErrPairNotAllowed    = errors.New("pair of currency types is not allowed by pairing rules")
+)
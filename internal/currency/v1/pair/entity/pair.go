@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pair is a currency pair registered for fetching, only enabled pairs are
+// fetched by the courses updater.
+type Pair struct {
+	ID       uuid.UUID
+	CodeFrom string
+	CodeTo   string
+	Enabled  bool
+	// RefreshInterval is the minimal time between fetches of the pair, zero means every update cycle
+	RefreshInterval time.Duration
+	// Provider is the preferred courses provider, empty means the default one
+	Provider  string
+	CreatedAt time.Time
+}
+
+type Pairs []Pair
+
+func (p Pair) Validate() error {
+	if p.CodeFrom == "" || p.CodeTo == "" || p.CodeFrom == p.CodeTo || p.RefreshInterval < 0 {
+		return ErrInvalidPair
+	}
+
+	return nil
+}
+
+// IsDue reports whether the pair fetched at lastFetchedAt has to be fetched at now.
+func (p Pair) IsDue(lastFetchedAt, now time.Time) bool {
+	return lastFetchedAt.IsZero() || now.Sub(lastFetchedAt) >= p.RefreshInterval
+}
@@ -0,0 +1,30 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/storage/postgres/entity"
+)
+
+func PairsToEntity(pairs storageentity.Pairs) entity.Pairs {
+	res := make(entity.Pairs, 0, len(pairs))
+
+	for _, p := range pairs {
+		res = append(res, PairToEntity(p))
+	}
+
+	return res
+}
+
+func PairToEntity(p storageentity.Pair) entity.Pair {
+	return entity.Pair{
+		ID:              p.ID,
+		CodeFrom:        p.CodeFrom,
+		CodeTo:          p.CodeTo,
+		Enabled:         p.Enabled,
+		RefreshInterval: time.Duration(p.RefreshSeconds) * time.Second,
+		Provider:        p.Provider,
+		CreatedAt:       p.CreatedAt,
+	}
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Pair struct {
+	ID             uuid.UUID `db:"id"`
+	CodeFrom       string    `db:"code_from"`
+	CodeTo         string    `db:"code_to"`
+	Enabled        bool      `db:"enabled"`
+	RefreshSeconds int64     `db:"refresh_seconds"`
+	Provider       string    `db:"provider"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type Pairs []Pair
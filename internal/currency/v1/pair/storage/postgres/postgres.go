@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pair/storage/postgres/converter"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/storage/postgres/entity"
+)
+
+const (
+	pairsTable = "currency_pairs"
+
+	pgxDuplicateKeyCode = "23505"
+)
+
+type RepoPostgres struct {
+	pgClient *pgxpool.Pool
+	timeout  time.Duration
+}
+
+func NewRepoPostgres(pgClient *pgxpool.Pool, timeout time.Duration) *RepoPostgres {
+	return &RepoPostgres{pgClient: pgClient, timeout: timeout}
+}
+
+func (r *RepoPostgres) GetPairs(ctx context.Context) (entity.Pairs, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select(
+		"id", "code_from", "code_to", "enabled", "refresh_seconds", "provider", "created_at",
+	).
+		From(pairsTable).
+		OrderBy("code_from", "code_to")
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx query")
+	}
+	defer rows.Close()
+
+	pairs, err := pgx.CollectRows(rows, pgx.RowToStructByName[storageentity.Pair])
+	if err != nil {
+		return nil, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.PairsToEntity(pairs), nil
+}
+
+func (r *RepoPostgres) CreatePair(ctx context.Context, pair entity.Pair) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Insert(pairsTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns("id", "code_from", "code_to", "enabled", "refresh_seconds", "provider", "created_at").
+		Values(
+			pair.ID,
+			pair.CodeFrom,
+			pair.CodeTo,
+			pair.Enabled,
+			int64(pair.RefreshInterval/time.Second),
+			pair.Provider,
+			pair.CreatedAt,
+		)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	if _, err = r.pgClient.Exec(ctx, query, v...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgxDuplicateKeyCode {
+				return entity.ErrPairAlreadyExists
+			}
+		}
+
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) UpdatePair(ctx context.Context, pair entity.Pair) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Update(pairsTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"id": pair.ID}).
+		Set("code_from", pair.CodeFrom).
+		Set("code_to", pair.CodeTo).
+		Set("enabled", pair.Enabled).
+		Set("refresh_seconds", int64(pair.RefreshInterval/time.Second)).
+		Set("provider", pair.Provider)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgxDuplicateKeyCode {
+				return entity.ErrPairAlreadyExists
+			}
+		}
+
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return currencyentity.ErrEntityNotFound
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) DeletePair(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Delete(pairsTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"id": id})
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	if err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return currencyentity.ErrEntityNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/config"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pair/storage/postgres"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	repo      *postgres.RepoPostgres
+	pgxClient *pgxpool.Pool
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) SetupSuite() {
+	ctx := context.Background()
+	conf, err := config.Load()
+	s.Require().NoError(err)
+
+	pgCfg, err := pgxpool.ParseConfig(
+		fmt.Sprintf(
+			"host=%s port=%d dbname=%s user=%s password=%s",
+			conf.PgHost(),
+			conf.PgPort(),
+			conf.PgDB(),
+			conf.PgUser(),
+			conf.PgPassword(),
+		),
+	)
+	s.Require().NoError(err)
+
+	pgClient, err := pgxpool.NewWithConfig(ctx, pgCfg)
+	s.Require().NoError(err)
+
+	err = pgClient.Ping(ctx)
+	s.Require().NoError(err)
+
+	s.repo = postgres.NewRepoPostgres(pgClient, 1*time.Second)
+	s.pgxClient = pgClient
+
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownSuite() {
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownTest() {
+	s.clearCollection()
+}
+
+func (s *Suite) clearCollection() {
+	ctx := context.Background()
+
+	_, err := s.pgxClient.Exec(ctx, "TRUNCATE TABLE currency_pairs")
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestCreatePair_GetPairs_NoErr() {
+	ctx := context.Background()
+
+	btcUSD := entity.Pair{
+		ID:              uuid.New(),
+		CodeFrom:        "BTC",
+		CodeTo:          "USD",
+		Enabled:         true,
+		RefreshInterval: 5 * time.Minute,
+		Provider:        "fastforex",
+		CreatedAt:       time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	usdBTC := entity.Pair{
+		ID:        uuid.New(),
+		CodeFrom:  "USD",
+		CodeTo:    "BTC",
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	s.Require().NoError(s.repo.CreatePair(ctx, usdBTC))
+	s.Require().NoError(s.repo.CreatePair(ctx, btcUSD))
+
+	res, err := s.repo.GetPairs(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(res, 2)
+
+	s.Require().Equal(btcUSD.ID, res[0].ID)
+	s.Require().True(res[0].Enabled)
+	s.Require().Equal(btcUSD.RefreshInterval, res[0].RefreshInterval)
+	s.Require().Equal(btcUSD.Provider, res[0].Provider)
+	s.Require().True(btcUSD.CreatedAt.Equal(res[0].CreatedAt))
+
+	s.Require().Equal(usdBTC.ID, res[1].ID)
+	s.Require().False(res[1].Enabled)
+}
+
+func (s *Suite) TestCreatePair_ReturnAlreadyExistsErr() {
+	ctx := context.Background()
+
+	pair := entity.Pair{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC", CreatedAt: time.Now()}
+	s.Require().NoError(s.repo.CreatePair(ctx, pair))
+
+	pair.ID = uuid.New()
+	err := s.repo.CreatePair(ctx, pair)
+	s.Require().ErrorIs(err, entity.ErrPairAlreadyExists)
+}
+
+func (s *Suite) TestUpdatePair() {
+	ctx := context.Background()
+
+	pair := entity.Pair{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC", Enabled: true, CreatedAt: time.Now()}
+	s.Require().NoError(s.repo.CreatePair(ctx, pair))
+
+	pair.Enabled = false
+	pair.RefreshInterval = time.Hour
+	s.Require().NoError(s.repo.UpdatePair(ctx, pair))
+
+	res, err := s.repo.GetPairs(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(res, 1)
+	s.Require().False(res[0].Enabled)
+	s.Require().Equal(time.Hour, res[0].RefreshInterval)
+
+	err = s.repo.UpdatePair(ctx, entity.Pair{ID: uuid.New(), CodeFrom: "USD", CodeTo: "ETH"})
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
+
+func (s *Suite) TestDeletePair() {
+	ctx := context.Background()
+
+	pair := entity.Pair{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC", CreatedAt: time.Now()}
+	s.Require().NoError(s.repo.CreatePair(ctx, pair))
+
+	s.Require().NoError(s.repo.DeletePair(ctx, pair.ID))
+
+	err := s.repo.DeletePair(ctx, pair.ID)
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
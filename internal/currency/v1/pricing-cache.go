@@ -0,0 +1,103 @@
+package currency
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+)
+
+// PricingCache keeps the pricing rules in memory, so conversions don't read them
+// from the storage. The rules are reloaded after every change through the cache and
+// by the timer, the last loaded rules are served while the storage fails.
+type PricingCache struct {
+	pricingRepo PricingRepo
+	l           *zerolog.Logger
+
+	mu       sync.RWMutex
+	pricings pricingentity.Pricings
+	loaded   bool
+}
+
+func NewPricingCache(pricingRepo PricingRepo, l *zerolog.Logger) *PricingCache {
+	return &PricingCache{
+		pricingRepo: pricingRepo,
+		l:           l,
+	}
+}
+
+// Refresh reloads the rules from the storage, the loaded rules are kept on error.
+func (c *PricingCache) Refresh(ctx context.Context) error {
+	_, err := c.load(ctx)
+
+	return err
+}
+
+// GetPricings returns the loaded rules, they are read from the storage only
+// until the first successful load.
+func (c *PricingCache) GetPricings(ctx context.Context) (pricingentity.Pricings, error) {
+	c.mu.RLock()
+	pricings, loaded := c.pricings, c.loaded
+	c.mu.RUnlock()
+
+	if loaded {
+		return pricings, nil
+	}
+
+	return c.load(ctx)
+}
+
+func (c *PricingCache) CreatePricing(ctx context.Context, pricing pricingentity.Pricing) error {
+	if err := c.pricingRepo.CreatePricing(ctx, pricing); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	c.reload(ctx)
+
+	return nil
+}
+
+func (c *PricingCache) UpdatePricing(ctx context.Context, pricing pricingentity.Pricing) error {
+	if err := c.pricingRepo.UpdatePricing(ctx, pricing); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	c.reload(ctx)
+
+	return nil
+}
+
+func (c *PricingCache) DeletePricing(ctx context.Context, id uuid.UUID) error {
+	if err := c.pricingRepo.DeletePricing(ctx, id); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	c.reload(ctx)
+
+	return nil
+}
+
+// reload refreshes the rules after a change, the change itself is already saved,
+// so a failed reload is only logged and the timer picks the change up later.
+func (c *PricingCache) reload(ctx context.Context) {
+	if _, err := c.load(ctx); err != nil {
+		c.l.Err(err).Msg("reload pricings after change")
+	}
+}
+
+func (c *PricingCache) load(ctx context.Context) (pricingentity.Pricings, error) {
+	pricings, err := c.pricingRepo.GetPricings(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "load pricings")
+	}
+
+	c.mu.Lock()
+	c.pricings = pricings
+	c.loaded = true
+	c.mu.Unlock()
+
+	return pricings, nil
+}
@@ -0,0 +1,111 @@
+package currency_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	"testing"
+)
+
+type PricingCacheTestSuite struct {
+	suite.Suite
+	cache           *currency.PricingCache
+	mockPricingRepo *mocks.PricingRepo
+	buf             *bytes.Buffer
+}
+
+func (s *PricingCacheTestSuite) SetupTest() {
+	s.mockPricingRepo = mocks.NewPricingRepo(s.T())
+	s.buf = &bytes.Buffer{}
+
+	l := zerolog.New(s.buf)
+	s.cache = currency.NewPricingCache(s.mockPricingRepo, &l)
+}
+
+func TestPricingCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(PricingCacheTestSuite))
+}
+
+func (s *PricingCacheTestSuite) TestGetPricings_LoadedOnce() {
+	ctx := context.Background()
+
+	pricings := pricingentity.Pricings{{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC", SpreadPercent: decimal.NewFromInt(1)}}
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(pricings, nil).Once()
+
+	for range 3 {
+		res, err := s.cache.GetPricings(ctx)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), pricings, res)
+	}
+}
+
+func (s *PricingCacheTestSuite) TestGetPricings_Err() {
+	ctx := context.Background()
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, errors.New("pg err")).Once()
+
+	_, err := s.cache.GetPricings(ctx)
+	require.ErrorContains(s.T(), err, "pg err")
+}
+
+func (s *PricingCacheTestSuite) TestRefresh_KeepsLastRulesOnErr() {
+	ctx := context.Background()
+
+	pricings := pricingentity.Pricings{{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC", SpreadPercent: decimal.NewFromInt(1)}}
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(pricings, nil).Once()
+	require.NoError(s.T(), s.cache.Refresh(ctx))
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, errors.New("pg err")).Once()
+	require.ErrorContains(s.T(), s.cache.Refresh(ctx), "pg err")
+
+	res, err := s.cache.GetPricings(ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), pricings, res)
+}
+
+func (s *PricingCacheTestSuite) TestChange_ReloadsRules() {
+	ctx := context.Background()
+
+	pricing := pricingentity.Pricing{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC", SpreadPercent: decimal.NewFromInt(1)}
+
+	s.mockPricingRepo.On("GetPricings", ctx).Return(pricingentity.Pricings{}, nil).Once()
+	require.NoError(s.T(), s.cache.Refresh(ctx))
+
+	s.mockPricingRepo.On("CreatePricing", ctx, pricing).Return(nil).Once()
+	s.mockPricingRepo.On("GetPricings", ctx).Return(pricingentity.Pricings{pricing}, nil).Once()
+	require.NoError(s.T(), s.cache.CreatePricing(ctx, pricing))
+
+	res, err := s.cache.GetPricings(ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), pricingentity.Pricings{pricing}, res)
+
+	s.mockPricingRepo.On("DeletePricing", ctx, pricing.ID).Return(nil).Once()
+	s.mockPricingRepo.On("GetPricings", ctx).Return(nil, errors.New("pg err")).Once()
+	require.NoError(s.T(), s.cache.DeletePricing(ctx, pricing.ID))
+	require.Contains(s.T(), s.buf.String(), "reload pricings after change")
+
+	res, err = s.cache.GetPricings(ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), pricingentity.Pricings{pricing}, res)
+}
+
+func (s *PricingCacheTestSuite) TestChange_Err() {
+	ctx := context.Background()
+
+	pricing := pricingentity.Pricing{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC"}
+
+	s.mockPricingRepo.On("UpdatePricing", ctx, pricing).Return(pricingentity.ErrPricingAlreadyExists).Once()
+
+	err := s.cache.UpdatePricing(ctx, pricing)
+	require.ErrorIs(s.T(), err, pricingentity.ErrPricingAlreadyExists)
+}
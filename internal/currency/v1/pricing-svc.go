@@ -0,0 +1,98 @@
+package currency
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+)
+
+type PricingSvc struct {
+	pricingRepo PricingRepo
+}
+
+func NewPricingSvc(pricingRepo PricingRepo) *PricingSvc {
+	return &PricingSvc{
+		pricingRepo: pricingRepo,
+	}
+}
+
+func (s *PricingSvc) GetPricings(ctx context.Context) ([]dto.PairPricing, error) {
+	pricings, err := s.pricingRepo.GetPricings(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get pricings from storage")
+	}
+
+	res := make([]dto.PairPricing, 0, len(pricings))
+	for _, p := range pricings {
+		res = append(res, pricingToDTO(p))
+	}
+
+	return res, nil
+}
+
+func (s *PricingSvc) CreatePricing(ctx context.Context, req dto.PairPricing) (dto.PairPricing, error) {
+	pricing := pricingFromDTO(req)
+	pricing.ID = uuid.New()
+
+	if err := pricing.Validate(); err != nil {
+		return dto.PairPricing{}, err
+	}
+
+	if err := s.pricingRepo.CreatePricing(ctx, pricing); err != nil {
+		return dto.PairPricing{}, errors.Wrap(err, "save pricing to storage")
+	}
+
+	return pricingToDTO(pricing), nil
+}
+
+func (s *PricingSvc) UpdatePricing(ctx context.Context, req dto.PairPricing) error {
+	pricing := pricingFromDTO(req)
+
+	if err := pricing.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.pricingRepo.UpdatePricing(ctx, pricing); err != nil {
+		return errors.Wrap(err, "update pricing in storage")
+	}
+
+	return nil
+}
+
+func (s *PricingSvc) DeletePricing(ctx context.Context, id uuid.UUID) error {
+	if err := s.pricingRepo.DeletePricing(ctx, id); err != nil {
+		return errors.Wrap(err, "delete pricing from storage")
+	}
+
+	return nil
+}
+
+func pricingFromDTO(p dto.PairPricing) pricingentity.Pricing {
+	return pricingentity.Pricing{
+		ID:            p.ID,
+		CodeFrom:      p.From,
+		CodeTo:        p.To,
+		TypeFrom:      entity.CurrencyType(p.FromType),
+		TypeTo:        entity.CurrencyType(p.ToType),
+		SpreadPercent: p.SpreadPercent,
+		FixedFee:      p.FixedFee,
+		MinFee:        p.MinFee,
+	}
+}
+
+func pricingToDTO(p pricingentity.Pricing) dto.PairPricing {
+	return dto.PairPricing{
+		ID:            p.ID,
+		From:          p.CodeFrom,
+		To:            p.CodeTo,
+		FromType:      int(p.TypeFrom),
+		ToType:        int(p.TypeTo),
+		SpreadPercent: p.SpreadPercent,
+		FixedFee:      p.FixedFee,
+		MinFee:        p.MinFee,
+	}
+}
@@ -0,0 +1,169 @@
+package currency_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"testing"
+)
+
+type PricingServiceTestSuite struct {
+	suite.Suite
+	svc             *currency.PricingSvc
+	mockPricingRepo *mocks.PricingRepo
+}
+
+func (s *PricingServiceTestSuite) SetupTest() {
+	s.mockPricingRepo = mocks.NewPricingRepo(s.T())
+	s.svc = currency.NewPricingSvc(s.mockPricingRepo)
+}
+
+func TestPricingServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PricingServiceTestSuite))
+}
+
+func (s *PricingServiceTestSuite) TestGetPricings_NoErr() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockPricingRepo.On("GetPricings", ctx).
+		Return(pricingentity.Pricings{{
+			ID:            id,
+			TypeFrom:      entity.TypeFiat,
+			TypeTo:        entity.TypeCrypto,
+			SpreadPercent: decimal.NewFromInt(1),
+		}}, nil).Once()
+
+	res, err := s.svc.GetPricings(ctx)
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), []dto.PairPricing{{
+		ID:            id,
+		FromType:      2,
+		ToType:        1,
+		SpreadPercent: decimal.NewFromInt(1),
+	}}, res)
+}
+
+func (s *PricingServiceTestSuite) TestCreatePricing_NoErr() {
+	ctx := context.Background()
+
+	s.mockPricingRepo.On("CreatePricing", ctx, mock.MatchedBy(func(p pricingentity.Pricing) bool {
+		return p.ID != uuid.Nil && p.CodeFrom == "USD" && p.CodeTo == "BTC"
+	})).Return(nil).Once()
+
+	res, err := s.svc.CreatePricing(ctx, dto.PairPricing{
+		From:          "USD",
+		To:            "BTC",
+		SpreadPercent: decimal.RequireFromString("0.5"),
+	})
+	require.NoError(s.T(), err)
+	require.NotEqual(s.T(), uuid.Nil, res.ID)
+}
+
+func (s *PricingServiceTestSuite) TestCreatePricing_Err() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		data     dto.PairPricing
+		mockFunc func()
+		expErr   error
+	}{
+		{
+			name:     "no_scope",
+			data:     dto.PairPricing{SpreadPercent: decimal.NewFromInt(1)},
+			mockFunc: func() {},
+			expErr:   pricingentity.ErrInvalidPricingScope,
+		},
+		{
+			name:     "codes_and_types",
+			data:     dto.PairPricing{From: "USD", To: "BTC", FromType: 2, ToType: 1},
+			mockFunc: func() {},
+			expErr:   pricingentity.ErrInvalidPricingScope,
+		},
+		{
+			name:     "invalid_type",
+			data:     dto.PairPricing{FromType: 2, ToType: 9},
+			mockFunc: func() {},
+			expErr:   pricingentity.ErrInvalidPricingScope,
+		},
+		{
+			name:     "spread_too_big",
+			data:     dto.PairPricing{From: "USD", To: "BTC", SpreadPercent: decimal.NewFromInt(100)},
+			mockFunc: func() {},
+			expErr:   pricingentity.ErrInvalidPricingValue,
+		},
+		{
+			name:     "negative_fee",
+			data:     dto.PairPricing{From: "USD", To: "BTC", MinFee: decimal.NewFromInt(-1)},
+			mockFunc: func() {},
+			expErr:   pricingentity.ErrInvalidPricingValue,
+		},
+		{
+			name: "already_exists",
+			data: dto.PairPricing{From: "USD", To: "BTC"},
+			mockFunc: func() {
+				s.mockPricingRepo.On("CreatePricing", ctx, mock.Anything).
+					Return(pricingentity.ErrPricingAlreadyExists).Once()
+			},
+			expErr: pricingentity.ErrPricingAlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			_, err := s.svc.CreatePricing(ctx, tc.data)
+			require.ErrorIs(t, err, tc.expErr)
+		})
+	}
+}
+
+func (s *PricingServiceTestSuite) TestUpdatePricing() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockPricingRepo.On("UpdatePricing", ctx, mock.MatchedBy(func(p pricingentity.Pricing) bool {
+		return p.ID == id && p.TypeFrom == entity.TypeCrypto && p.TypeTo == entity.TypeFiat
+	})).Return(nil).Once()
+
+	err := s.svc.UpdatePricing(ctx, dto.PairPricing{ID: id, FromType: 1, ToType: 2})
+	require.NoError(s.T(), err)
+
+	s.mockPricingRepo.On("UpdatePricing", ctx, mock.Anything).
+		Return(entity.ErrEntityNotFound).Once()
+
+	err = s.svc.UpdatePricing(ctx, dto.PairPricing{ID: id, FromType: 1, ToType: 2})
+	require.ErrorIs(s.T(), err, entity.ErrEntityNotFound)
+}
+
+func (s *PricingServiceTestSuite) TestDeletePricing() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockPricingRepo.On("DeletePricing", ctx, id).
+		Return(nil).Once()
+
+	err := s.svc.DeletePricing(ctx, id)
+	require.NoError(s.T(), err)
+
+	s.mockPricingRepo.On("DeletePricing", ctx, id).
+		Return(errors.New("pg err")).Once()
+
+	err = s.svc.DeletePricing(ctx, id)
+	require.Error(s.T(), err)
+}
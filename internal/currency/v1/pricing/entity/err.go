@@ -0,0 +1,9 @@
+package entity
+
+import "errors"
+
+var (
+	ErrPricingAlreadyExists = errors.New("pricing already exists")
+	ErrInvalidPricingScope  = errors.New("pricing must be set either for currency codes or for currency types")
+	ErrInvalidPricingValue  = errors.New("spread must be in [0, 100) and fees must not be negative")
+)
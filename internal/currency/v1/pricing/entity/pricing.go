@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+)
+
+// Pricing is the markup applied to conversions of a pair. It is set either
+// for a pair of currency codes or for a pair of currency types, fees are
+// charged in the target currency.
+type Pricing struct {
+	ID            uuid.UUID
+	CodeFrom      string
+	CodeTo        string
+	TypeFrom      currencyentity.CurrencyType
+	TypeTo        currencyentity.CurrencyType
+	SpreadPercent decimal.Decimal
+	FixedFee      decimal.Decimal
+	MinFee        decimal.Decimal
+}
+
+type Pricings []Pricing
+
+func (p Pricing) Validate() error {
+	byCodes := p.CodeFrom != "" && p.CodeTo != "" && p.TypeFrom == 0 && p.TypeTo == 0
+	byTypes := p.CodeFrom == "" && p.CodeTo == "" && p.TypeFrom != 0 && p.TypeTo != 0
+
+	if !byCodes && !byTypes {
+		return ErrInvalidPricingScope
+	}
+
+	if byTypes {
+		if _, err := currencyentity.IntToCurrencyType(int(p.TypeFrom)); err != nil {
+			return ErrInvalidPricingScope
+		}
+
+		if _, err := currencyentity.IntToCurrencyType(int(p.TypeTo)); err != nil {
+			return ErrInvalidPricingScope
+		}
+	}
+
+	if p.SpreadPercent.IsNegative() || p.SpreadPercent.GreaterThanOrEqual(decimal.NewFromInt(100)) ||
+		p.FixedFee.IsNegative() || p.MinFee.IsNegative() {
+		return ErrInvalidPricingValue
+	}
+
+	return nil
+}
+
+func (p Pricing) IsByTypes() bool {
+	return p.TypeFrom != 0
+}
+
+// Charge is the breakdown of a conversion after the pricing is applied.
+type Charge struct {
+	SpreadAmount decimal.Decimal
+	Fee          decimal.Decimal
+	Net          decimal.Decimal
+}
+
+// Apply charges the spread and the fixed fee from the gross result. When both
+// together are below the minimum fee, the fee is raised up to it.
+// Net never goes below zero.
+func (p Pricing) Apply(gross decimal.Decimal) Charge {
+	spreadAmount := gross.Mul(p.SpreadPercent).Div(decimal.NewFromInt(100))
+
+	fee := p.FixedFee
+	if spreadAmount.Add(fee).LessThan(p.MinFee) {
+		fee = p.MinFee.Sub(spreadAmount)
+	}
+
+	net := gross.Sub(spreadAmount).Sub(fee)
+	if net.IsNegative() {
+		net = decimal.Zero
+	}
+
+	return Charge{
+		SpreadAmount: spreadAmount,
+		Fee:          fee,
+		Net:          net,
+	}
+}
+
+// HasTypeRules reports whether any pricing is set for currency types.
+func (p Pricings) HasTypeRules() bool {
+	for _, v := range p {
+		if v.IsByTypes() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Find returns the pricing for the pair, pricing for the exact codes
+// takes precedence over pricing for the currency types.
+func (p Pricings) Find(from, to currencyentity.Currency) (Pricing, bool) {
+	for _, v := range p {
+		if !v.IsByTypes() && v.CodeFrom == from.Code && v.CodeTo == to.Code {
+			return v, true
+		}
+	}
+
+	for _, v := range p {
+		if v.IsByTypes() && v.TypeFrom == from.Type && v.TypeTo == to.Type {
+			return v, true
+		}
+	}
+
+	return Pricing{}, false
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/storage/postgres/entity"
+	"github.com/veleton777/test_work_blum/internal/pkg/pgutil"
+)
+
+func PricingsToEntity(pricings storageentity.Pricings) entity.Pricings {
+	res := make(entity.Pricings, 0, len(pricings))
+
+	for _, p := range pricings {
+		res = append(res, PricingToEntity(p))
+	}
+
+	return res
+}
+
+func PricingToEntity(p storageentity.Pricing) entity.Pricing {
+	res := entity.Pricing{
+		ID:            p.ID,
+		SpreadPercent: pgutil.NumericToDecimal(p.SpreadPercent),
+		FixedFee:      pgutil.NumericToDecimal(p.FixedFee),
+		MinFee:        pgutil.NumericToDecimal(p.MinFee),
+	}
+
+	if p.CodeFrom != nil && p.CodeTo != nil {
+		res.CodeFrom = *p.CodeFrom
+		res.CodeTo = *p.CodeTo
+	}
+
+	if p.TypeFrom != nil && p.TypeTo != nil {
+		res.TypeFrom = currencyentity.CurrencyType(*p.TypeFrom)
+		res.TypeTo = currencyentity.CurrencyType(*p.TypeTo)
+	}
+
+	return res
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Pricing struct {
+	ID            uuid.UUID      `db:"id"`
+	CodeFrom      *string        `db:"code_from"`
+	CodeTo        *string        `db:"code_to"`
+	TypeFrom      *int           `db:"type_from"`
+	TypeTo        *int           `db:"type_to"`
+	SpreadPercent pgtype.Numeric `db:"spread_percent"`
+	FixedFee      pgtype.Numeric `db:"fixed_fee"`
+	MinFee        pgtype.Numeric `db:"min_fee"`
+}
+
+type Pricings []Pricing
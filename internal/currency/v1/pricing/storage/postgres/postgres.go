@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pricing/storage/postgres/converter"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/storage/postgres/entity"
+)
+
+const (
+	pricingTable = "pair_pricing"
+
+	pgxDuplicateKeyCode = "23505"
+)
+
+type RepoPostgres struct {
+	pgClient *pgxpool.Pool
+	timeout  time.Duration
+}
+
+func NewRepoPostgres(pgClient *pgxpool.Pool, timeout time.Duration) *RepoPostgres {
+	return &RepoPostgres{pgClient: pgClient, timeout: timeout}
+}
+
+func (r *RepoPostgres) GetPricings(ctx context.Context) (entity.Pricings, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select(
+		"id", "code_from", "code_to", "type_from", "type_to", "spread_percent", "fixed_fee", "min_fee",
+	).
+		From(pricingTable)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx query")
+	}
+	defer rows.Close()
+
+	pricings, err := pgx.CollectRows(rows, pgx.RowToStructByName[storageentity.Pricing])
+	if err != nil {
+		return nil, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.PricingsToEntity(pricings), nil
+}
+
+func (r *RepoPostgres) CreatePricing(ctx context.Context, pricing entity.Pricing) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Insert(pricingTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns("id", "code_from", "code_to", "type_from", "type_to", "spread_percent", "fixed_fee", "min_fee").
+		Values(
+			pricing.ID,
+			nullCode(pricing.CodeFrom),
+			nullCode(pricing.CodeTo),
+			nullType(pricing.TypeFrom),
+			nullType(pricing.TypeTo),
+			pricing.SpreadPercent,
+			pricing.FixedFee,
+			pricing.MinFee,
+		)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	if _, err = r.pgClient.Exec(ctx, query, v...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgxDuplicateKeyCode {
+				return entity.ErrPricingAlreadyExists
+			}
+		}
+
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) UpdatePricing(ctx context.Context, pricing entity.Pricing) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Update(pricingTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"id": pricing.ID}).
+		Set("code_from", nullCode(pricing.CodeFrom)).
+		Set("code_to", nullCode(pricing.CodeTo)).
+		Set("type_from", nullType(pricing.TypeFrom)).
+		Set("type_to", nullType(pricing.TypeTo)).
+		Set("spread_percent", pricing.SpreadPercent).
+		Set("fixed_fee", pricing.FixedFee).
+		Set("min_fee", pricing.MinFee)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgxDuplicateKeyCode {
+				return entity.ErrPricingAlreadyExists
+			}
+		}
+
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return currencyentity.ErrEntityNotFound
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) DeletePricing(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Delete(pricingTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"id": id})
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	if err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return currencyentity.ErrEntityNotFound
+	}
+
+	return nil
+}
+
+func nullCode(code string) *string {
+	if code == "" {
+		return nil
+	}
+
+	return &code
+}
+
+func nullType(t currencyentity.CurrencyType) *int {
+	if t == 0 {
+		return nil
+	}
+
+	v := int(t)
+
+	return &v
+}
@@ -0,0 +1,157 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/config"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/pricing/storage/postgres"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	repo      *postgres.RepoPostgres
+	pgxClient *pgxpool.Pool
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) SetupSuite() {
+	ctx := context.Background()
+	conf, err := config.Load()
+	s.Require().NoError(err)
+
+	pgCfg, err := pgxpool.ParseConfig(
+		fmt.Sprintf(
+			"host=%s port=%d dbname=%s user=%s password=%s",
+			conf.PgHost(),
+			conf.PgPort(),
+			conf.PgDB(),
+			conf.PgUser(),
+			conf.PgPassword(),
+		),
+	)
+	s.Require().NoError(err)
+
+	pgClient, err := pgxpool.NewWithConfig(ctx, pgCfg)
+	s.Require().NoError(err)
+
+	err = pgClient.Ping(ctx)
+	s.Require().NoError(err)
+
+	s.repo = postgres.NewRepoPostgres(pgClient, 1*time.Second)
+	s.pgxClient = pgClient
+
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownSuite() {
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownTest() {
+	s.clearCollection()
+}
+
+func (s *Suite) clearCollection() {
+	ctx := context.Background()
+
+	_, err := s.pgxClient.Exec(ctx, "TRUNCATE TABLE pair_pricing")
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestCreatePricing_GetPricings_NoErr() {
+	ctx := context.Background()
+
+	byCodes := entity.Pricing{
+		ID:            uuid.New(),
+		CodeFrom:      "USD",
+		CodeTo:        "BTC",
+		SpreadPercent: decimal.RequireFromString("0.5"),
+		FixedFee:      decimal.RequireFromString("0.000001"),
+		MinFee:        decimal.Zero,
+	}
+
+	byTypes := entity.Pricing{
+		ID:            uuid.New(),
+		TypeFrom:      currencyentity.TypeFiat,
+		TypeTo:        currencyentity.TypeCrypto,
+		SpreadPercent: decimal.NewFromInt(1),
+		FixedFee:      decimal.Zero,
+		MinFee:        decimal.RequireFromString("0.00001"),
+	}
+
+	s.Require().NoError(s.repo.CreatePricing(ctx, byCodes))
+	s.Require().NoError(s.repo.CreatePricing(ctx, byTypes))
+
+	res, err := s.repo.GetPricings(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(res, 2)
+
+	for _, p := range res {
+		exp := byCodes
+		if p.ID == byTypes.ID {
+			exp = byTypes
+		}
+
+		s.Require().Equal(exp.CodeFrom, p.CodeFrom)
+		s.Require().Equal(exp.CodeTo, p.CodeTo)
+		s.Require().Equal(exp.TypeFrom, p.TypeFrom)
+		s.Require().Equal(exp.TypeTo, p.TypeTo)
+		s.Require().True(exp.SpreadPercent.Equal(p.SpreadPercent))
+		s.Require().True(exp.FixedFee.Equal(p.FixedFee))
+		s.Require().True(exp.MinFee.Equal(p.MinFee))
+	}
+}
+
+func (s *Suite) TestCreatePricing_ReturnAlreadyExistsErr() {
+	ctx := context.Background()
+
+	pricing := entity.Pricing{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC"}
+	s.Require().NoError(s.repo.CreatePricing(ctx, pricing))
+
+	pricing.ID = uuid.New()
+	err := s.repo.CreatePricing(ctx, pricing)
+	s.Require().ErrorIs(err, entity.ErrPricingAlreadyExists)
+}
+
+func (s *Suite) TestUpdatePricing() {
+	ctx := context.Background()
+
+	pricing := entity.Pricing{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC"}
+	s.Require().NoError(s.repo.CreatePricing(ctx, pricing))
+
+	pricing.SpreadPercent = decimal.NewFromInt(2)
+	s.Require().NoError(s.repo.UpdatePricing(ctx, pricing))
+
+	res, err := s.repo.GetPricings(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(res, 1)
+	s.Require().True(decimal.NewFromInt(2).Equal(res[0].SpreadPercent))
+
+	err = s.repo.UpdatePricing(ctx, entity.Pricing{ID: uuid.New(), CodeFrom: "USD", CodeTo: "ETH"})
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
+
+func (s *Suite) TestDeletePricing() {
+	ctx := context.Background()
+
+	pricing := entity.Pricing{ID: uuid.New(), CodeFrom: "USD", CodeTo: "BTC"}
+	s.Require().NoError(s.repo.CreatePricing(ctx, pricing))
+
+	s.Require().NoError(s.repo.DeletePricing(ctx, pricing.ID))
+
+	err := s.repo.DeletePricing(ctx, pricing.ID)
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
@@ -0,0 +1,341 @@
+package currency
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/veleton777/test_work_blum/internal/dto"
+)
+
+var (
+	errNoProviders = errors.New("no courses providers")
+	errNoCourse    = errors.New("no course in provider response")
+	errNoAgreement = errors.New("providers courses do not agree within tolerance")
+)
+
+// Providers is the registry of courses providers in priority order. A pair is
+// asked from the providers one by one until one of them answers. Providers whose
+// call budgets are spent are not asked until the budgets are available again.
+type Providers struct {
+	apis []CurrenciesAPI
+	opts ProvidersOptions
+	l    *zerolog.Logger
+}
+
+type ProvidersOptions struct {
+	// Aggregate asks every provider and publishes the median of their courses instead of the first answer
+	Aggregate bool
+	// Tolerance is how many percent a course may deviate from the median before it is discarded as an outlier
+	Tolerance decimal.Decimal
+	// Budgets report whether the call budgets of the providers are spent
+	Budgets []BudgetReporter
+}
+
+// breakerReporter is implemented by the providers which are called through a circuit breaker.
+type breakerReporter interface {
+	BreakerState() string
+}
+
+// BudgetReporter is implemented by the services counting calls of a provider against its budget.
+type BudgetReporter interface {
+	Provider() string
+	BudgetExhausted() bool
+}
+
+// Course is the course of a pair with the name of the provider which answered it.
+type Course struct {
+	Value    decimal.Decimal
+	Provider string
+	// Sources is how many providers agreed on the course
+	Sources int
+}
+
+func NewProviders(l *zerolog.Logger, opts ProvidersOptions, apis ...CurrenciesAPI) *Providers {
+	return &Providers{
+		apis: apis,
+		opts: opts,
+		l:    l,
+	}
+}
+
+// FetchMulti returns courses from the base to the targets by one request to a provider.
+// The preferred provider is asked first when it is registered, targets which are
+// not answered by a provider are asked from the next one. Targets none of the providers
+// answered are returned with the error of the last provider. With aggregation every provider
+// is asked and the preferred one is ignored.
+func (p *Providers) FetchMulti(
+	ctx context.Context,
+	from string,
+	to []string,
+	preferred string,
+) (map[string]Course, map[string]error) {
+	apis := p.available()
+
+	if p.opts.Aggregate && len(apis) > 1 {
+		return p.fetchAggregated(ctx, apis, from, to)
+	}
+
+	res := make(map[string]Course, len(to))
+	errs := make(map[string]error)
+
+	for _, code := range to {
+		errs[code] = errNoProviders
+	}
+
+	pending := to
+
+	for _, api := range ordered(apis, preferred) {
+		if len(pending) == 0 {
+			break
+		}
+
+		courses, err := api.FetchMulti(ctx, from, pending)
+		if err != nil {
+			p.l.Err(err).Msgf("provider %s failed: from %s to %v", api.Name(), from, pending)
+
+			for _, code := range pending {
+				errs[code] = err
+			}
+
+			continue
+		}
+
+		var missed []string
+
+		for _, code := range pending {
+			v, ok := courses[code]
+			if !ok {
+				missed = append(missed, code)
+				errs[code] = errNoCourse
+
+				continue
+			}
+
+			res[code] = Course{Value: v, Provider: api.Name(), Sources: 1}
+			delete(errs, code)
+		}
+
+		if len(missed) > 0 {
+			p.l.Warn().Msgf("provider %s has no courses: from %s to %v", api.Name(), from, missed)
+		}
+
+		pending = missed
+	}
+
+	return res, errs
+}
+
+// fetchAggregated asks every provider at once and returns the median of the courses
+// which agree with each other, see aggregate.
+func (p *Providers) fetchAggregated(
+	ctx context.Context,
+	apis []CurrenciesAPI,
+	from string,
+	to []string,
+) (map[string]Course, map[string]error) {
+	quotes := make([]map[string]decimal.Decimal, len(apis))
+	apiErrs := make([]error, len(apis))
+
+	wg := &sync.WaitGroup{}
+
+	for i, api := range apis {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			quotes[i], apiErrs[i] = api.FetchMulti(ctx, from, to)
+		}()
+	}
+
+	wg.Wait()
+
+	res := make(map[string]Course, len(to))
+	errs := make(map[string]error)
+
+	for i, err := range apiErrs {
+		if err != nil {
+			p.l.Err(err).Msgf("provider %s failed: from %s to %v", apis[i].Name(), from, to)
+		}
+	}
+
+	for _, code := range to {
+		var courses []Course
+
+		for i, api := range apis {
+			if v, ok := quotes[i][code]; ok && apiErrs[i] == nil {
+				courses = append(courses, Course{Value: v, Provider: api.Name()})
+			}
+		}
+
+		if len(courses) == 0 {
+			errs[code] = errNoCourse
+
+			continue
+		}
+
+		course, outliers := aggregate(courses, p.opts.Tolerance)
+
+		for _, o := range outliers {
+			p.l.Warn().Msgf("provider %s course from %s to %s is an outlier: %s, median %s",
+				o.Provider, from, code, o.Value, median(courses))
+		}
+
+		if course.Sources == 0 {
+			errs[code] = errNoAgreement
+
+			continue
+		}
+
+		res[code] = course
+	}
+
+	return res, errs
+}
+
+// aggregate discards the courses which deviate from the median of all courses by more than
+// the tolerance and returns the median of the rest with the number and names of its sources.
+// When no course is within the tolerance, like two providers far from each other, the course
+// has no sources.
+func aggregate(courses []Course, tolerance decimal.Decimal) (Course, []Course) {
+	m := median(courses)
+
+	var (
+		agreed   []Course
+		outliers []Course
+	)
+
+	for _, c := range courses {
+		if m.IsZero() || c.Value.Sub(m).Abs().Div(m).Mul(decimal.NewFromInt(100)).LessThanOrEqual(tolerance) {
+			agreed = append(agreed, c)
+		} else {
+			outliers = append(outliers, c)
+		}
+	}
+
+	if len(agreed) == 0 {
+		return Course{}, outliers
+	}
+
+	names := make([]string, 0, len(agreed))
+	for _, c := range agreed {
+		names = append(names, c.Provider)
+	}
+
+	return Course{
+		Value:    median(agreed),
+		Provider: strings.Join(names, ","),
+		Sources:  len(agreed),
+	}, outliers
+}
+
+func median(courses []Course) decimal.Decimal {
+	values := make([]decimal.Decimal, 0, len(courses))
+	for _, c := range courses {
+		values = append(values, c.Value)
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].LessThan(values[j])
+	})
+
+	mid := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[mid]
+	}
+
+	return values[mid-1].Add(values[mid]).Div(decimal.NewFromInt(2))
+}
+
+// Historical returns the course at the moment and the name of the provider which answered.
+func (p *Providers) Historical(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, string, error) {
+	err := errNoProviders
+
+	for _, api := range p.available() {
+		var course decimal.Decimal
+
+		course, err = api.Historical(ctx, from, to, at)
+		if err == nil {
+			return course, api.Name(), nil
+		}
+
+		p.l.Err(err).Msgf("provider %s failed: from %s to %s at %s", api.Name(), from, to, at)
+	}
+
+	return decimal.Decimal{}, "", err //nolint:wrapcheck
+}
+
+// Statuses returns the providers in priority order with the states of their breakers and budgets.
+func (p *Providers) Statuses(_ context.Context) []dto.ProviderStatus {
+	res := make([]dto.ProviderStatus, 0, len(p.apis))
+
+	for _, api := range p.apis {
+		status := dto.ProviderStatus{Name: api.Name()}
+
+		if b, ok := api.(breakerReporter); ok {
+			status.BreakerState = b.BreakerState()
+		}
+
+		status.BudgetExhausted = p.budgetSpent(api.Name())
+
+		res = append(res, status)
+	}
+
+	return res
+}
+
+// available returns the providers whose budgets are not spent.
+func (p *Providers) available() []CurrenciesAPI {
+	res := make([]CurrenciesAPI, 0, len(p.apis))
+
+	for _, api := range p.apis {
+		if p.budgetSpent(api.Name()) {
+			p.l.Warn().Msgf("provider %s is skipped: budget is spent", api.Name())
+
+			continue
+		}
+
+		res = append(res, api)
+	}
+
+	return res
+}
+
+func (p *Providers) budgetSpent(provider string) bool {
+	for _, b := range p.opts.Budgets {
+		if b.Provider() == provider && b.BudgetExhausted() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ordered returns the providers with the preferred one moved to the front.
+func ordered(apis []CurrenciesAPI, preferred string) []CurrenciesAPI {
+	if preferred == "" {
+		return apis
+	}
+
+	res := make([]CurrenciesAPI, 0, len(apis))
+
+	for _, api := range apis {
+		if api.Name() == preferred {
+			res = append(res, api)
+		}
+	}
+
+	for _, api := range apis {
+		if api.Name() != preferred {
+			res = append(res, api)
+		}
+	}
+
+	return res
+}
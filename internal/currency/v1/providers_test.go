@@ -0,0 +1,280 @@
+package currency_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"testing"
+	"time"
+)
+
+type ProvidersTestSuite struct {
+	suite.Suite
+	providers     *currency.Providers
+	mockPrimary   *mocks.CurrenciesAPI
+	mockSecondary *mocks.CurrenciesAPI
+
+	buf *bytes.Buffer
+}
+
+func (s *ProvidersTestSuite) SetupTest() {
+	s.buf = &bytes.Buffer{}
+	l := zerolog.New(s.buf)
+
+	s.mockPrimary = mocks.NewCurrenciesAPI(s.T())
+	s.mockSecondary = mocks.NewCurrenciesAPI(s.T())
+	s.mockPrimary.On("Name").Return("fastforex").Maybe()
+	s.mockSecondary.On("Name").Return("cryptocompare").Maybe()
+
+	s.providers = currency.NewProviders(&l, currency.ProvidersOptions{}, s.mockPrimary, s.mockSecondary)
+}
+
+func TestProvidersTestSuite(t *testing.T) {
+	suite.Run(t, new(ProvidersTestSuite))
+}
+
+func (s *ProvidersTestSuite) TestFetchMulti_Primary() {
+	ctx := context.Background()
+
+	s.mockPrimary.On("FetchMulti", ctx, "USD", []string{"BTC", "EUR"}).
+		Return(map[string]decimal.Decimal{
+			"BTC": decimal.RequireFromString("0.0000145"),
+			"EUR": decimal.RequireFromString("0.93"),
+		}, nil).Once()
+
+	courses, errs := s.providers.FetchMulti(ctx, "USD", []string{"BTC", "EUR"}, "")
+	require.Empty(s.T(), errs)
+	require.Equal(s.T(), map[string]currency.Course{
+		"BTC": {Value: decimal.RequireFromString("0.0000145"), Provider: "fastforex", Sources: 1},
+		"EUR": {Value: decimal.RequireFromString("0.93"), Provider: "fastforex", Sources: 1},
+	}, courses)
+
+	s.mockSecondary.AssertNotCalled(s.T(), "FetchMulti")
+}
+
+func (s *ProvidersTestSuite) TestFetchMulti_Fallback() {
+	ctx := context.Background()
+
+	s.mockPrimary.On("FetchMulti", ctx, "USD", []string{"BTC", "EUR"}).
+		Return(nil, errors.New("timeout")).Once()
+
+	s.mockSecondary.On("FetchMulti", ctx, "USD", []string{"BTC", "EUR"}).
+		Return(map[string]decimal.Decimal{
+			"BTC": decimal.RequireFromString("0.0000145"),
+			"EUR": decimal.RequireFromString("0.93"),
+		}, nil).Once()
+
+	courses, errs := s.providers.FetchMulti(ctx, "USD", []string{"BTC", "EUR"}, "")
+	require.Empty(s.T(), errs)
+	require.Equal(s.T(), "cryptocompare", courses["BTC"].Provider)
+	require.Equal(s.T(), "cryptocompare", courses["EUR"].Provider)
+	require.Contains(s.T(), s.buf.String(), "provider fastforex failed: from USD to [BTC EUR]")
+}
+
+func (s *ProvidersTestSuite) TestFetchMulti_MissedTargets() {
+	ctx := context.Background()
+
+	s.mockPrimary.On("FetchMulti", ctx, "USD", []string{"BTC", "EUR"}).
+		Return(map[string]decimal.Decimal{"EUR": decimal.RequireFromString("0.93")}, nil).Once()
+
+	s.mockSecondary.On("FetchMulti", ctx, "USD", []string{"BTC"}).
+		Return(map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.0000145")}, nil).Once()
+
+	courses, errs := s.providers.FetchMulti(ctx, "USD", []string{"BTC", "EUR"}, "")
+	require.Empty(s.T(), errs)
+	require.Equal(s.T(), "cryptocompare", courses["BTC"].Provider)
+	require.Equal(s.T(), "fastforex", courses["EUR"].Provider)
+}
+
+func (s *ProvidersTestSuite) TestFetchMulti_Preferred() {
+	ctx := context.Background()
+
+	s.mockSecondary.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(69000)}, nil).Once()
+
+	courses, errs := s.providers.FetchMulti(ctx, "BTC", []string{"USD"}, "cryptocompare")
+	require.Empty(s.T(), errs)
+	require.Equal(s.T(), "cryptocompare", courses["USD"].Provider)
+
+	s.mockPrimary.AssertNotCalled(s.T(), "FetchMulti")
+}
+
+func (s *ProvidersTestSuite) TestFetchMulti_AllFailed() {
+	ctx := context.Background()
+
+	s.mockPrimary.On("FetchMulti", ctx, "BTC", []string{"USD", "EUR"}).
+		Return(map[string]decimal.Decimal{"EUR": decimal.NewFromInt(64000)}, nil).Once()
+
+	s.mockSecondary.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(nil, errors.New("rate limit")).Once()
+
+	courses, errs := s.providers.FetchMulti(ctx, "BTC", []string{"USD", "EUR"}, "")
+	require.Len(s.T(), courses, 1)
+	require.Len(s.T(), errs, 1)
+	require.EqualError(s.T(), errs["USD"], "rate limit")
+
+	l := zerolog.New(s.buf)
+
+	courses, errs = currency.NewProviders(&l, currency.ProvidersOptions{}).FetchMulti(ctx, "BTC", []string{"USD"}, "")
+	require.Empty(s.T(), courses)
+	require.Error(s.T(), errs["USD"])
+}
+
+func (s *ProvidersTestSuite) TestHistorical_Fallback() {
+	ctx := context.Background()
+	at := time.Date(2024, 6, 14, 15, 30, 0, 0, time.UTC)
+
+	s.mockPrimary.On("Historical", ctx, "BTC", "USD", at).
+		Return(decimal.Decimal{}, errors.New("timeout")).Once()
+
+	s.mockSecondary.On("Historical", ctx, "BTC", "USD", at).
+		Return(decimal.NewFromInt(66000), nil).Once()
+
+	course, provider, err := s.providers.Historical(ctx, "BTC", "USD", at)
+	require.NoError(s.T(), err)
+	require.True(s.T(), decimal.NewFromInt(66000).Equal(course))
+	require.Equal(s.T(), "cryptocompare", provider)
+}
+
+// breakerAPI is the provider called through a circuit breaker.
+type breakerAPI struct {
+	*mocks.CurrenciesAPI
+	state string
+}
+
+func (a breakerAPI) BreakerState() string {
+	return a.state
+}
+
+type budgetReporter struct {
+	provider  string
+	exhausted bool
+}
+
+func (r budgetReporter) Provider() string {
+	return r.provider
+}
+
+func (r budgetReporter) BudgetExhausted() bool {
+	return r.exhausted
+}
+
+func (s *ProvidersTestSuite) TestStatuses() {
+	l := zerolog.New(s.buf)
+	providers := currency.NewProviders(&l, currency.ProvidersOptions{}, breakerAPI{CurrenciesAPI: s.mockPrimary, state: "open"}, s.mockSecondary)
+
+	require.Equal(s.T(), []dto.ProviderStatus{
+		{Name: "fastforex", BreakerState: "open"},
+		{Name: "cryptocompare"},
+	}, providers.Statuses(context.Background()))
+}
+
+func (s *ProvidersTestSuite) TestStatuses_BudgetExhausted() {
+	l := zerolog.New(s.buf)
+	providers := currency.NewProviders(&l, currency.ProvidersOptions{
+		Budgets: []currency.BudgetReporter{budgetReporter{provider: "fastforex", exhausted: true}},
+	}, s.mockPrimary, s.mockSecondary)
+
+	require.Equal(s.T(), []dto.ProviderStatus{
+		{Name: "fastforex", BudgetExhausted: true},
+		{Name: "cryptocompare"},
+	}, providers.Statuses(context.Background()))
+}
+
+func (s *ProvidersTestSuite) TestFetchMulti_BudgetSpent() {
+	ctx := context.Background()
+	at := time.Date(2024, 6, 14, 15, 30, 0, 0, time.UTC)
+
+	l := zerolog.New(s.buf)
+	providers := currency.NewProviders(&l, currency.ProvidersOptions{
+		Budgets: []currency.BudgetReporter{budgetReporter{provider: "fastforex", exhausted: true}},
+	}, s.mockPrimary, s.mockSecondary)
+
+	s.mockSecondary.On("FetchMulti", ctx, "USD", []string{"BTC"}).
+		Return(map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.0000145")}, nil).Once()
+	s.mockSecondary.On("Historical", ctx, "BTC", "USD", at).
+		Return(decimal.NewFromInt(66000), nil).Once()
+
+	courses, errs := providers.FetchMulti(ctx, "USD", []string{"BTC"}, "fastforex")
+	require.Empty(s.T(), errs)
+	require.Equal(s.T(), "cryptocompare", courses["BTC"].Provider)
+
+	_, provider, err := providers.Historical(ctx, "BTC", "USD", at)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "cryptocompare", provider)
+
+	s.mockPrimary.AssertNotCalled(s.T(), "FetchMulti", mock.Anything, mock.Anything, mock.Anything)
+	s.mockPrimary.AssertNotCalled(s.T(), "Historical", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.Contains(s.T(), s.buf.String(), "provider fastforex is skipped: budget is spent")
+}
+
+func (s *ProvidersTestSuite) TestFetchMulti_Aggregate() {
+	ctx := context.Background()
+
+	third := mocks.NewCurrenciesAPI(s.T())
+	third.On("Name").Return("exchangerate").Maybe()
+
+	l := zerolog.New(s.buf)
+	providers := currency.NewProviders(&l, currency.ProvidersOptions{
+		Aggregate: true,
+		Tolerance: decimal.NewFromInt(1),
+	}, s.mockPrimary, s.mockSecondary, third)
+
+	s.mockPrimary.On("FetchMulti", ctx, "BTC", []string{"USD", "EUR"}).
+		Return(map[string]decimal.Decimal{
+			"USD": decimal.NewFromInt(69000),
+			"EUR": decimal.NewFromInt(64000),
+		}, nil).Once()
+
+	s.mockSecondary.On("FetchMulti", ctx, "BTC", []string{"USD", "EUR"}).
+		Return(map[string]decimal.Decimal{
+			"USD": decimal.NewFromInt(69100),
+			"EUR": decimal.NewFromInt(71000),
+		}, nil).Once()
+
+	third.On("FetchMulti", ctx, "BTC", []string{"USD", "EUR"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(80000)}, nil).Once()
+
+	courses, errs := providers.FetchMulti(ctx, "BTC", []string{"USD", "EUR"}, "exchangerate")
+
+	require.Len(s.T(), courses, 1)
+	require.True(s.T(), decimal.NewFromInt(69050).Equal(courses["USD"].Value))
+	require.Equal(s.T(), "fastforex,cryptocompare", courses["USD"].Provider)
+	require.Equal(s.T(), 2, courses["USD"].Sources)
+	require.Len(s.T(), errs, 1)
+	require.ErrorContains(s.T(), errs["EUR"], "providers courses do not agree within tolerance")
+
+	require.Contains(s.T(), s.buf.String(), "provider exchangerate course from BTC to USD is an outlier: 80000, median 69100")
+}
+
+func (s *ProvidersTestSuite) TestFetchMulti_AggregateProviderFailed() {
+	ctx := context.Background()
+
+	l := zerolog.New(s.buf)
+	providers := currency.NewProviders(&l, currency.ProvidersOptions{
+		Aggregate: true,
+		Tolerance: decimal.NewFromInt(1),
+	}, s.mockPrimary, s.mockSecondary)
+
+	s.mockPrimary.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(nil, errors.New("timeout")).Once()
+
+	s.mockSecondary.On("FetchMulti", ctx, "BTC", []string{"USD"}).
+		Return(map[string]decimal.Decimal{"USD": decimal.NewFromInt(69100)}, nil).Once()
+
+	courses, errs := providers.FetchMulti(ctx, "BTC", []string{"USD"}, "")
+	require.Empty(s.T(), errs)
+	require.Equal(s.T(), map[string]currency.Course{
+		"USD": {Value: decimal.NewFromInt(69100), Provider: "cryptocompare", Sources: 1},
+	}, courses)
+
+	require.Contains(s.T(), s.buf.String(), "provider fastforex failed: from BTC to [USD]")
+}
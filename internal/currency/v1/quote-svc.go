@@ -0,0 +1,107 @@
+package currency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	quoteentity "github.com/veleton777/test_work_blum/internal/currency/v1/quote/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+)
+
+type QuoteSvc struct {
+	quoteRepo QuoteRepo
+	converter Converter
+	ttl       time.Duration
+}
+
+func NewQuoteSvc(quoteRepo QuoteRepo, converter Converter, ttl time.Duration) *QuoteSvc {
+	return &QuoteSvc{
+		quoteRepo: quoteRepo,
+		converter: converter,
+		ttl:       ttl,
+	}
+}
+
+func (s *QuoteSvc) CreateQuote(ctx context.Context, req dto.CreateQuoteReq) (dto.QuoteResp, error) {
+	res, err := s.converter.Convert(ctx, dto.Conversion{
+		From:   req.From,
+		To:     req.To,
+		Amount: decimal.NewFromInt(1),
+	})
+	if err != nil {
+		return dto.QuoteResp{}, errors.Wrap(err, "get current rate")
+	}
+
+	now := time.Now().UTC()
+
+	quote := quoteentity.Quote{
+		ID:            uuid.New(),
+		CodeFrom:      req.From,
+		CodeTo:        req.To,
+		Rate:          res.Rate,
+		Source:        res.Source,
+		SpreadPercent: res.SpreadPercent,
+		FixedFee:      res.FixedFee,
+		MinFee:        res.MinFee,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(s.ttl),
+	}
+
+	if err = s.quoteRepo.CreateQuote(ctx, quote); err != nil {
+		return dto.QuoteResp{}, errors.Wrap(err, "save quote to storage")
+	}
+
+	return dto.QuoteResp{
+		ID:            quote.ID,
+		From:          quote.CodeFrom,
+		To:            quote.CodeTo,
+		Rate:          quote.Rate,
+		Source:        quote.Source,
+		SpreadPercent: quote.SpreadPercent,
+		FixedFee:      quote.FixedFee,
+		MinFee:        quote.MinFee,
+		ExpiresAt:     quote.ExpiresAt,
+	}, nil
+}
+
+func (s *QuoteSvc) ExecuteQuote(ctx context.Context, req dto.ExecuteQuote) (dto.ExecuteQuoteResp, error) {
+	quote, err := s.quoteRepo.GetQuote(ctx, req.ID)
+	if err != nil {
+		return dto.ExecuteQuoteResp{}, errors.Wrap(err, "get quote from storage")
+	}
+
+	now := time.Now().UTC()
+
+	if quote.IsExecuted() {
+		return dto.ExecuteQuoteResp{}, quoteentity.ErrQuoteAlreadyExecuted
+	}
+
+	if quote.IsExpired(now) {
+		return dto.ExecuteQuoteResp{}, quoteentity.ErrQuoteExpired
+	}
+
+	if err = s.quoteRepo.MarkExecuted(ctx, quote.ID, now); err != nil {
+		return dto.ExecuteQuoteResp{}, errors.Wrap(err, "mark quote executed")
+	}
+
+	// the markup is the one locked with the rate, later pricing changes don't affect the quote
+	gross := quote.Rate.Mul(req.Amount)
+	charge := quote.Charge(gross)
+
+	return dto.ExecuteQuoteResp{
+		QuoteID:       quote.ID,
+		From:          quote.CodeFrom,
+		To:            quote.CodeTo,
+		Rate:          quote.Rate,
+		Amount:        req.Amount,
+		Result:        gross,
+		SpreadPercent: quote.SpreadPercent,
+		SpreadAmount:  charge.SpreadAmount,
+		Fee:           charge.Fee,
+		Net:           charge.Net,
+		ExecutedAt:    now,
+	}, nil
+}
@@ -0,0 +1,206 @@
+package currency_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	quoteentity "github.com/veleton777/test_work_blum/internal/currency/v1/quote/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"testing"
+	"time"
+)
+
+type QuoteServiceTestSuite struct {
+	suite.Suite
+	svc           *currency.QuoteSvc
+	mockQuoteRepo *mocks.QuoteRepo
+	mockConverter *mocks.Converter
+}
+
+func (s *QuoteServiceTestSuite) SetupTest() {
+	s.mockQuoteRepo = mocks.NewQuoteRepo(s.T())
+	s.mockConverter = mocks.NewConverter(s.T())
+	s.svc = currency.NewQuoteSvc(s.mockQuoteRepo, s.mockConverter, 30*time.Second)
+}
+
+func TestQuoteServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(QuoteServiceTestSuite))
+}
+
+func (s *QuoteServiceTestSuite) TestCreateQuote_NoErr() {
+	ctx := context.Background()
+
+	s.mockConverter.On("Convert", ctx, dto.Conversion{From: "USD", To: "BTC", Amount: decimal.NewFromInt(1)}).
+		Return(dto.ConversionResult{
+			Rate:          decimal.RequireFromString("0.00001444655"),
+			Source:        "fastforex",
+			SpreadPercent: decimal.RequireFromString("0.5"),
+			FixedFee:      decimal.RequireFromString("0.000001"),
+			MinFee:        decimal.RequireFromString("0.000002"),
+		}, nil).Once()
+
+	s.mockQuoteRepo.On("CreateQuote", ctx, mock.MatchedBy(func(q quoteentity.Quote) bool {
+		return q.CodeFrom == "USD" && q.CodeTo == "BTC" &&
+			q.Rate.Equal(decimal.RequireFromString("0.00001444655")) &&
+			q.SpreadPercent.Equal(decimal.RequireFromString("0.5")) &&
+			q.FixedFee.Equal(decimal.RequireFromString("0.000001")) &&
+			q.MinFee.Equal(decimal.RequireFromString("0.000002")) &&
+			q.ExpiresAt.Sub(q.CreatedAt) == 30*time.Second
+	})).Return(nil).Once()
+
+	res, err := s.svc.CreateQuote(ctx, dto.CreateQuoteReq{From: "USD", To: "BTC"})
+	require.NoError(s.T(), err)
+
+	require.NotEqual(s.T(), uuid.Nil, res.ID)
+	require.Equal(s.T(), "0.00001444655", res.Rate.String())
+	require.Equal(s.T(), "fastforex", res.Source)
+	require.Equal(s.T(), "0.5", res.SpreadPercent.String())
+	require.True(s.T(), res.ExpiresAt.After(time.Now()))
+}
+
+func (s *QuoteServiceTestSuite) TestCreateQuote_CurrencyNotAvailable() {
+	ctx := context.Background()
+
+	s.mockConverter.On("Convert", ctx, mock.Anything).
+		Return(dto.ConversionResult{}, entity.ErrCurrencyNotAvailable).Once()
+
+	_, err := s.svc.CreateQuote(ctx, dto.CreateQuoteReq{From: "USD", To: "BTC"})
+	require.ErrorIs(s.T(), err, entity.ErrCurrencyNotAvailable)
+}
+
+func (s *QuoteServiceTestSuite) TestExecuteQuote_NoErr() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockQuoteRepo.On("GetQuote", ctx, id).
+		Return(quoteentity.Quote{
+			ID:        id,
+			CodeFrom:  "USD",
+			CodeTo:    "BTC",
+			Rate:      decimal.RequireFromString("0.00001444655"),
+			ExpiresAt: time.Now().Add(time.Minute),
+		}, nil).Once()
+
+	s.mockQuoteRepo.On("MarkExecuted", ctx, id, mock.Anything).
+		Return(nil).Once()
+
+	res, err := s.svc.ExecuteQuote(ctx, dto.ExecuteQuote{ID: id, Amount: decimal.RequireFromString("100.5")})
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), id, res.QuoteID)
+	require.Equal(s.T(), "0.001451878275", res.Result.String())
+	require.Equal(s.T(), "0.001451878275", res.Net.String())
+}
+
+func (s *QuoteServiceTestSuite) TestExecuteQuote_LockedPricing() {
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.mockQuoteRepo.On("GetQuote", ctx, id).
+		Return(quoteentity.Quote{
+			ID:            id,
+			CodeFrom:      "USD",
+			CodeTo:        "BTC",
+			Rate:          decimal.RequireFromString("0.00001444655"),
+			SpreadPercent: decimal.RequireFromString("0.5"),
+			FixedFee:      decimal.RequireFromString("0.000001"),
+			ExpiresAt:     time.Now().Add(time.Minute),
+		}, nil).Once()
+
+	s.mockQuoteRepo.On("MarkExecuted", ctx, id, mock.Anything).
+		Return(nil).Once()
+
+	res, err := s.svc.ExecuteQuote(ctx, dto.ExecuteQuote{ID: id, Amount: decimal.RequireFromString("100.5")})
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), "0.001451878275", res.Result.String())
+	require.Equal(s.T(), "0.5", res.SpreadPercent.String())
+	require.Equal(s.T(), "0.000007259391375", res.SpreadAmount.String())
+	require.Equal(s.T(), "0.000001", res.Fee.String())
+	require.Equal(s.T(), "0.001443618883625", res.Net.String())
+}
+
+func (s *QuoteServiceTestSuite) TestExecuteQuote_Err() {
+	ctx := context.Background()
+
+	id := uuid.New()
+	executedAt := time.Now()
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expErr   error
+	}{
+		{
+			name: "not_found",
+			mockFunc: func() {
+				s.mockQuoteRepo.On("GetQuote", ctx, id).
+					Return(quoteentity.Quote{}, entity.ErrEntityNotFound).Once()
+			},
+			expErr: entity.ErrEntityNotFound,
+		},
+		{
+			name: "expired",
+			mockFunc: func() {
+				s.mockQuoteRepo.On("GetQuote", ctx, id).
+					Return(quoteentity.Quote{ID: id, ExpiresAt: time.Now().Add(-time.Second)}, nil).Once()
+			},
+			expErr: quoteentity.ErrQuoteExpired,
+		},
+		{
+			name: "already_executed",
+			mockFunc: func() {
+				s.mockQuoteRepo.On("GetQuote", ctx, id).
+					Return(quoteentity.Quote{ID: id, ExpiresAt: time.Now().Add(time.Minute), ExecutedAt: &executedAt}, nil).Once()
+			},
+			expErr: quoteentity.ErrQuoteAlreadyExecuted,
+		},
+		{
+			name: "concurrent_execution",
+			mockFunc: func() {
+				s.mockQuoteRepo.On("GetQuote", ctx, id).
+					Return(quoteentity.Quote{ID: id, ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+				s.mockQuoteRepo.On("MarkExecuted", ctx, id, mock.Anything).
+					Return(quoteentity.ErrQuoteAlreadyExecuted).Once()
+			},
+			expErr: quoteentity.ErrQuoteAlreadyExecuted,
+		},
+		{
+			name: "expired_before_execution",
+			mockFunc: func() {
+				s.mockQuoteRepo.On("GetQuote", ctx, id).
+					Return(quoteentity.Quote{ID: id, ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+				s.mockQuoteRepo.On("MarkExecuted", ctx, id, mock.Anything).
+					Return(quoteentity.ErrQuoteExpired).Once()
+			},
+			expErr: quoteentity.ErrQuoteExpired,
+		},
+		{
+			name: "storage_err",
+			mockFunc: func() {
+				s.mockQuoteRepo.On("GetQuote", ctx, id).
+					Return(quoteentity.Quote{}, errors.New("pg err")).Once()
+			},
+			expErr: errors.New("pg err"),
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+
+			_, err := s.svc.ExecuteQuote(ctx, dto.ExecuteQuote{ID: id, Amount: decimal.NewFromInt(1)})
+			require.Error(t, err)
+			require.ErrorContains(t, err, tc.expErr.Error())
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package entity
+
+import "errors"
+
+var (
+	ErrQuoteExpired         = errors.New("quote expired")
+	ErrQuoteAlreadyExecuted = errors.New("quote already executed")
+)
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+)
+
+// Quote locks the mid rate together with the markup which was applied
+// to the pair when the quote was created.
+type Quote struct {
+	ID            uuid.UUID
+	CodeFrom      string
+	CodeTo        string
+	Rate          decimal.Decimal
+	Source        string
+	SpreadPercent decimal.Decimal
+	FixedFee      decimal.Decimal
+	MinFee        decimal.Decimal
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	ExecutedAt    *time.Time
+}
+
+// Charge applies the locked markup to the amount converted at the locked rate.
+func (q Quote) Charge(gross decimal.Decimal) pricingentity.Charge {
+	return pricingentity.Pricing{ //nolint:exhaustruct
+		SpreadPercent: q.SpreadPercent,
+		FixedFee:      q.FixedFee,
+		MinFee:        q.MinFee,
+	}.Apply(gross)
+}
+
+func (q Quote) IsExpired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+func (q Quote) IsExecuted() bool {
+	return q.ExecutedAt != nil
+}
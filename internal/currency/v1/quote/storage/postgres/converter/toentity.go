@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"github.com/veleton777/test_work_blum/internal/currency/v1/quote/entity"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/quote/storage/postgres/entity"
+	"github.com/veleton777/test_work_blum/internal/pkg/pgutil"
+)
+
+func QuoteToEntity(q storageentity.Quote) entity.Quote {
+	return entity.Quote{
+		ID:            q.ID,
+		CodeFrom:      q.CodeFrom,
+		CodeTo:        q.CodeTo,
+		Rate:          pgutil.NumericToDecimal(q.Rate),
+		Source:        q.Source,
+		SpreadPercent: pgutil.NumericToDecimal(q.SpreadPercent),
+		FixedFee:      pgutil.NumericToDecimal(q.FixedFee),
+		MinFee:        pgutil.NumericToDecimal(q.MinFee),
+		CreatedAt:     q.CreatedAt,
+		ExpiresAt:     q.ExpiresAt,
+		ExecutedAt:    q.ExecutedAt,
+	}
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Quote struct {
+	ID            uuid.UUID      `db:"id"`
+	CodeFrom      string         `db:"code_from"`
+	CodeTo        string         `db:"code_to"`
+	Rate          pgtype.Numeric `db:"rate"`
+	Source        string         `db:"source"`
+	SpreadPercent pgtype.Numeric `db:"spread_percent"`
+	FixedFee      pgtype.Numeric `db:"fixed_fee"`
+	MinFee        pgtype.Numeric `db:"min_fee"`
+	CreatedAt     time.Time      `db:"created_at"`
+	ExpiresAt     time.Time      `db:"expires_at"`
+	ExecutedAt    *time.Time     `db:"executed_at"`
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/quote/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/quote/storage/postgres/converter"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/quote/storage/postgres/entity"
+)
+
+const quotesTable = "quotes"
+
+type RepoPostgres struct {
+	pgClient *pgxpool.Pool
+	timeout  time.Duration
+}
+
+func NewRepoPostgres(pgClient *pgxpool.Pool, timeout time.Duration) *RepoPostgres {
+	return &RepoPostgres{pgClient: pgClient, timeout: timeout}
+}
+
+func (r *RepoPostgres) CreateQuote(ctx context.Context, quote entity.Quote) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Insert(quotesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns(
+			"id", "code_from", "code_to", "rate", "source", "spread_percent", "fixed_fee", "min_fee", "created_at", "expires_at",
+		).
+		Values(
+			quote.ID, quote.CodeFrom, quote.CodeTo, quote.Rate, quote.Source,
+			quote.SpreadPercent, quote.FixedFee, quote.MinFee, quote.CreatedAt, quote.ExpiresAt,
+		)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	if _, err = r.pgClient.Exec(ctx, query, v...); err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	return nil
+}
+
+func (r *RepoPostgres) GetQuote(ctx context.Context, id uuid.UUID) (entity.Quote, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select(
+		"id", "code_from", "code_to", "rate", "source", "spread_percent", "fixed_fee", "min_fee",
+		"created_at", "expires_at", "executed_at",
+	).
+		From(quotesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"id": id})
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return entity.Quote{}, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return entity.Quote{}, errors.Wrap(err, "pgx query")
+	}
+
+	quote, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[storageentity.Quote])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Quote{}, currencyentity.ErrEntityNotFound
+		}
+
+		return entity.Quote{}, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.QuoteToEntity(quote), nil
+}
+
+// MarkExecuted sets the execution time only for a quote which is neither
+// executed nor expired, so concurrent executions cannot both succeed. When the
+// quote is not updated, the error tells whether it is executed or expired.
+func (r *RepoPostgres) MarkExecuted(ctx context.Context, id uuid.UUID, executedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Update(quotesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Set("executed_at", executedAt).
+		Where(squirrel.Eq{"id": id, "executed_at": nil}).
+		Where(squirrel.Gt{"expires_at": executedAt})
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	cmd, err := r.pgClient.Exec(ctx, query, v...)
+	if err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return r.notExecutedErr(ctx, id, executedAt)
+	}
+
+	return nil
+}
+
+// notExecutedErr returns why the quote was not marked executed.
+func (r *RepoPostgres) notExecutedErr(ctx context.Context, id uuid.UUID, executedAt time.Time) error {
+	builder := squirrel.Select("executed_at IS NOT NULL").
+		Column(squirrel.Expr("expires_at <= ?", executedAt)).
+		From(quotesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"id": id})
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	var executed, expired bool
+
+	if err = r.pgClient.QueryRow(ctx, query, v...).Scan(&executed, &expired); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return currencyentity.ErrEntityNotFound
+		}
+
+		return errors.Wrap(err, "scan quote state")
+	}
+
+	if !executed && expired {
+		return entity.ErrQuoteExpired
+	}
+
+	return entity.ErrQuoteAlreadyExecuted
+}
@@ -0,0 +1,167 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/config"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/quote/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/quote/storage/postgres"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	repo      *postgres.RepoPostgres
+	pgxClient *pgxpool.Pool
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) SetupSuite() {
+	ctx := context.Background()
+	conf, err := config.Load()
+	s.Require().NoError(err)
+
+	pgCfg, err := pgxpool.ParseConfig(
+		fmt.Sprintf(
+			"host=%s port=%d dbname=%s user=%s password=%s",
+			conf.PgHost(),
+			conf.PgPort(),
+			conf.PgDB(),
+			conf.PgUser(),
+			conf.PgPassword(),
+		),
+	)
+	s.Require().NoError(err)
+
+	pgClient, err := pgxpool.NewWithConfig(ctx, pgCfg)
+	s.Require().NoError(err)
+
+	err = pgClient.Ping(ctx)
+	s.Require().NoError(err)
+
+	s.repo = postgres.NewRepoPostgres(pgClient, 1*time.Second)
+	s.pgxClient = pgClient
+
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownSuite() {
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownTest() {
+	s.clearCollection()
+}
+
+func (s *Suite) clearCollection() {
+	ctx := context.Background()
+
+	_, err := s.pgxClient.Exec(ctx, "TRUNCATE TABLE quotes")
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestCreateQuote_GetQuote_NoErr() {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	quote := entity.Quote{
+		ID:            uuid.New(),
+		CodeFrom:      "USD",
+		CodeTo:        "BTC",
+		Rate:          decimal.RequireFromString("0.00001444655"),
+		Source:        "fastforex",
+		SpreadPercent: decimal.RequireFromString("0.5"),
+		FixedFee:      decimal.RequireFromString("0.000001"),
+		MinFee:        decimal.RequireFromString("0.000002"),
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(30 * time.Second),
+	}
+
+	err := s.repo.CreateQuote(ctx, quote)
+	s.Require().NoError(err)
+
+	res, err := s.repo.GetQuote(ctx, quote.ID)
+	s.Require().NoError(err)
+
+	s.Require().Equal(quote.ID, res.ID)
+	s.Require().Equal(quote.CodeFrom, res.CodeFrom)
+	s.Require().Equal(quote.CodeTo, res.CodeTo)
+	s.Require().True(quote.Rate.Equal(res.Rate))
+	s.Require().Equal(quote.Source, res.Source)
+	s.Require().True(quote.SpreadPercent.Equal(res.SpreadPercent))
+	s.Require().True(quote.FixedFee.Equal(res.FixedFee))
+	s.Require().True(quote.MinFee.Equal(res.MinFee))
+	s.Require().True(quote.ExpiresAt.Equal(res.ExpiresAt))
+	s.Require().Nil(res.ExecutedAt)
+}
+
+func (s *Suite) TestGetQuote_ReturnNotFoundErr() {
+	ctx := context.Background()
+
+	_, err := s.repo.GetQuote(ctx, uuid.New())
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
+
+func (s *Suite) TestMarkExecuted() {
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+
+	quote := entity.Quote{
+		ID:        uuid.New(),
+		CodeFrom:  "USD",
+		CodeTo:    "BTC",
+		Rate:      decimal.RequireFromString("0.00001444655"),
+		CreatedAt: now,
+		ExpiresAt: now.Add(30 * time.Second),
+	}
+
+	err := s.repo.CreateQuote(ctx, quote)
+	s.Require().NoError(err)
+
+	err = s.repo.MarkExecuted(ctx, quote.ID, now.Add(time.Second))
+	s.Require().NoError(err)
+
+	err = s.repo.MarkExecuted(ctx, quote.ID, now.Add(2*time.Second))
+	s.Require().ErrorIs(err, entity.ErrQuoteAlreadyExecuted)
+
+	res, err := s.repo.GetQuote(ctx, quote.ID)
+	s.Require().NoError(err)
+	s.Require().NotNil(res.ExecutedAt)
+}
+
+func (s *Suite) TestMarkExecuted_Expired() {
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+
+	quote := entity.Quote{
+		ID:        uuid.New(),
+		CodeFrom:  "USD",
+		CodeTo:    "BTC",
+		Rate:      decimal.RequireFromString("0.00001444655"),
+		CreatedAt: now,
+		ExpiresAt: now.Add(30 * time.Second),
+	}
+
+	err := s.repo.CreateQuote(ctx, quote)
+	s.Require().NoError(err)
+
+	err = s.repo.MarkExecuted(ctx, quote.ID, now.Add(time.Minute))
+	s.Require().ErrorIs(err, entity.ErrQuoteExpired)
+
+	err = s.repo.MarkExecuted(ctx, uuid.New(), now)
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
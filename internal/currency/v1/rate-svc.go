@@ -0,0 +1,79 @@
+package currency
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	rateentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+)
+
+type RateSvc struct {
+	ratesRepo RatesRepo
+}
+
+func NewRateSvc(ratesRepo RatesRepo) *RateSvc {
+	return &RateSvc{
+		ratesRepo: ratesRepo,
+	}
+}
+
+// RatesHistory returns at most req.Limit rates from the start of the range, when
+// the range has more of them the response points at the start of the next page.
+func (s *RateSvc) RatesHistory(ctx context.Context, req dto.RatesHistoryReq) (dto.RatesHistoryResp, error) {
+	// one more rate is read to find out whether there is the next page
+	rates, err := s.ratesRepo.GetRates(ctx, req.From, req.To, req.Start, req.End, uint64(req.Limit)+1) //nolint:gosec
+	if err != nil {
+		return dto.RatesHistoryResp{}, errors.Wrap(err, "get rates from storage")
+	}
+
+	resp := dto.RatesHistoryResp{
+		From:  req.From,
+		To:    req.To,
+		Rates: make([]dto.Rate, 0, min(len(rates), req.Limit)),
+	}
+
+	if len(rates) > req.Limit {
+		resp.NextStart = &rates[req.Limit].CreatedAt
+		rates = rates[:req.Limit]
+	}
+
+	for _, r := range rates {
+		resp.Rates = append(resp.Rates, dto.Rate{
+			Rate:      r.Rate,
+			Source:    r.Provider,
+			CycleID:   r.CycleID,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *RateSvc) Candles(ctx context.Context, req dto.CandlesReq) (dto.CandlesResp, error) {
+	candles, err := s.ratesRepo.GetCandles(
+		ctx, req.From, req.To, rateentity.CandleInterval(req.Interval), req.Start, req.End,
+	)
+	if err != nil {
+		return dto.CandlesResp{}, errors.Wrap(err, "get candles from storage")
+	}
+
+	resp := dto.CandlesResp{
+		From:     req.From,
+		To:       req.To,
+		Interval: req.Interval,
+		Candles:  make([]dto.Candle, 0, len(candles)),
+	}
+
+	for _, c := range candles {
+		resp.Candles = append(resp.Candles, dto.Candle{
+			Start: c.BucketStart,
+			Open:  c.Open,
+			High:  c.High,
+			Low:   c.Low,
+			Close: c.Close,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,179 @@
+package currency_test
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	rateentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"testing"
+	"time"
+)
+
+type RateServiceTestSuite struct {
+	suite.Suite
+	svc           *currency.RateSvc
+	mockRatesRepo *mocks.RatesRepo
+}
+
+func (s *RateServiceTestSuite) SetupTest() {
+	s.mockRatesRepo = mocks.NewRatesRepo(s.T())
+	s.svc = currency.NewRateSvc(s.mockRatesRepo)
+}
+
+func TestRateServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RateServiceTestSuite))
+}
+
+func (s *RateServiceTestSuite) TestRatesHistory_NoErr() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	s.mockRatesRepo.On("GetRates", ctx, "USD", "BTC", start, end, uint64(11)).
+		Return(rateentity.Rates{
+			{
+				CodeFrom:  "USD",
+				CodeTo:    "BTC",
+				Rate:      decimal.NewFromFloat(0.00001444655),
+				CreatedAt: start.Add(14 * time.Hour),
+			},
+		}, nil).Once()
+
+	res, err := s.svc.RatesHistory(ctx, dto.RatesHistoryReq{
+		From:  "USD",
+		To:    "BTC",
+		Start: start,
+		End:   end,
+		Limit: 10,
+	})
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), dto.RatesHistoryResp{
+		From: "USD",
+		To:   "BTC",
+		Rates: []dto.Rate{
+			{
+				Rate:      decimal.NewFromFloat(0.00001444655),
+				CreatedAt: start.Add(14 * time.Hour),
+			},
+		},
+	}, res)
+}
+
+func (s *RateServiceTestSuite) TestRatesHistory_NextPage() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	s.mockRatesRepo.On("GetRates", ctx, "USD", "BTC", start, end, uint64(3)).
+		Return(rateentity.Rates{
+			{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.NewFromInt(1), CreatedAt: start.Add(time.Hour)},
+			{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.NewFromInt(2), CreatedAt: start.Add(2 * time.Hour)},
+			{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.NewFromInt(3), CreatedAt: start.Add(3 * time.Hour)},
+		}, nil).Once()
+
+	res, err := s.svc.RatesHistory(ctx, dto.RatesHistoryReq{
+		From:  "USD",
+		To:    "BTC",
+		Start: start,
+		End:   end,
+		Limit: 2,
+	})
+	require.NoError(s.T(), err)
+
+	require.Len(s.T(), res.Rates, 2)
+	require.Equal(s.T(), start.Add(2*time.Hour), res.Rates[1].CreatedAt)
+	require.NotNil(s.T(), res.NextStart)
+	require.Equal(s.T(), start.Add(3*time.Hour), *res.NextStart)
+}
+
+func (s *RateServiceTestSuite) TestRatesHistory_Err() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	s.mockRatesRepo.On("GetRates", ctx, "USD", "BTC", start, end, uint64(11)).
+		Return(nil, errors.New("pg err")).Once()
+
+	_, err := s.svc.RatesHistory(ctx, dto.RatesHistoryReq{
+		From:  "USD",
+		To:    "BTC",
+		Start: start,
+		End:   end,
+		Limit: 10,
+	})
+	require.Error(s.T(), err)
+	require.ErrorContains(s.T(), err, "pg err")
+}
+
+func (s *RateServiceTestSuite) TestCandles_NoErr() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	s.mockRatesRepo.On("GetCandles", ctx, "USD", "BTC", rateentity.CandleHour, start, end).
+		Return(rateentity.Candles{
+			{
+				CodeFrom:    "USD",
+				CodeTo:      "BTC",
+				Interval:    rateentity.CandleHour,
+				BucketStart: start.Add(14 * time.Hour),
+				Open:        decimal.RequireFromString("0.00001444655"),
+				High:        decimal.RequireFromString("0.00001445"),
+				Low:         decimal.RequireFromString("0.0000144"),
+				Close:       decimal.RequireFromString("0.00001444"),
+			},
+		}, nil).Once()
+
+	res, err := s.svc.Candles(ctx, dto.CandlesReq{
+		From:     "USD",
+		To:       "BTC",
+		Interval: "1h",
+		Start:    start,
+		End:      end,
+	})
+	require.NoError(s.T(), err)
+
+	require.Equal(s.T(), dto.CandlesResp{
+		From:     "USD",
+		To:       "BTC",
+		Interval: "1h",
+		Candles: []dto.Candle{
+			{
+				Start: start.Add(14 * time.Hour),
+				Open:  decimal.RequireFromString("0.00001444655"),
+				High:  decimal.RequireFromString("0.00001445"),
+				Low:   decimal.RequireFromString("0.0000144"),
+				Close: decimal.RequireFromString("0.00001444"),
+			},
+		},
+	}, res)
+}
+
+func (s *RateServiceTestSuite) TestCandles_Err() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	s.mockRatesRepo.On("GetCandles", ctx, "USD", "BTC", rateentity.CandleDay, start, end).
+		Return(nil, errors.New("pg err")).Once()
+
+	_, err := s.svc.Candles(ctx, dto.CandlesReq{
+		From:     "USD",
+		To:       "BTC",
+		Interval: "1d",
+		Start:    start,
+		End:      end,
+	})
+	require.ErrorContains(s.T(), err, "pg err")
+}
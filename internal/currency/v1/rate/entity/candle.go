@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CandleInterval string
+
+const (
+	CandleMinute CandleInterval = "1m"
+	CandleHour   CandleInterval = "1h"
+	CandleDay    CandleInterval = "1d"
+)
+
+// CandleIntervals are the intervals every saved rate is rolled into.
+var CandleIntervals = []CandleInterval{CandleMinute, CandleHour, CandleDay} //nolint:gochecknoglobals
+
+func (i CandleInterval) Duration() time.Duration {
+	switch i {
+	case CandleMinute:
+		return time.Minute
+	case CandleHour:
+		return time.Hour
+	case CandleDay:
+		return 24 * time.Hour
+	}
+
+	return 0
+}
+
+// BucketStart returns the start of the candle the moment belongs to, buckets are aligned to UTC.
+func (i CandleInterval) BucketStart(t time.Time) time.Time {
+	return t.UTC().Truncate(i.Duration())
+}
+
+type Candle struct {
+	CodeFrom    string
+	CodeTo      string
+	Interval    CandleInterval
+	BucketStart time.Time
+	Open        decimal.Decimal
+	High        decimal.Decimal
+	Low         decimal.Decimal
+	Close       decimal.Decimal
+}
+
+type Candles []Candle
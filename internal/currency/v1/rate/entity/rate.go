@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Rate struct {
+	CodeFrom  string
+	CodeTo    string
+	Rate      decimal.Decimal
+	Provider  string
+	CycleID   uuid.UUID
+	CreatedAt time.Time
+	// IsBackfilled is set for a rate taken from the provider history to fill a gap, it is
+	// a daily rate stamped with the requested moment, so it is not rolled into candles
+	IsBackfilled bool
+}
+
+type Rates []Rate
@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/storage/postgres/entity"
+	"github.com/veleton777/test_work_blum/internal/pkg/pgutil"
+)
+
+func RateToEntity(r storageentity.Rate) entity.Rate {
+	return entity.Rate{
+		CodeFrom:     r.CodeFrom,
+		CodeTo:       r.CodeTo,
+		Rate:         pgutil.NumericToDecimal(r.Rate),
+		Provider:     r.Provider,
+		CycleID:      r.CycleID.Bytes,
+		CreatedAt:    r.CreatedAt,
+		IsBackfilled: r.IsBackfilled,
+	}
+}
+
+func RatesToEntity(rates []storageentity.Rate) entity.Rates {
+	res := make(entity.Rates, 0, len(rates))
+
+	for _, r := range rates {
+		res = append(res, RateToEntity(r))
+	}
+
+	return res
+}
+
+func CandleToEntity(c storageentity.Candle) entity.Candle {
+	return entity.Candle{
+		CodeFrom:    c.CodeFrom,
+		CodeTo:      c.CodeTo,
+		Interval:    entity.CandleInterval(c.Interval),
+		BucketStart: c.BucketStart,
+		Open:        pgutil.NumericToDecimal(c.Open),
+		High:        pgutil.NumericToDecimal(c.High),
+		Low:         pgutil.NumericToDecimal(c.Low),
+		Close:       pgutil.NumericToDecimal(c.Close),
+	}
+}
+
+func CandlesToEntity(candles []storageentity.Candle) entity.Candles {
+	res := make(entity.Candles, 0, len(candles))
+
+	for _, c := range candles {
+		res = append(res, CandleToEntity(c))
+	}
+
+	return res
+}
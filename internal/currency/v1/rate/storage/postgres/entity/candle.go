@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Candle struct {
+	CodeFrom    string         `db:"code_from"`
+	CodeTo      string         `db:"code_to"`
+	Interval    string         `db:"period"`
+	BucketStart time.Time      `db:"bucket_start"`
+	Open        pgtype.Numeric `db:"open"`
+	High        pgtype.Numeric `db:"high"`
+	Low         pgtype.Numeric `db:"low"`
+	Close       pgtype.Numeric `db:"close"`
+}
+
+type Candles []Candle
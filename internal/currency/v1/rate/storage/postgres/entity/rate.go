@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Rate struct {
+	CodeFrom     string         `db:"code_from"`
+	CodeTo       string         `db:"code_to"`
+	Rate         pgtype.Numeric `db:"rate"`
+	Provider     string         `db:"provider"`
+	CycleID      pgtype.UUID    `db:"cycle_id"`
+	CreatedAt    time.Time      `db:"created_at"`
+	IsBackfilled bool           `db:"is_backfilled"`
+}
+
+type Rates []Rate
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/rate/storage/postgres/converter"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/storage/postgres/entity"
+)
+
+const (
+	ratesTable   = "currency_rates"
+	candlesTable = "rate_candles"
+)
+
+var rateColumns = []string{ //nolint:gochecknoglobals
+	"code_from", "code_to", "rate", "provider", "cycle_id", "created_at", "is_backfilled",
+}
+
+type RepoPostgres struct {
+	pgClient *pgxpool.Pool
+	timeout  time.Duration
+}
+
+func NewRepoPostgres(pgClient *pgxpool.Pool, timeout time.Duration) *RepoPostgres {
+	return &RepoPostgres{pgClient: pgClient, timeout: timeout}
+}
+
+// SaveRate persists the rate and rolls it into its candles in one transaction,
+// backfilled rates are kept out of the candles.
+func (r *RepoPostgres) SaveRate(ctx context.Context, rate entity.Rate) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Insert(ratesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns(rateColumns...).
+		Values(rate.CodeFrom, rate.CodeTo, rate.Rate, rate.Provider, cycleID(rate.CycleID), rate.CreatedAt, rate.IsBackfilled)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	tx, err := r.pgClient.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "begin pg tx")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err = tx.Exec(ctx, query, v...); err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	if !rate.IsBackfilled {
+		for _, interval := range entity.CandleIntervals {
+			if err = r.upsertCandle(ctx, tx, rate, interval); err != nil {
+				return errors.Wrapf(err, "upsert %s candle", interval)
+			}
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "commit pg tx")
+	}
+
+	return nil
+}
+
+// upsertCandle opens the candle with the rate or extends it. Open and close are
+// chosen by the rate time, so rates saved out of order still land correctly.
+func (r *RepoPostgres) upsertCandle(ctx context.Context, tx pgx.Tx, rate entity.Rate, interval entity.CandleInterval) error {
+	builder := squirrel.Insert(candlesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns(
+			"code_from", "code_to", "period", "bucket_start", "open", "high", "low", "close", "open_at", "close_at",
+		).
+		Values(
+			rate.CodeFrom,
+			rate.CodeTo,
+			string(interval),
+			interval.BucketStart(rate.CreatedAt),
+			rate.Rate,
+			rate.Rate,
+			rate.Rate,
+			rate.Rate,
+			rate.CreatedAt,
+			rate.CreatedAt,
+		).
+		Suffix(`ON CONFLICT (code_from, code_to, period, bucket_start) DO UPDATE SET
+			open = CASE WHEN EXCLUDED.open_at < rate_candles.open_at THEN EXCLUDED.open ELSE rate_candles.open END,
+			high = GREATEST(rate_candles.high, EXCLUDED.high),
+			low = LEAST(rate_candles.low, EXCLUDED.low),
+			close = CASE WHEN EXCLUDED.close_at >= rate_candles.close_at THEN EXCLUDED.close ELSE rate_candles.close END,
+			open_at = LEAST(rate_candles.open_at, EXCLUDED.open_at),
+			close_at = GREATEST(rate_candles.close_at, EXCLUDED.close_at)`)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	if _, err = tx.Exec(ctx, query, v...); err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	return nil
+}
+
+// GetRates returns at most limit earliest rates saved by the update cycles, backfilled rates are skipped.
+func (r *RepoPostgres) GetRates(
+	ctx context.Context,
+	codeFrom, codeTo string,
+	start, end time.Time,
+	limit uint64,
+) (entity.Rates, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select(rateColumns...).
+		From(ratesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"code_from": codeFrom, "code_to": codeTo, "is_backfilled": false}).
+		Where(squirrel.GtOrEq{"created_at": start}).
+		Where(squirrel.LtOrEq{"created_at": end}).
+		OrderBy("created_at").
+		Limit(limit)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx query")
+	}
+	defer rows.Close()
+
+	rates, err := pgx.CollectRows(rows, pgx.RowToStructByName[storageentity.Rate])
+	if err != nil {
+		return nil, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.RatesToEntity(rates), nil
+}
+
+// GetCandles returns candles of the pair whose buckets start in [start, end].
+func (r *RepoPostgres) GetCandles(
+	ctx context.Context,
+	codeFrom, codeTo string,
+	interval entity.CandleInterval,
+	start, end time.Time,
+) (entity.Candles, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select(
+		"code_from", "code_to", "period", "bucket_start", "open", "high", "low", "close",
+	).
+		From(candlesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"code_from": codeFrom, "code_to": codeTo, "period": string(interval)}).
+		Where(squirrel.GtOrEq{"bucket_start": start}).
+		Where(squirrel.LtOrEq{"bucket_start": end}).
+		OrderBy("bucket_start")
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgx query")
+	}
+	defer rows.Close()
+
+	candles, err := pgx.CollectRows(rows, pgx.RowToStructByName[storageentity.Candle])
+	if err != nil {
+		return nil, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.CandlesToEntity(candles), nil
+}
+
+// GetRateAt returns the latest rate of the pair saved at or before the moment.
+func (r *RepoPostgres) GetRateAt(ctx context.Context, codeFrom, codeTo string, at time.Time) (entity.Rate, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select(rateColumns...).
+		From(ratesTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"code_from": codeFrom, "code_to": codeTo}).
+		Where(squirrel.LtOrEq{"created_at": at}).
+		OrderBy("created_at DESC").
+		Limit(1)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return entity.Rate{}, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return entity.Rate{}, errors.Wrap(err, "pgx query")
+	}
+
+	rate, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[storageentity.Rate])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Rate{}, currencyentity.ErrEntityNotFound
+		}
+
+		return entity.Rate{}, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.RateToEntity(rate), nil
+}
+
+func cycleID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: id != uuid.Nil}
+}
@@ -0,0 +1,197 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/config"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/rate/storage/postgres"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	repo      *postgres.RepoPostgres
+	pgxClient *pgxpool.Pool
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) SetupSuite() {
+	ctx := context.Background()
+	conf, err := config.Load()
+	s.Require().NoError(err)
+
+	pgCfg, err := pgxpool.ParseConfig(
+		fmt.Sprintf(
+			"host=%s port=%d dbname=%s user=%s password=%s",
+			conf.PgHost(),
+			conf.PgPort(),
+			conf.PgDB(),
+			conf.PgUser(),
+			conf.PgPassword(),
+		),
+	)
+	s.Require().NoError(err)
+
+	pgClient, err := pgxpool.NewWithConfig(ctx, pgCfg)
+	s.Require().NoError(err)
+
+	err = pgClient.Ping(ctx)
+	s.Require().NoError(err)
+
+	s.repo = postgres.NewRepoPostgres(pgClient, 1*time.Second)
+	s.pgxClient = pgClient
+
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownSuite() {
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownTest() {
+	s.clearCollection()
+}
+
+func (s *Suite) clearCollection() {
+	ctx := context.Background()
+
+	_, err := s.pgxClient.Exec(ctx, "TRUNCATE TABLE currency_rates, rate_candles")
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestSaveRate_GetRates_NoErr() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+
+	rates := entity.Rates{
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("0.00001444655"), Provider: "fastforex", CycleID: uuid.New(), CreatedAt: start.Add(time.Hour)},
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("0.00001444700"), CreatedAt: start.Add(2 * time.Hour)},
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("0.00001444800"), CreatedAt: start.Add(48 * time.Hour)},
+		{CodeFrom: "BTC", CodeTo: "USD", Rate: decimal.RequireFromString("69220.5"), CreatedAt: start.Add(time.Hour)},
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("0.00001"), CreatedAt: start.Add(3 * time.Hour), IsBackfilled: true},
+	}
+
+	for _, r := range rates {
+		err := s.repo.SaveRate(ctx, r)
+		s.Require().NoError(err)
+	}
+
+	res, err := s.repo.GetRates(ctx, "USD", "BTC", start, start.Add(24*time.Hour), 10)
+	s.Require().NoError(err)
+
+	s.Require().Len(res, 2)
+
+	s.Require().True(rates[0].Rate.Equal(res[0].Rate))
+	s.Require().True(rates[0].CreatedAt.Equal(res[0].CreatedAt))
+	s.Require().Equal(rates[0].Provider, res[0].Provider)
+	s.Require().Equal(rates[0].CycleID, res[0].CycleID)
+	s.Require().True(rates[1].Rate.Equal(res[1].Rate))
+	s.Require().True(rates[1].CreatedAt.Equal(res[1].CreatedAt))
+
+	res, err = s.repo.GetRates(ctx, "USD", "BTC", start, start.Add(24*time.Hour), 1)
+	s.Require().NoError(err)
+
+	s.Require().Len(res, 1)
+	s.Require().True(rates[0].CreatedAt.Equal(res[0].CreatedAt))
+}
+
+func (s *Suite) TestGetRates_Empty() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+
+	res, err := s.repo.GetRates(ctx, "USD", "BTC", start, start.Add(24*time.Hour), 10)
+	s.Require().NoError(err)
+	s.Require().Len(res, 0)
+}
+
+func (s *Suite) TestGetRateAt() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+
+	rates := entity.Rates{
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("0.00001444655"), CreatedAt: start.Add(time.Hour)},
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("0.00001444700"), CreatedAt: start.Add(2 * time.Hour)},
+	}
+
+	for _, r := range rates {
+		err := s.repo.SaveRate(ctx, r)
+		s.Require().NoError(err)
+	}
+
+	res, err := s.repo.GetRateAt(ctx, "USD", "BTC", start.Add(90*time.Minute))
+	s.Require().NoError(err)
+	s.Require().True(rates[0].Rate.Equal(res.Rate))
+	s.Require().True(rates[0].CreatedAt.Equal(res.CreatedAt))
+
+	_, err = s.repo.GetRateAt(ctx, "USD", "BTC", start)
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
+
+func (s *Suite) TestSaveRate_GetCandles() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC)
+
+	// the last rate is saved out of order and must not become the close
+	rates := entity.Rates{
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("2"), CreatedAt: start.Add(10 * time.Second)},
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("5"), CreatedAt: start.Add(20 * time.Second)},
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("3"), CreatedAt: start.Add(50 * time.Second)},
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("4"), CreatedAt: start.Add(90 * time.Second)},
+		{CodeFrom: "USD", CodeTo: "BTC", Rate: decimal.RequireFromString("1"), CreatedAt: start.Add(30 * time.Second)},
+	}
+
+	for _, r := range rates {
+		err := s.repo.SaveRate(ctx, r)
+		s.Require().NoError(err)
+	}
+
+	minutes, err := s.repo.GetCandles(ctx, "USD", "BTC", entity.CandleMinute, start, start.Add(time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(minutes, 2)
+
+	s.Require().True(start.Equal(minutes[0].BucketStart))
+	s.Require().Equal("2", minutes[0].Open.String())
+	s.Require().Equal("5", minutes[0].High.String())
+	s.Require().Equal("1", minutes[0].Low.String())
+	s.Require().Equal("3", minutes[0].Close.String())
+
+	s.Require().True(start.Add(time.Minute).Equal(minutes[1].BucketStart))
+	s.Require().Equal("4", minutes[1].Open.String())
+	s.Require().Equal("4", minutes[1].Close.String())
+
+	// a backfilled daily rate must not move the candles
+	err = s.repo.SaveRate(ctx, entity.Rate{
+		CodeFrom:     "USD",
+		CodeTo:       "BTC",
+		Rate:         decimal.RequireFromString("100"),
+		CreatedAt:    start.Add(40 * time.Second),
+		IsBackfilled: true,
+	})
+	s.Require().NoError(err)
+
+	days, err := s.repo.GetCandles(ctx, "USD", "BTC", entity.CandleDay, start.Add(-24*time.Hour), start)
+	s.Require().NoError(err)
+	s.Require().Len(days, 1)
+
+	s.Require().True(time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC).Equal(days[0].BucketStart))
+	s.Require().Equal("2", days[0].Open.String())
+	s.Require().Equal("5", days[0].High.String())
+	s.Require().Equal("1", days[0].Low.String())
+	s.Require().Equal("4", days[0].Close.String())
+}
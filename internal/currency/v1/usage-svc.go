@@ -0,0 +1,176 @@
+package currency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	usageentity "github.com/veleton777/test_work_blum/internal/currency/v1/usage/entity"
+)
+
+// UsageSvc counts calls to the provider against its budget and stretches
+// the refresh interval when the budget would be exceeded.
+type UsageSvc struct {
+	usageRepo UsageRepo
+	api       UsageAPI
+	opts      UsageOptions
+	l         *zerolog.Logger
+
+	mu sync.Mutex
+	// cycleCalls is how many calls the last update cycle made
+	cycleCalls int64
+	// exhausted is set when the rest of the budget is not enough for one more cycle
+	exhausted bool
+}
+
+type UsageOptions struct {
+	// Budget is how many calls are allowed in the period, zero disables budgeting
+	Budget int64
+	Period usageentity.Period
+	// Delay is the refresh interval used while the projected usage fits the budget
+	Delay time.Duration
+	// MaxDelay caps the stretched interval, so the courses are refreshed at least
+	// that often even when the budget is spent, zero doesn't cap it
+	MaxDelay time.Duration
+}
+
+func NewUsageSvc(usageRepo UsageRepo, api UsageAPI, opts UsageOptions, l *zerolog.Logger) *UsageSvc {
+	return &UsageSvc{
+		usageRepo: usageRepo,
+		api:       api,
+		opts:      opts,
+		l:         l,
+	}
+}
+
+// Provider returns the name of the provider the budget belongs to.
+func (s *UsageSvc) Provider() string {
+	return s.api.Name()
+}
+
+// BudgetExhausted reports whether the last computed interval found the budget spent.
+func (s *UsageSvc) BudgetExhausted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.exhausted
+}
+
+// Record saves the calls made since the previous record, it is called after every update cycle.
+// All the calls count against the budget, while only the fetch calls are the cost of the cycle.
+func (s *UsageSvc) Record(ctx context.Context) error {
+	calls := s.api.TakeCalls()
+	cycleCalls := s.api.TakeFetchCalls()
+
+	// the providers skip the provider while its budget is spent, so a cycle without
+	// fetch calls keeps the cost of the last one and the budget stays spent
+	if cycleCalls > 0 {
+		s.mu.Lock()
+		s.cycleCalls = cycleCalls
+		s.mu.Unlock()
+	}
+
+	if calls == 0 {
+		return nil
+	}
+
+	start, _ := s.opts.Period.Bounds(time.Now())
+
+	if err := s.usageRepo.AddCalls(ctx, s.api.Name(), start, calls); err != nil {
+		return errors.Wrap(err, "add calls to storage")
+	}
+
+	return nil
+}
+
+// Reconcile replaces the counted calls with the provider figures. The provider
+// reports the monthly usage, so the daily counter is not reconciled.
+func (s *UsageSvc) Reconcile(ctx context.Context) error {
+	if s.opts.Period != usageentity.PeriodMonth {
+		return nil
+	}
+
+	calls, err := s.api.Usage(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get usage from provider")
+	}
+
+	start, _ := s.opts.Period.Bounds(time.Now())
+
+	if err = s.usageRepo.SetCalls(ctx, s.api.Name(), start, calls); err != nil {
+		return errors.Wrap(err, "set calls in storage")
+	}
+
+	return nil
+}
+
+// Delay returns the refresh interval. When cycles as expensive as the last one
+// run every Delay until the end of the period would exceed the budget, the interval
+// is stretched to spread the rest of the budget evenly; when the budget is spent
+// the next cycle waits for the next period. The interval never exceeds MaxDelay.
+func (s *UsageSvc) Delay(ctx context.Context) time.Duration {
+	s.mu.Lock()
+	cycleCalls := s.cycleCalls
+	s.mu.Unlock()
+
+	if s.opts.Budget <= 0 || cycleCalls == 0 {
+		s.setExhausted(false)
+
+		return s.opts.Delay
+	}
+
+	now := time.Now()
+	start, end := s.opts.Period.Bounds(now)
+
+	var used int64
+
+	usage, err := s.usageRepo.GetUsage(ctx, s.api.Name(), start)
+	if err == nil {
+		used = usage.Calls
+	} else if !errors.Is(err, entity.ErrEntityNotFound) {
+		s.l.Err(err).Msg("get usage from storage")
+
+		return s.opts.Delay
+	}
+
+	left := end.Sub(now)
+	remaining := s.opts.Budget - used
+
+	if remaining < cycleCalls {
+		s.setExhausted(true)
+		s.l.Warn().Msgf("provider %s budget is spent: %d of %d calls", s.api.Name(), used, s.opts.Budget)
+
+		return s.capDelay(left)
+	}
+
+	s.setExhausted(false)
+
+	delay := left / time.Duration(remaining/cycleCalls)
+	if delay <= s.opts.Delay {
+		return s.opts.Delay
+	}
+
+	delay = s.capDelay(delay)
+
+	s.l.Info().Msgf("provider %s refresh interval is stretched to %s: %d of %d calls used",
+		s.api.Name(), delay, used, s.opts.Budget)
+
+	return delay
+}
+
+func (s *UsageSvc) capDelay(d time.Duration) time.Duration {
+	if s.opts.MaxDelay > 0 && d > s.opts.MaxDelay {
+		return s.opts.MaxDelay
+	}
+
+	return d
+}
+
+func (s *UsageSvc) setExhausted(exhausted bool) {
+	s.mu.Lock()
+	s.exhausted = exhausted
+	s.mu.Unlock()
+}
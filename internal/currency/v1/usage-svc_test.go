@@ -0,0 +1,231 @@
+package currency_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/mocks"
+	usageentity "github.com/veleton777/test_work_blum/internal/currency/v1/usage/entity"
+	"testing"
+	"time"
+)
+
+type UsageServiceTestSuite struct {
+	suite.Suite
+	mockUsageRepo *mocks.UsageRepo
+	mockUsageAPI  *mocks.UsageAPI
+
+	buf *bytes.Buffer
+}
+
+func (s *UsageServiceTestSuite) SetupTest() {
+	s.buf = &bytes.Buffer{}
+
+	s.mockUsageRepo = mocks.NewUsageRepo(s.T())
+	s.mockUsageAPI = mocks.NewUsageAPI(s.T())
+	s.mockUsageAPI.On("Name").Return("fastforex").Maybe()
+}
+
+func TestUsageServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(UsageServiceTestSuite))
+}
+
+func (s *UsageServiceTestSuite) svc(opts currency.UsageOptions) *currency.UsageSvc {
+	l := zerolog.New(s.buf)
+
+	return currency.NewUsageSvc(s.mockUsageRepo, s.mockUsageAPI, opts, &l)
+}
+
+func (s *UsageServiceTestSuite) TestRecord() {
+	ctx := context.Background()
+	start, _ := usageentity.PeriodMonth.Bounds(time.Now())
+
+	svc := s.svc(currency.UsageOptions{Period: usageentity.PeriodMonth})
+
+	s.mockUsageAPI.On("TakeCalls").Return(int64(5)).Once()
+	s.mockUsageAPI.On("TakeFetchCalls").Return(int64(3)).Once()
+	s.mockUsageRepo.On("AddCalls", ctx, "fastforex", start, int64(5)).Return(nil).Once()
+
+	require.NoError(s.T(), svc.Record(ctx))
+
+	s.mockUsageAPI.On("TakeCalls").Return(int64(0)).Once()
+	s.mockUsageAPI.On("TakeFetchCalls").Return(int64(0)).Once()
+
+	require.NoError(s.T(), svc.Record(ctx))
+
+	s.mockUsageRepo.AssertNumberOfCalls(s.T(), "AddCalls", 1)
+}
+
+func (s *UsageServiceTestSuite) TestRecord_StorageErr() {
+	ctx := context.Background()
+
+	svc := s.svc(currency.UsageOptions{Period: usageentity.PeriodDay})
+
+	s.mockUsageAPI.On("TakeCalls").Return(int64(5)).Once()
+	s.mockUsageAPI.On("TakeFetchCalls").Return(int64(5)).Once()
+	s.mockUsageRepo.On("AddCalls", ctx, "fastforex", mock.Anything, int64(5)).Return(errors.New("pg err")).Once()
+
+	require.ErrorContains(s.T(), svc.Record(ctx), "add calls to storage: pg err")
+}
+
+func (s *UsageServiceTestSuite) TestReconcile() {
+	ctx := context.Background()
+	start, _ := usageentity.PeriodMonth.Bounds(time.Now())
+
+	s.mockUsageAPI.On("Usage", ctx).Return(int64(1234), nil).Once()
+	s.mockUsageRepo.On("SetCalls", ctx, "fastforex", start, int64(1234)).Return(nil).Once()
+
+	require.NoError(s.T(), s.svc(currency.UsageOptions{Period: usageentity.PeriodMonth}).Reconcile(ctx))
+
+	require.NoError(s.T(), s.svc(currency.UsageOptions{Period: usageentity.PeriodDay}).Reconcile(ctx))
+
+	s.mockUsageAPI.AssertNumberOfCalls(s.T(), "Usage", 1)
+}
+
+func (s *UsageServiceTestSuite) TestDelay() {
+	ctx := context.Background()
+	now := time.Now()
+	start, end := usageentity.PeriodDay.Bounds(now)
+	left := end.Sub(now)
+
+	testCases := []struct {
+		name      string
+		budget    int64
+		maxDelay  time.Duration
+		mockFunc  func()
+		check     func(t *testing.T, delay time.Duration)
+		exhausted bool
+	}{
+		{
+			name:     "no_budget",
+			budget:   0,
+			mockFunc: func() {},
+			check: func(t *testing.T, delay time.Duration) {
+				require.Equal(t, time.Second, delay)
+			},
+		},
+		{
+			name:   "fits_budget",
+			budget: 10_000_000,
+			mockFunc: func() {
+				s.mockUsageRepo.On("GetUsage", ctx, "fastforex", start).
+					Return(usageentity.Usage{}, entity.ErrEntityNotFound).Once()
+			},
+			check: func(t *testing.T, delay time.Duration) {
+				require.Equal(t, time.Second, delay)
+			},
+		},
+		{
+			name:   "stretched",
+			budget: 110,
+			mockFunc: func() {
+				s.mockUsageRepo.On("GetUsage", ctx, "fastforex", start).
+					Return(usageentity.Usage{Calls: 100}, nil).Once()
+			},
+			check: func(t *testing.T, delay time.Duration) {
+				// 10 calls left are enough for 5 cycles of 2 calls
+				require.InDelta(t, float64(left/5), float64(delay), float64(time.Second))
+			},
+		},
+		{
+			name:   "spent",
+			budget: 101,
+			mockFunc: func() {
+				s.mockUsageRepo.On("GetUsage", ctx, "fastforex", start).
+					Return(usageentity.Usage{Calls: 100}, nil).Once()
+			},
+			check: func(t *testing.T, delay time.Duration) {
+				require.InDelta(t, float64(left), float64(delay), float64(time.Second))
+			},
+			exhausted: true,
+		},
+		{
+			name:     "spent_capped",
+			budget:   101,
+			maxDelay: time.Minute,
+			mockFunc: func() {
+				s.mockUsageRepo.On("GetUsage", ctx, "fastforex", start).
+					Return(usageentity.Usage{Calls: 100}, nil).Once()
+			},
+			check: func(t *testing.T, delay time.Duration) {
+				require.Equal(t, time.Minute, delay)
+			},
+			exhausted: true,
+		},
+		{
+			name:     "stretched_capped",
+			budget:   110,
+			maxDelay: time.Minute,
+			mockFunc: func() {
+				s.mockUsageRepo.On("GetUsage", ctx, "fastforex", start).
+					Return(usageentity.Usage{Calls: 100}, nil).Once()
+			},
+			check: func(t *testing.T, delay time.Duration) {
+				require.Equal(t, time.Minute, delay)
+			},
+		},
+		{
+			name:   "storage_err",
+			budget: 110,
+			mockFunc: func() {
+				s.mockUsageRepo.On("GetUsage", ctx, "fastforex", start).
+					Return(usageentity.Usage{}, errors.New("pg err")).Once()
+			},
+			check: func(t *testing.T, delay time.Duration) {
+				require.Equal(t, time.Second, delay)
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			svc := s.svc(currency.UsageOptions{
+				Budget:   c.budget,
+				Period:   usageentity.PeriodDay,
+				Delay:    time.Second,
+				MaxDelay: c.maxDelay,
+			})
+
+			// the historical call made between the cycles counts against the budget but not against the cycle
+			s.mockUsageAPI.On("TakeCalls").Return(int64(3)).Once()
+			s.mockUsageAPI.On("TakeFetchCalls").Return(int64(2)).Once()
+			s.mockUsageRepo.On("AddCalls", ctx, "fastforex", start, int64(3)).Return(nil).Once()
+			require.NoError(t, svc.Record(ctx))
+
+			c.mockFunc()
+
+			c.check(t, svc.Delay(ctx))
+			require.Equal(t, c.exhausted, svc.BudgetExhausted())
+		})
+	}
+}
+
+func (s *UsageServiceTestSuite) TestDelay_StaysSpentWhileSkipped() {
+	ctx := context.Background()
+	start, _ := usageentity.PeriodDay.Bounds(time.Now())
+
+	svc := s.svc(currency.UsageOptions{Budget: 101, Period: usageentity.PeriodDay, Delay: time.Second})
+
+	s.mockUsageAPI.On("TakeCalls").Return(int64(2)).Once()
+	s.mockUsageAPI.On("TakeFetchCalls").Return(int64(2)).Once()
+	s.mockUsageRepo.On("AddCalls", ctx, "fastforex", start, int64(2)).Return(nil).Once()
+	s.mockUsageRepo.On("GetUsage", ctx, "fastforex", start).Return(usageentity.Usage{Calls: 100}, nil).Twice()
+
+	require.NoError(s.T(), svc.Record(ctx))
+	svc.Delay(ctx)
+	require.True(s.T(), svc.BudgetExhausted())
+
+	// the provider is skipped by the next cycle, which makes no calls
+	s.mockUsageAPI.On("TakeCalls").Return(int64(0)).Once()
+	s.mockUsageAPI.On("TakeFetchCalls").Return(int64(0)).Once()
+
+	require.NoError(s.T(), svc.Record(ctx))
+	svc.Delay(ctx)
+	require.True(s.T(), svc.BudgetExhausted())
+}
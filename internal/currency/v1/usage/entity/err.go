@@ -0,0 +1,5 @@
+package entity
+
+import "errors"
+
+var ErrInvalidPeriod = errors.New("usage period must be day or month")
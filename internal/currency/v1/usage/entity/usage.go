@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// Period is the span the calls budget of a provider is given for.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodMonth Period = "month"
+)
+
+// Usage is the number of calls made to the provider in the period started at PeriodStart.
+type Usage struct {
+	Provider    string
+	PeriodStart time.Time
+	Calls       int64
+	UpdatedAt   time.Time
+}
+
+func ParsePeriod(v string) (Period, error) {
+	switch p := Period(v); p {
+	case PeriodDay, PeriodMonth:
+		return p, nil
+	}
+
+	return "", ErrInvalidPeriod
+}
+
+// Bounds returns the start and the end of the period which contains t in UTC.
+func (p Period) Bounds(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+
+	if p == PeriodDay {
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+		return start, start.AddDate(0, 0, 1)
+	}
+
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return start, start.AddDate(0, 1, 0)
+}
@@ -0,0 +1,15 @@
+package converter
+
+import (
+	"github.com/veleton777/test_work_blum/internal/currency/v1/usage/entity"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/usage/storage/postgres/entity"
+)
+
+func UsageToEntity(u storageentity.Usage) entity.Usage {
+	return entity.Usage{
+		Provider:    u.Provider,
+		PeriodStart: u.PeriodStart.UTC(),
+		Calls:       u.Calls,
+		UpdatedAt:   u.UpdatedAt,
+	}
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+type Usage struct {
+	Provider    string    `db:"provider"`
+	PeriodStart time.Time `db:"period_start"`
+	Calls       int64     `db:"calls"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/usage/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/usage/storage/postgres/converter"
+	storageentity "github.com/veleton777/test_work_blum/internal/currency/v1/usage/storage/postgres/entity"
+)
+
+const usageTable = "provider_usage"
+
+type RepoPostgres struct {
+	pgClient *pgxpool.Pool
+	timeout  time.Duration
+}
+
+func NewRepoPostgres(pgClient *pgxpool.Pool, timeout time.Duration) *RepoPostgres {
+	return &RepoPostgres{pgClient: pgClient, timeout: timeout}
+}
+
+// AddCalls increments the calls of the provider in the period.
+func (r *RepoPostgres) AddCalls(ctx context.Context, provider string, periodStart time.Time, calls int64) error {
+	return r.upsert(ctx, provider, periodStart, calls,
+		"ON CONFLICT (provider, period_start) DO UPDATE SET "+
+			"calls = provider_usage.calls + EXCLUDED.calls, updated_at = EXCLUDED.updated_at")
+}
+
+// SetCalls replaces the calls of the provider in the period with the figure reported by the provider.
+func (r *RepoPostgres) SetCalls(ctx context.Context, provider string, periodStart time.Time, calls int64) error {
+	return r.upsert(ctx, provider, periodStart, calls,
+		"ON CONFLICT (provider, period_start) DO UPDATE SET calls = EXCLUDED.calls, updated_at = EXCLUDED.updated_at")
+}
+
+func (r *RepoPostgres) GetUsage(ctx context.Context, provider string, periodStart time.Time) (entity.Usage, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Select("provider", "period_start", "calls", "updated_at").
+		From(usageTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Where(squirrel.Eq{"provider": provider, "period_start": periodStart})
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return entity.Usage{}, errors.Wrap(err, "query to sql")
+	}
+
+	rows, err := r.pgClient.Query(ctx, query, v...)
+	if err != nil {
+		return entity.Usage{}, errors.Wrap(err, "pgx query")
+	}
+
+	usage, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[storageentity.Usage])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Usage{}, currencyentity.ErrEntityNotFound
+		}
+
+		return entity.Usage{}, errors.Wrap(err, "scan resp to struct")
+	}
+
+	return converter.UsageToEntity(usage), nil
+}
+
+func (r *RepoPostgres) upsert(ctx context.Context, provider string, periodStart time.Time, calls int64, onConflict string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	builder := squirrel.Insert(usageTable).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns("provider", "period_start", "calls", "updated_at").
+		Values(provider, periodStart, calls, time.Now().UTC()).
+		Suffix(onConflict)
+
+	query, v, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "query to sql")
+	}
+
+	if _, err = r.pgClient.Exec(ctx, query, v...); err != nil {
+		return errors.Wrap(err, "exec pg query")
+	}
+
+	return nil
+}
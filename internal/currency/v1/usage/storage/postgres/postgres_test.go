@@ -0,0 +1,98 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/config"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/usage/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/usage/storage/postgres"
+	"testing"
+	"time"
+)
+
+type Suite struct {
+	suite.Suite
+	repo      *postgres.RepoPostgres
+	pgxClient *pgxpool.Pool
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) SetupSuite() {
+	ctx := context.Background()
+	conf, err := config.Load()
+	s.Require().NoError(err)
+
+	pgCfg, err := pgxpool.ParseConfig(
+		fmt.Sprintf(
+			"host=%s port=%d dbname=%s user=%s password=%s",
+			conf.PgHost(),
+			conf.PgPort(),
+			conf.PgDB(),
+			conf.PgUser(),
+			conf.PgPassword(),
+		),
+	)
+	s.Require().NoError(err)
+
+	pgClient, err := pgxpool.NewWithConfig(ctx, pgCfg)
+	s.Require().NoError(err)
+
+	err = pgClient.Ping(ctx)
+	s.Require().NoError(err)
+
+	s.repo = postgres.NewRepoPostgres(pgClient, 1*time.Second)
+	s.pgxClient = pgClient
+
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownSuite() {
+	s.clearCollection()
+}
+
+func (s *Suite) TearDownTest() {
+	s.clearCollection()
+}
+
+func (s *Suite) clearCollection() {
+	ctx := context.Background()
+
+	_, err := s.pgxClient.Exec(ctx, "TRUNCATE TABLE provider_usage")
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestAddCalls_SetCalls_GetUsage() {
+	ctx := context.Background()
+
+	start, _ := entity.PeriodMonth.Bounds(time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC))
+
+	s.Require().NoError(s.repo.AddCalls(ctx, "fastforex", start, 3))
+	s.Require().NoError(s.repo.AddCalls(ctx, "fastforex", start, 2))
+
+	res, err := s.repo.GetUsage(ctx, "fastforex", start)
+	s.Require().NoError(err)
+	s.Require().Equal("fastforex", res.Provider)
+	s.Require().True(start.Equal(res.PeriodStart))
+	s.Require().Equal(int64(5), res.Calls)
+
+	s.Require().NoError(s.repo.SetCalls(ctx, "fastforex", start, 42))
+
+	res, err = s.repo.GetUsage(ctx, "fastforex", start)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(42), res.Calls)
+}
+
+func (s *Suite) TestGetUsage_ReturnNotFoundErr() {
+	ctx := context.Background()
+
+	_, err := s.repo.GetUsage(ctx, "fastforex", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	s.Require().ErrorIs(err, currencyentity.ErrEntityNotFound)
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CreateAlertReq registers an alert of one of the kinds:
+// * cross - the rate crosses threshold in any direction
+// * change - the rate moves by changePercent or more within window
+type CreateAlertReq struct {
+	From          string `json:"from" validate:"required" example:"BTC"`
+	To            string `json:"to" validate:"required" example:"USD"`
+	Kind          string `json:"kind" validate:"required,oneof=cross change" enums:"cross,change"`
+	Threshold     string `json:"threshold,omitempty" validate:"required_if=Kind cross,omitempty,numeric" example:"70000"`
+	ChangePercent string `json:"changePercent,omitempty" validate:"required_if=Kind change,omitempty,numeric" example:"5"`
+	Window        string `json:"window,omitempty" validate:"required_if=Kind change" example:"1h"`
+	URL           string `json:"url" validate:"required,http_url" example:"https://example.com/webhooks/rates"`
+}
+
+type CreateAlert struct {
+	From          string
+	To            string
+	Kind          string
+	Threshold     decimal.Decimal
+	ChangePercent decimal.Decimal
+	Window        time.Duration
+	URL           string
+}
+
+type Alert struct {
+	ID            uuid.UUID       `json:"id" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	From          string          `json:"from" example:"BTC"`
+	To            string          `json:"to" example:"USD"`
+	Kind          string          `json:"kind" enums:"cross,change"`
+	Threshold     decimal.Decimal `json:"threshold" swaggertype:"string" example:"70000"`
+	ChangePercent decimal.Decimal `json:"changePercent" swaggertype:"string" example:"0"`
+	Window        string          `json:"window,omitempty" example:"1h0m0s"`
+	URL           string          `json:"url" example:"https://example.com/webhooks/rates"`
+	// Secret signs webhooks of the alert, it is returned only when the alert is created
+	Secret          string     `json:"secret,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	LastTriggeredAt *time.Time `json:"lastTriggeredAt,omitempty" example:"2024-06-14T14:00:00Z"`
+	CreatedAt       time.Time  `json:"createdAt" example:"2024-06-14T13:00:00Z"`
+}
+
+type AlertDelivery struct {
+	Attempt    int       `json:"attempt" example:"1"`
+	StatusCode int       `json:"statusCode" example:"200"`
+	Error      string    `json:"error,omitempty" example:"response status not ok"`
+	CreatedAt  time.Time `json:"createdAt" example:"2024-06-14T14:00:00Z"`
+}
+
+// AlertEvent is the webhook body. It is signed with the alert secret, see webhook.Sign.
+type AlertEvent struct {
+	AlertID       uuid.UUID       `json:"alertId"`
+	Kind          string          `json:"kind"`
+	From          string          `json:"from"`
+	To            string          `json:"to"`
+	Rate          decimal.Decimal `json:"rate"`
+	BaseRate      decimal.Decimal `json:"baseRate"`
+	Threshold     decimal.Decimal `json:"threshold"`
+	ChangePercent decimal.Decimal `json:"changePercent"`
+	At            time.Time       `json:"at"`
+}
+
+// RateTick is a new course of the pair fetched from the currencies API.
+type RateTick struct {
+	From string
+	To   string
+	Rate decimal.Decimal
+	At   time.Time
+}
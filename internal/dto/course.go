@@ -1,11 +1,124 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
 type ConvertCurrencyReq struct {
 	From   string  `json:"from" validate:"required" example:"USD"`
 	To     string  `json:"to" validate:"required" example:"BTC"`
 	Amount float64 `json:"amount" validate:"required,gt=0" example:"1"`
+	// At is the moment whose rate is used, the current rate is used when empty
+	At string `json:"at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-06-14T14:00:00Z"`
 }
 
 type ConvertCurrencyResp struct {
-	Course float64 `json:"course" example:"0.00001444655"`
+	// Course is the conversion result, kept for backward compatibility
+	Course float64   `json:"course" example:"0.00001444655"`
+	Rate   float64   `json:"rate" example:"0.00001444655"`
+	Amount float64   `json:"amount" example:"1"`
+	Result float64   `json:"result" example:"0.00001444655"`
+	AsOf   time.Time `json:"asOf" example:"2024-06-14T14:00:00Z"`
+	Source string    `json:"source" example:"fastforex"`
+	// CycleID identifies the rates update cycle which produced the rate
+	CycleID uuid.UUID `json:"cycleId" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	// IsSynthetic is true when the rate is derived through the pivot currency
+	IsSynthetic bool   `json:"isSynthetic" example:"false"`
+	Via         string `json:"via,omitempty" example:"USD"`
+	// IsStale is true when the latest provider update failed and the last known rate is used
+	IsStale bool `json:"isStale" example:"false"`
+	// SpreadPercent, SpreadAmount and Fee are the markup charged from the result, Net is what the client gets
+	SpreadPercent float64 `json:"spreadPercent" example:"0.5"`
+	SpreadAmount  float64 `json:"spreadAmount" example:"0.00000007223"`
+	Fee           float64 `json:"fee" example:"0.000001"`
+	Net           float64 `json:"net" example:"0.00001337432"`
+}
+
+type ConvertCurrencyBatchResp struct {
+	Items []ConvertCurrencyBatchItem `json:"items"`
+}
+
+// ConvertCurrencyBatchItem holds either the conversion result or the error for one request item
+type ConvertCurrencyBatchItem struct {
+	Result *ConvertCurrencyResp     `json:"result,omitempty"`
+	Error  *ConvertCurrencyBatchErr `json:"error,omitempty"`
+}
+
+type ConvertCurrencyBatchErr struct {
+	Text         string `json:"text" example:"Bad Request"`
+	BusinessCode int    `json:"businessCode,omitempty" example:"1"`
+}
+
+type ConvertCurrencyV2Req struct {
+	From   string `json:"from" validate:"required" example:"USD"`
+	To     string `json:"to" validate:"required" example:"BTC"`
+	Amount string `json:"amount" validate:"required,numeric" example:"0.00012345"`
+	// At is the moment whose rate is used, the current rate is used when empty
+	At string `json:"at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-06-14T14:00:00Z"`
+}
+
+type ConvertCurrencyV2Resp struct {
+	Rate   decimal.Decimal `json:"rate" swaggertype:"string" example:"0.00001444655"`
+	Amount decimal.Decimal `json:"amount" swaggertype:"string" example:"1"`
+	Result decimal.Decimal `json:"result" swaggertype:"string" example:"0.00001444655"`
+	AsOf   time.Time       `json:"asOf" example:"2024-06-14T14:00:00Z"`
+	Source string          `json:"source" example:"fastforex"`
+	// CycleID identifies the rates update cycle which produced the rate
+	CycleID uuid.UUID `json:"cycleId" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	// IsSynthetic is true when the rate is derived through the pivot currency
+	IsSynthetic bool   `json:"isSynthetic" example:"false"`
+	Via         string `json:"via,omitempty" example:"USD"`
+	// IsStale is true when the latest provider update failed and the last known rate is used
+	IsStale bool `json:"isStale" example:"false"`
+	// SpreadPercent, SpreadAmount and Fee are the markup charged from the result, Net is what the client gets
+	SpreadPercent decimal.Decimal `json:"spreadPercent" swaggertype:"string" example:"0.5"`
+	SpreadAmount  decimal.Decimal `json:"spreadAmount" swaggertype:"string" example:"0.00000007223"`
+	Fee           decimal.Decimal `json:"fee" swaggertype:"string" example:"0.000001"`
+	Net           decimal.Decimal `json:"net" swaggertype:"string" example:"0.00001337432"`
+}
+
+type Conversion struct {
+	From   string
+	To     string
+	Amount decimal.Decimal
+	// At is set to convert at the rate which was valid at that moment
+	At *time.Time
+}
+
+type ConversionResult struct {
+	// Rate is the mid rate and Result is the amount converted at it before the markup
+	Rate          decimal.Decimal
+	Amount        decimal.Decimal
+	Result        decimal.Decimal
+	AsOf          time.Time
+	Source        string
+	CycleID       uuid.UUID
+	IsSynthetic   bool
+	Via           string
+	IsStale       bool
+	SpreadPercent decimal.Decimal
+	SpreadAmount  decimal.Decimal
+	Fee           decimal.Decimal
+	Net           decimal.Decimal
+	// FixedFee and MinFee are the fees of the applied pricing, quotes lock them with the spread
+	FixedFee decimal.Decimal
+	MinFee   decimal.Decimal
+}
+
+type BatchConversionResult struct {
+	Result ConversionResult
+	Err    error
+}
+
+// UpdateCycleSummary counts the registered pairs by the outcome of the update courses cycle.
+// Skipped pairs are not due yet or have an unavailable currency.
+type UpdateCycleSummary struct {
+	CycleID   uuid.UUID
+	Succeeded int
+	Failed    int
+	Skipped   int
+	Duration  time.Duration
 }
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -12,11 +14,155 @@ type Currency struct {
 	// Type
 	// * 1 - Crypto type
 	// * 2 - Fiat type
-	Type        int  `json:"type" validate:"required" enums:"1,2"`
-	IsAvailable bool `json:"isAvailable" validate:"required"`
+	// * 3 - Stablecoin type
+	// * 4 - Precious metal type
+	// * 5 - Custom index type
+	Type int `json:"type" validate:"required" enums:"1,2,3,4,5"`
+	// IsAvailable is false when absent
+	IsAvailable bool `json:"isAvailable"`
+	// Version is taken from If-Match header
+	Version int64 `json:"-"`
+}
+
+// CurrencyPatch is JSON Merge Patch of the currency, absent fields are left unchanged
+type CurrencyPatch struct {
+	ID   uuid.UUID `json:"-"`
+	Name *string   `json:"name,omitempty" validate:"omitnil,min=1" example:"Bitcoin"`
+	Code *string   `json:"code,omitempty" validate:"omitnil,min=1" example:"BTC"`
+	// Type
+	// * 1 - Crypto type
+	// * 2 - Fiat type
+	// * 3 - Stablecoin type
+	// * 4 - Precious metal type
+	// * 5 - Custom index type
+	Type        *int  `json:"type,omitempty" validate:"omitnil,oneof=1 2 3 4 5" enums:"1,2,3,4,5"`
+	IsAvailable *bool `json:"isAvailable,omitempty" example:"false"`
+	// Version is taken from If-Match header
+	Version int64 `json:"-"`
 }
 
 type CurrencyStorageDTO struct {
 	Course      decimal.Decimal
 	IsAvailable bool
+	IsSynthetic bool
+	Pivot       string
+	FetchedAt   time.Time
+	Provider    string
+	CycleID     uuid.UUID
+	// LastError is set when the latest update failed and Course is the last known value
+	LastError string
+	// Sources is how many providers agreed on the course
+	Sources int
+}
+
+type CurrencyPair struct {
+	From string
+	To   string
+}
+
+type ListCurrenciesQuery struct {
+	// Type
+	// * 1 - Crypto type
+	// * 2 - Fiat type
+	// * 3 - Stablecoin type
+	// * 4 - Precious metal type
+	// * 5 - Custom index type
+	Type        int    `json:"type" validate:"omitempty,oneof=1 2 3 4 5" enums:"1,2,3,4,5"`
+	IsAvailable string `json:"isAvailable" validate:"omitempty,boolean" example:"true"`
+	// Search is a case-insensitive part of the currency name
+	Search string `json:"search" example:"coin"`
+	// Sort by code or name, prefix "-" sorts in descending order
+	Sort   string `json:"sort" validate:"omitempty,oneof=code -code name -name" enums:"code,-code,name,-name"`
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit" validate:"omitempty,min=1,max=200" example:"50"`
+}
+
+type ListCurrenciesReq struct {
+	Type        int
+	IsAvailable *bool
+	Search      string
+	Sort        string
+	Cursor      string
+	Limit       int
+}
+
+type CurrencyResp struct {
+	ID   uuid.UUID `json:"id" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	Name string    `json:"name" example:"Bitcoin"`
+	Code string    `json:"code" example:"BTC"`
+	// Type
+	// * 1 - Crypto type
+	// * 2 - Fiat type
+	// * 3 - Stablecoin type
+	// * 4 - Precious metal type
+	// * 5 - Custom index type
+	Type        int  `json:"type" enums:"1,2,3,4,5"`
+	IsAvailable bool `json:"isAvailable"`
+	// Version is the same as ETag header, it is sent in If-Match header to update or delete the currency
+	Version int64 `json:"version" example:"1"`
+	// DeletedAt is only set in audit records of deleted currencies
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+type CurrenciesPage struct {
+	Currencies []CurrencyResp `json:"currencies"`
+	// NextCursor is passed as cursor to get the next page, it is empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// CurrencyRecord is a currency row of the catalogue import and export
+type CurrencyRecord struct {
+	Code string `json:"code" validate:"required" example:"BTC"`
+	Name string `json:"name" validate:"required" example:"Bitcoin"`
+	// Type
+	// * 1 - Crypto type
+	// * 2 - Fiat type
+	// * 3 - Stablecoin type
+	// * 4 - Precious metal type
+	// * 5 - Custom index type
+	Type        int  `json:"type" validate:"oneof=1 2 3 4 5" enums:"1,2,3,4,5"`
+	IsAvailable bool `json:"isAvailable"`
+}
+
+type ImportCurrenciesQuery struct {
+	Format string `json:"format" validate:"omitempty,oneof=csv json" enums:"csv,json"`
+	// DryRun reports what the import would do without saving it
+	DryRun string `json:"dryRun" validate:"omitempty,boolean" example:"true"`
+}
+
+type ExportCurrenciesQuery struct {
+	Format string `json:"format" validate:"omitempty,oneof=csv json" enums:"csv,json"`
+}
+
+type ImportCurrenciesReq struct {
+	Records []CurrencyRecord
+	DryRun  bool
+}
+
+type ImportReport struct {
+	DryRun    bool `json:"dryRun"`
+	Created   int  `json:"created"`
+	Updated   int  `json:"updated"`
+	Unchanged int  `json:"unchanged"`
+	// Errors of the rows, nothing is saved when there are any
+	Errors []ImportRowError `json:"errors,omitempty"`
+}
+
+type ImportRowError struct {
+	// Row is the number of the record starting from 1, csv header is not counted
+	Row   int    `json:"row" example:"1"`
+	Code  string `json:"code,omitempty" example:"BTC"`
+	Error string `json:"error"`
+}
+
+// CurrencyAuditRecord is a change of the currency, before is null for the created
+// currency and after is null for the purged one
+type CurrencyAuditRecord struct {
+	ID        uuid.UUID     `json:"id" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	Action    string        `json:"action" enums:"create,update,delete,restore,purge"`
+	Actor     string        `json:"actor" example:"admin"`
+	RequestID string        `json:"requestId" example:"0e7c35d1-2c1d-4b7a-9a57-5f0c7f3ea8b2"`
+	Before    *CurrencyResp `json:"before"`
+	After     *CurrencyResp `json:"after"`
+	CreatedAt time.Time     `json:"createdAt"`
 }
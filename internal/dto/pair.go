@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PairReq registers a currency pair fetched by the courses updater.
+type PairReq struct {
+	From string `json:"from" validate:"required" example:"BTC"`
+	To   string `json:"to" validate:"required" example:"USD"`
+	// Enabled pairs are fetched, a new pair is enabled when it is not set
+	Enabled *bool `json:"enabled,omitempty" example:"true"`
+	// RefreshInterval is the minimal time between fetches, the pair is fetched every update cycle when it is not set
+	RefreshInterval string `json:"refreshInterval,omitempty" example:"5m"`
+	// Provider is the preferred courses provider, the default one is used when it is not set
+	Provider string `json:"provider,omitempty" validate:"omitempty,max=32" example:"fastforex"`
+}
+
+type SavePair struct {
+	ID              uuid.UUID
+	From            string
+	To              string
+	Enabled         bool
+	RefreshInterval time.Duration
+	Provider        string
+}
+
+type Pair struct {
+	ID              uuid.UUID `json:"id" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	From            string    `json:"from" example:"BTC"`
+	To              string    `json:"to" example:"USD"`
+	Enabled         bool      `json:"enabled" example:"true"`
+	RefreshInterval string    `json:"refreshInterval,omitempty" example:"5m0s"`
+	Provider        string    `json:"provider,omitempty" example:"fastforex"`
+	CreatedAt       time.Time `json:"createdAt" example:"2024-06-14T13:00:00Z"`
+}
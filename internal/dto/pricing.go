@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PairPricing is set either for currency codes (from, to) or for currency types (fromType, toType).
+// Fees are charged in the target currency.
+type PairPricing struct {
+	ID   uuid.UUID `json:"id" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	From string    `json:"from,omitempty" example:"USD"`
+	To   string    `json:"to,omitempty" example:"BTC"`
+	// FromType and ToType
+	// * 1 - Crypto type
+	// * 2 - Fiat type
+	// * 3 - Stablecoin type
+	// * 4 - Precious metal type
+	// * 5 - Custom index type
+	FromType      int             `json:"fromType,omitempty" enums:"1,2,3,4,5"`
+	ToType        int             `json:"toType,omitempty" enums:"1,2,3,4,5"`
+	SpreadPercent decimal.Decimal `json:"spreadPercent" swaggertype:"string" example:"0.5"`
+	FixedFee      decimal.Decimal `json:"fixedFee" swaggertype:"string" example:"0.000001"`
+	MinFee        decimal.Decimal `json:"minFee" swaggertype:"string" example:"0.00001"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+// ProviderStatus is the courses provider in priority order with the state of its circuit breaker.
+type ProviderStatus struct {
+	Name string `json:"name" example:"fastforex"`
+	// BreakerState is closed, open or half-open, it is empty when the provider has no breaker
+	BreakerState string `json:"breakerState,omitempty" example:"closed"`
+	// BudgetExhausted is set when the provider calls budget is spent until the end of the period
+	BudgetExhausted bool `json:"budgetExhausted,omitempty" example:"false"`
+}
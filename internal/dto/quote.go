@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type CreateQuoteReq struct {
+	From string `json:"from" validate:"required" example:"USD"`
+	To   string `json:"to" validate:"required" example:"BTC"`
+}
+
+type QuoteResp struct {
+	ID     uuid.UUID       `json:"id" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	From   string          `json:"from" example:"USD"`
+	To     string          `json:"to" example:"BTC"`
+	Rate   decimal.Decimal `json:"rate" swaggertype:"string" example:"0.00001444655"`
+	Source string          `json:"source" example:"fastforex"`
+	// SpreadPercent, FixedFee and MinFee are the markup locked with the rate
+	SpreadPercent decimal.Decimal `json:"spreadPercent" swaggertype:"string" example:"0.5"`
+	FixedFee      decimal.Decimal `json:"fixedFee" swaggertype:"string" example:"0.000001"`
+	MinFee        decimal.Decimal `json:"minFee" swaggertype:"string" example:"0"`
+	ExpiresAt     time.Time       `json:"expiresAt" example:"2024-06-14T14:00:30Z"`
+}
+
+type ExecuteQuoteReq struct {
+	Amount string `json:"amount" validate:"required,numeric" example:"100.50"`
+}
+
+type ExecuteQuote struct {
+	ID     uuid.UUID
+	Amount decimal.Decimal
+}
+
+type ExecuteQuoteResp struct {
+	QuoteID uuid.UUID       `json:"quoteId" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	From    string          `json:"from" example:"USD"`
+	To      string          `json:"to" example:"BTC"`
+	Rate    decimal.Decimal `json:"rate" swaggertype:"string" example:"0.00001444655"`
+	Amount  decimal.Decimal `json:"amount" swaggertype:"string" example:"100.50"`
+	Result  decimal.Decimal `json:"result" swaggertype:"string" example:"0.001451878275"`
+	// SpreadPercent, SpreadAmount and Fee are the locked markup charged from the result, Net is what the client gets
+	SpreadPercent decimal.Decimal `json:"spreadPercent" swaggertype:"string" example:"0.5"`
+	SpreadAmount  decimal.Decimal `json:"spreadAmount" swaggertype:"string" example:"0.000007259391375"`
+	Fee           decimal.Decimal `json:"fee" swaggertype:"string" example:"0.000001"`
+	Net           decimal.Decimal `json:"net" swaggertype:"string" example:"0.001443618883625"`
+	ExecutedAt    time.Time       `json:"executedAt" example:"2024-06-14T14:00:10Z"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type RatesHistoryQuery struct {
+	From  string `json:"from" validate:"required" example:"USD"`
+	To    string `json:"to" validate:"required" example:"BTC"`
+	Start string `json:"start" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2024-06-14T00:00:00Z"`
+	End   string `json:"end" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2024-06-15T00:00:00Z"`
+	// Limit is how many rates are returned at most, 5000 by default
+	Limit int `json:"limit" validate:"omitempty,min=1,max=5000" example:"1000"`
+}
+
+type RatesHistoryReq struct {
+	From  string
+	To    string
+	Start time.Time
+	End   time.Time
+	Limit int
+}
+
+type Rate struct {
+	Rate      decimal.Decimal `json:"rate" swaggertype:"string" example:"0.00001444655"`
+	Source    string          `json:"source" example:"fastforex"`
+	CycleID   uuid.UUID       `json:"cycleId" example:"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"`
+	CreatedAt time.Time       `json:"createdAt" example:"2024-06-14T14:00:00Z"`
+}
+
+type RatesHistoryResp struct {
+	From  string `json:"from" example:"USD"`
+	To    string `json:"to" example:"BTC"`
+	Rates []Rate `json:"rates"`
+	// NextStart is set when the range has more rates than the limit,
+	// the rest of them are returned by the request with this start
+	NextStart *time.Time `json:"nextStart,omitempty" example:"2024-06-14T18:00:00Z"`
+}
+
+type CandlesQuery struct {
+	From string `json:"from" validate:"required" example:"USD"`
+	To   string `json:"to" validate:"required" example:"BTC"`
+	// Interval
+	// * 1m - minute candles
+	// * 1h - hour candles
+	// * 1d - day candles
+	Interval string `json:"interval" validate:"required,oneof=1m 1h 1d" enums:"1m,1h,1d"`
+	Start    string `json:"start" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2024-06-14T00:00:00Z"`
+	End      string `json:"end" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2024-06-15T00:00:00Z"`
+}
+
+type CandlesReq struct {
+	From     string
+	To       string
+	Interval string
+	Start    time.Time
+	End      time.Time
+}
+
+type Candle struct {
+	Start time.Time       `json:"start" example:"2024-06-14T14:00:00Z"`
+	Open  decimal.Decimal `json:"open" swaggertype:"string" example:"0.00001444655"`
+	High  decimal.Decimal `json:"high" swaggertype:"string" example:"0.00001445"`
+	Low   decimal.Decimal `json:"low" swaggertype:"string" example:"0.0000144"`
+	Close decimal.Decimal `json:"close" swaggertype:"string" example:"0.00001444"`
+}
+
+type CandlesResp struct {
+	From     string   `json:"from" example:"USD"`
+	To       string   `json:"to" example:"BTC"`
+	Interval string   `json:"interval" example:"1h"`
+	Candles  []Candle `json:"candles"`
+}
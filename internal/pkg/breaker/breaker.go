@@ -0,0 +1,110 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type State string
+
+const (
+	// StateClosed lets every request through
+	StateClosed State = "closed"
+	// StateOpen rejects requests until the cooldown passes
+	StateOpen State = "open"
+	// StateHalfOpen lets one request through to probe the service and rejects the rest until
+	// the probe is resolved, its failure opens the breaker again
+	StateHalfOpen State = "half-open"
+)
+
+// Breaker opens after the number of consecutive failures reaches the threshold.
+// The breaker with zero threshold never opens on failures, it is opened only by Trip.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	// openFor is the cooldown of the current opening, Trip may ask for a longer one
+	openFor time.Duration
+}
+
+func New(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     StateClosed,
+	}
+}
+
+// Allow reports whether the request may be sent. The open breaker turns half-open
+// when the cooldown has passed and lets the first request through as the probe;
+// every allowed request must be resolved by Success, Failure or Trip.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.openFor {
+			return false
+		}
+
+		b.state = StateHalfOpen
+
+		return true
+	case StateHalfOpen:
+		// the probe is in flight
+		return false
+	}
+
+	return true
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.state == StateHalfOpen || (b.threshold > 0 && b.failures >= b.threshold) {
+		b.open(b.cooldown)
+	}
+}
+
+// Trip opens the breaker at once for the cooldown or for d when it is longer,
+// it is used when the service itself asks to stay away for a while.
+func (b *Breaker) Trip(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.open(max(b.cooldown, d))
+}
+
+func (b *Breaker) open(d time.Duration) {
+	b.state = StateOpen
+	b.openedAt = time.Now()
+	b.openFor = d
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.openFor {
+		return StateHalfOpen
+	}
+
+	return b.state
+}
@@ -0,0 +1,100 @@
+package breaker_test
+
+import (
+	"github.com/stretchr/testify/require"
+	"github.com/veleton777/test_work_blum/internal/pkg/breaker"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	b := breaker.New(2, 50*time.Millisecond)
+
+	b.Failure()
+	require.True(t, b.Allow())
+	require.Equal(t, breaker.StateClosed, b.State())
+
+	b.Failure()
+	require.False(t, b.Allow())
+	require.Equal(t, breaker.StateOpen, b.State())
+
+	time.Sleep(60 * time.Millisecond)
+
+	require.True(t, b.Allow())
+	require.Equal(t, breaker.StateHalfOpen, b.State())
+
+	b.Failure()
+	require.False(t, b.Allow())
+
+	time.Sleep(60 * time.Millisecond)
+
+	require.True(t, b.Allow())
+
+	b.Success()
+	require.Equal(t, breaker.StateClosed, b.State())
+}
+
+func TestBreaker_ZeroThreshold(t *testing.T) {
+	b := breaker.New(0, time.Minute)
+
+	for range 10 {
+		b.Failure()
+	}
+
+	require.True(t, b.Allow())
+	require.Equal(t, breaker.StateClosed, b.State())
+}
+
+func TestBreaker_Trip(t *testing.T) {
+	b := breaker.New(0, 10*time.Millisecond)
+
+	b.Trip(50 * time.Millisecond)
+	require.False(t, b.Allow())
+	require.Equal(t, breaker.StateOpen, b.State())
+
+	time.Sleep(20 * time.Millisecond)
+
+	require.False(t, b.Allow())
+
+	time.Sleep(40 * time.Millisecond)
+
+	require.True(t, b.Allow())
+	require.Equal(t, breaker.StateHalfOpen, b.State())
+}
+
+func TestBreaker_HalfOpenSingleProbe(t *testing.T) {
+	b := breaker.New(1, 10*time.Millisecond)
+
+	b.Failure()
+	require.False(t, b.Allow())
+
+	time.Sleep(20 * time.Millisecond)
+
+	var (
+		allowed atomic.Int32
+		wg      sync.WaitGroup
+	)
+
+	for range 50 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if b.Allow() {
+				allowed.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, int32(1), allowed.Load())
+	require.Equal(t, breaker.StateHalfOpen, b.State())
+
+	b.Success()
+	require.True(t, b.Allow())
+	require.True(t, b.Allow())
+}
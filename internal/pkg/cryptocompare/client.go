@@ -0,0 +1,134 @@
+package cryptocompare
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	errInvalidResponse     = errors.New("invalid response")
+	errResponseStatusNotOK = errors.New("response status not ok")
+)
+
+const (
+	providerName = "cryptocompare"
+
+	priceURI      = "data/price"
+	historicalURI = "data/pricehistorical"
+
+	// maxErrorBodySize is how much of the error response body is read into the error message
+	maxErrorBodySize = 4096
+)
+
+type httpClient interface {
+	Do(r *http.Request) (*http.Response, error)
+}
+
+type Client struct {
+	host       string
+	apiKey     string
+	httpClient httpClient
+}
+
+func NewClient(host, apiKey string, httpClient *http.Client) *Client {
+	return &Client{
+		host:       host,
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (c *Client) Name() string {
+	return providerName
+}
+
+// FetchMulti returns prices from the base to the targets in one request,
+// targets missed by the provider are absent in the result.
+func (c *Client) FetchMulti(ctx context.Context, from string, to []string) (map[string]decimal.Decimal, error) {
+	values := make(url.Values)
+	values.Add("fsym", from)
+	values.Add("tsyms", strings.Join(to, ","))
+
+	var result PriceResp
+	if err := c.get(ctx, priceURI, values, &result); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]decimal.Decimal, len(result))
+
+	for code, v := range result {
+		price, err := decimal.NewFromString(v.String())
+		if err != nil {
+			return nil, errors.Wrap(err, "parse result to decimal")
+		}
+
+		res[code] = price
+	}
+
+	return res, nil
+}
+
+// Historical returns the daily close price of the pair for the day of the given moment.
+func (c *Client) Historical(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, error) {
+	values := make(url.Values)
+	values.Add("fsym", from)
+	values.Add("tsyms", to)
+	values.Add("ts", strconv.FormatInt(at.Unix(), 10))
+
+	var result HistoricalResp
+	if err := c.get(ctx, historicalURI, values, &result); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	return parseResult(result[from], to)
+}
+
+// get sends the request. Responses with status other than 200 are returned as StatusError
+// and requests which got no response as RequestError, both unwrap to the error of their kind.
+func (c *Client) get(ctx context.Context, uri string, values url.Values, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+"/"+uri, nil)
+	if err != nil {
+		return errors.Wrap(err, "create http request")
+	}
+
+	req.URL.RawQuery = values.Encode()
+	req.Header.Set("Authorization", "Apikey "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return requestErr(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusErr(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrap(err, "unmarshal json body to struct")
+	}
+
+	return nil
+}
+
+func parseResult(result map[string]json.Number, to string) (decimal.Decimal, error) {
+	v, ok := result[to]
+	if !ok {
+		return decimal.Decimal{}, errInvalidResponse
+	}
+
+	res, err := decimal.NewFromString(v.String())
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, "parse result to decimal")
+	}
+
+	return res, nil
+}
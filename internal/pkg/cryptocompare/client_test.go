@@ -0,0 +1,201 @@
+package cryptocompare_test
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/pkg/cryptocompare"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type ClientSuite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
+
+func (s *ClientSuite) TestFetchMultiMethod() {
+	testCases := []struct {
+		name    string
+		handler func(res http.ResponseWriter, req *http.Request)
+		expRes  map[string]decimal.Decimal
+		expErr  error
+	}{
+		{
+			name: "success",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/data/price" || req.URL.Query().Get("fsym") != "USD" ||
+					req.URL.Query().Get("tsyms") != "BTC,ETH" || req.Header.Get("Authorization") != "Apikey apiKey" {
+					res.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"BTC": 0.00001445, "ETH": 0.0003}`))
+			},
+			expRes: map[string]decimal.Decimal{
+				"BTC": decimal.RequireFromString("0.00001445"),
+				"ETH": decimal.RequireFromString("0.0003"),
+			},
+			expErr: nil,
+		},
+		{
+			name: "missed_target",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"BTC": 0.00001445}`))
+			},
+			expRes: map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.00001445")},
+			expErr: nil,
+		},
+		{
+			name: "invalid_response_status",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusTooManyRequests)
+				res.Write([]byte(`{}`))
+			},
+			expRes: nil,
+			expErr: cryptocompare.ErrQuota,
+		},
+		{
+			name: "auth_err",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusUnauthorized)
+				res.Write([]byte(`{"Response": "Error", "Message": "You need a valid auth key or api key to access this endpoint"}`))
+			},
+			expRes: nil,
+			expErr: cryptocompare.ErrAuth,
+		},
+		{
+			name: "api_err",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"Response": "Error", "Message": "fsym param is invalid"}`))
+			},
+			expRes: nil,
+			expErr: errors.New("unmarshal json body to struct"),
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			testSrv := httptest.NewServer(http.HandlerFunc(c.handler))
+			defer testSrv.Close()
+
+			cl := cryptocompare.NewClient(testSrv.URL, "apiKey", &http.Client{})
+			res, err := cl.FetchMulti(context.Background(), "USD", []string{"BTC", "ETH"})
+
+			require.Equal(t, c.expRes, res)
+
+			if c.expErr != nil {
+				require.ErrorContains(t, err, c.expErr.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func (s *ClientSuite) TestHistoricalMethod() {
+	testCases := []struct {
+		name    string
+		handler func(res http.ResponseWriter, req *http.Request)
+		expRes  decimal.Decimal
+		expErr  error
+	}{
+		{
+			name: "success",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/data/pricehistorical" || req.URL.Query().Get("ts") != "1718379000" {
+					res.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"BTC": {"USD": 66011.1}}`))
+			},
+			expRes: decimal.RequireFromString("66011.1"),
+			expErr: nil,
+		},
+		{
+			name: "invalid_response_text",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"ETH": {"USD": 123}}`))
+			},
+			expRes: decimal.Decimal{},
+			expErr: errors.New("invalid response"),
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			testSrv := httptest.NewServer(http.HandlerFunc(c.handler))
+			defer testSrv.Close()
+
+			ctx := context.Background()
+
+			cl := cryptocompare.NewClient(testSrv.URL, "apiKey", &http.Client{})
+			res, err := cl.Historical(ctx, "BTC", "USD", time.Date(2024, 6, 14, 15, 30, 0, 0, time.UTC))
+
+			require.True(t, c.expRes.Equal(res))
+
+			if c.expErr != nil {
+				require.ErrorContains(t, err, c.expErr.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func (s *ClientSuite) TestStatusErr_Message() {
+	testSrv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadGateway)
+		res.Write([]byte(`{"Response": "Error", "Message": "upstream is down"}`))
+	}))
+	defer testSrv.Close()
+
+	cl := cryptocompare.NewClient(testSrv.URL, "apiKey", &http.Client{})
+	_, err := cl.Historical(context.Background(), "BTC", "USD", time.Now())
+
+	var statusErr *cryptocompare.StatusError
+	s.Require().ErrorAs(err, &statusErr)
+	s.Require().ErrorIs(err, cryptocompare.ErrServer)
+	s.Require().Equal(http.StatusBadGateway, statusErr.StatusCode)
+	s.Require().Equal("upstream is down", statusErr.Message)
+}
+
+func (s *ClientSuite) TestRequestErr_KeepsCause() {
+	cl := cryptocompare.NewClient("http://crypto-compare", "apiKey", &http.Client{
+		Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, context.DeadlineExceeded
+		}),
+	})
+
+	_, err := cl.FetchMulti(context.Background(), "USD", []string{"BTC"})
+	s.Require().ErrorIs(err, cryptocompare.ErrTimeout)
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+
+	var reqErr *cryptocompare.RequestError
+	s.Require().ErrorAs(err, &reqErr)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
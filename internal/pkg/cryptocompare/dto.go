@@ -0,0 +1,15 @@
+package cryptocompare
+
+import "encoding/json"
+
+// PriceResp is the price of one unit of the source currency by target currencies.
+type PriceResp map[string]json.Number
+
+// HistoricalResp is the price by source and target currencies.
+type HistoricalResp map[string]map[string]json.Number
+
+// ErrorResp is the body of the error response.
+type ErrorResp struct {
+	Response string `json:"Response"`
+	Message  string `json:"Message"`
+}
@@ -0,0 +1,102 @@
+package cryptocompare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrAuth        = errors.New("auth failed")
+	ErrQuota       = errors.New("quota exceeded")
+	ErrNotFound    = errors.New("not found")
+	ErrServer      = errors.New("server error")
+	ErrTimeout     = errors.New("request timeout")
+	ErrUnavailable = errors.New("provider unavailable")
+)
+
+// StatusError is the response of the provider with status other than 200.
+// It unwraps to the error of the status kind.
+type StatusError struct {
+	StatusCode int
+	Message    string
+
+	kind error
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %d %s: %s", errResponseStatusNotOK, e.StatusCode, e.kind, e.Message)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.kind
+}
+
+// RequestError is the request which got no response. It unwraps both to the error
+// of its kind and to the cause, so the cause like context.DeadlineExceeded is kept.
+type RequestError struct {
+	Err error
+
+	kind error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("request to crypto compare: %s: %s", e.kind, e.Err)
+}
+
+func (e *RequestError) Unwrap() []error {
+	return []error{e.kind, e.Err}
+}
+
+func statusKind(code int) error {
+	switch {
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return ErrAuth
+	case code == http.StatusTooManyRequests:
+		return ErrQuota
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code >= http.StatusInternalServerError:
+		return ErrServer
+	}
+
+	return errResponseStatusNotOK
+}
+
+// requestErr classifies the error of the request which got no response, cancellation of ctx is kept as is.
+func requestErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return errors.Wrap(err, "request to crypto compare")
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &RequestError{Err: err, kind: ErrTimeout}
+	}
+
+	return &RequestError{Err: err, kind: ErrUnavailable}
+}
+
+func statusErr(resp *http.Response) error {
+	res := &StatusError{
+		StatusCode: resp.StatusCode,
+		kind:       statusKind(resp.StatusCode),
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	var errResp ErrorResp
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
+		res.Message = errResp.Message
+	} else {
+		res.Message = strings.TrimSpace(string(body))
+	}
+
+	return res
+}
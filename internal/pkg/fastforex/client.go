@@ -3,11 +3,19 @@ package fastforex
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/veleton777/test_work_blum/internal/pkg/breaker"
 )
 
 var (
@@ -15,7 +23,20 @@ var (
 	errResponseStatusNotOK = errors.New("response status not ok")
 )
 
-const convertURI = "convert"
+const (
+	providerName = "fastforex"
+
+	historicalURI = "historical"
+	fetchMultiURI = "fetch-multi"
+	fetchAllURI   = "fetch-all"
+	usageURI      = "usage"
+
+	// maxMultiTargets is how many targets are asked with fetch-multi, longer lists are taken from fetch-all
+	maxMultiTargets = 50
+
+	// maxErrorBodySize is how much of the error response body is read into the error message
+	maxErrorBodySize = 4096
+)
 
 type httpClient interface {
 	Do(r *http.Request) (*http.Response, error)
@@ -25,50 +46,329 @@ type Client struct {
 	host       string
 	apiKey     string
 	httpClient httpClient
+	opts       Options
+	breaker    *breaker.Breaker
+
+	// calls is how many requests were sent since the calls were taken last time
+	calls atomic.Int64
+	// fetchCalls is how many of the calls were fetch-multi and fetch-all requests
+	fetchCalls atomic.Int64
+}
+
+// Options of retries and circuit breaker, zero options make one attempt without the breaker.
+type Options struct {
+	// MaxAttempts is how many times a request is sent while it fails with a transient error
+	MaxAttempts int
+	// Backoff is the delay before the second attempt, it doubles after every failed attempt and is jittered
+	Backoff time.Duration
+	// MaxBackoff caps the delay between attempts, the remaining attempts may wait
+	// for Retry-After as long as all of them would wait for MaxBackoff together
+	MaxBackoff time.Duration
+	// BreakerThreshold is how many requests in a row fail with a transient error before the breaker opens
+	BreakerThreshold int
+	// BreakerCooldown is how long the open breaker rejects requests before probing the provider
+	BreakerCooldown time.Duration
 }
 
-func NewClient(host, apiKey string, httpClient *http.Client) *Client {
+func NewClient(host, apiKey string, httpClient *http.Client, opts Options) *Client {
 	return &Client{
 		host:       host,
 		apiKey:     apiKey,
 		httpClient: httpClient,
+		opts:       opts,
+		breaker:    breaker.New(opts.BreakerThreshold, opts.BreakerCooldown),
 	}
 }
 
-func (c *Client) Convert(ctx context.Context, from, to string, amount float64) (float64, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+"/"+convertURI, nil)
-	if err != nil {
-		return 0, errors.Wrap(err, "create http request")
+func (c *Client) Name() string {
+	return providerName
+}
+
+// BreakerState returns the state of the circuit breaker in front of the provider.
+func (c *Client) BreakerState() string {
+	return string(c.breaker.State())
+}
+
+// FetchMulti returns courses from the base to the targets in one request,
+// targets missed by the provider are absent in the result.
+func (c *Client) FetchMulti(ctx context.Context, from string, to []string) (map[string]decimal.Decimal, error) {
+	if len(to) > maxMultiTargets {
+		all, err := c.FetchAll(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+
+		res := make(map[string]decimal.Decimal, len(to))
+
+		for _, code := range to {
+			if v, ok := all[code]; ok {
+				res[code] = v
+			}
+		}
+
+		return res, nil
 	}
 
 	values := make(url.Values)
-	values.Add("api_key", c.apiKey)
+	values.Add("from", from)
+	values.Add("to", strings.Join(to, ","))
+
+	var result FetchMultiResp
+	if err := c.get(ctx, fetchMultiURI, values, &result); err != nil {
+		return nil, err
+	}
+
+	return parseResults(result.Results)
+}
+
+// FetchAll returns courses from the base to every currency known by the provider.
+func (c *Client) FetchAll(ctx context.Context, from string) (map[string]decimal.Decimal, error) {
+	values := make(url.Values)
+	values.Add("from", from)
+
+	var result FetchMultiResp
+	if err := c.get(ctx, fetchAllURI, values, &result); err != nil {
+		return nil, err
+	}
+
+	return parseResults(result.Results)
+}
+
+// Usage returns how many calls the api key made in the current month as counted by the provider.
+func (c *Client) Usage(ctx context.Context) (int64, error) {
+	var result UsageResp
+	if err := c.get(ctx, usageURI, make(url.Values), &result); err != nil {
+		return 0, err
+	}
+
+	return result.Usage.Calls, nil
+}
+
+// TakeCalls returns how many requests were sent to the provider, every attempt
+// is a call, and starts counting again.
+func (c *Client) TakeCalls() int64 {
+	return c.calls.Swap(0)
+}
+
+// TakeFetchCalls returns how many fetch-multi and fetch-all requests were sent,
+// these are the requests of the update cycle, and starts counting them again.
+func (c *Client) TakeFetchCalls() int64 {
+	return c.fetchCalls.Swap(0)
+}
+
+// Historical returns the rate of the pair for the day of the given moment.
+func (c *Client) Historical(ctx context.Context, from, to string, at time.Time) (decimal.Decimal, error) {
+	values := make(url.Values)
+	values.Add("date", at.UTC().Format(time.DateOnly))
 	values.Add("from", from)
 	values.Add("to", to)
-	values.Add("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+
+	var result HistoricalResp
+	if err := c.get(ctx, historicalURI, values, &result); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	return parseResult(result.Results, to)
+}
+
+// get sends the request again while it fails with a transient error and attempts are not over.
+// Requests are rejected with ErrCircuitOpen without being sent while the breaker is open.
+// Every outcome resolves the breaker: the provider answering with a non-transient error
+// is up, while the request given up during the wait counts as failed.
+// When the provider asks to wait longer than the request may take, the quota error is
+// returned at once and the breaker stays open until the asked time passes.
+func (c *Client) get(ctx context.Context, uri string, values url.Values, result any) error {
+	if !c.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+
+	values.Add("api_key", c.apiKey)
+
+	backoff := c.opts.Backoff
+
+	for attempt := 1; ; attempt++ {
+		err := c.do(ctx, uri, values, result)
+
+		switch {
+		case err == nil:
+			c.breaker.Success()
+
+			return nil
+		case !isTransient(err):
+			c.breaker.Success()
+
+			return err
+		}
+
+		delay := c.delay(backoff)
+
+		var statusErr *StatusError
+
+		switch {
+		case errors.As(err, &statusErr) && statusErr.RetryAfter > 0:
+			if attempt >= c.opts.MaxAttempts || !c.canWait(ctx, statusErr.RetryAfter, attempt) {
+				c.breaker.Trip(statusErr.RetryAfter)
+
+				return err
+			}
+
+			delay = statusErr.RetryAfter
+		case attempt >= c.opts.MaxAttempts:
+			c.breaker.Failure()
+
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			c.breaker.Failure()
+
+			return errors.Wrap(ctx.Err(), "wait before retry")
+		case <-time.After(delay):
+		}
+
+		backoff *= 2
+	}
+}
+
+func (c *Client) do(ctx context.Context, uri string, values url.Values, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+"/"+uri, nil)
+	if err != nil {
+		return errors.Wrap(err, "create http request")
+	}
 
 	req.URL.RawQuery = values.Encode()
 
+	c.calls.Add(1)
+
+	if uri == fetchMultiURI || uri == fetchAllURI {
+		c.fetchCalls.Add(1)
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return 0, errors.Wrap(err, "request to fast forex")
+		return requestErr(ctx, err)
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
-		return 0, errResponseStatusNotOK
+		return statusErr(resp)
 	}
 
-	defer resp.Body.Close()
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrap(err, "unmarshal json body to struct")
+	}
+
+	return nil
+}
+
+// delay returns the backoff jittered to the range from its half to the whole.
+func (c *Client) delay(backoff time.Duration) time.Duration {
+	backoff = c.capDelay(backoff)
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + rand.N(backoff/2+1) //nolint:gosec
+}
+
+func (c *Client) capDelay(d time.Duration) time.Duration {
+	if c.opts.MaxBackoff > 0 && d > c.opts.MaxBackoff {
+		return c.opts.MaxBackoff
+	}
+
+	return d
+}
+
+// canWait reports whether waiting for d before the next attempt fits both the deadline
+// of ctx and the time the remaining attempts may wait, MaxBackoff each.
+func (c *Client) canWait(ctx context.Context, d time.Duration, attempt int) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+
+	return c.opts.MaxBackoff <= 0 || d <= time.Duration(c.opts.MaxAttempts-attempt)*c.opts.MaxBackoff
+}
+
+// requestErr classifies the error of the request which got no response, cancellation of ctx is not transient.
+func requestErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return errors.Wrap(err, "request to fast forex")
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &RequestError{Err: err, kind: ErrTimeout}
+	}
+
+	return &RequestError{Err: err, kind: ErrUnavailable}
+}
+
+func statusErr(resp *http.Response) error {
+	res := &StatusError{
+		StatusCode: resp.StatusCode,
+		kind:       statusKind(resp.StatusCode),
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
 
-	var result ConvertResp
-	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, errors.Wrap(err, "unmarshal json body to struct")
+	var errResp ErrorResp
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
+		res.Message = errResp.Error
+	} else {
+		res.Message = strings.TrimSpace(string(body))
 	}
 
-	v, ok := result.Result[to]
+	if resp.StatusCode == http.StatusTooManyRequests {
+		res.RetryAfter = retryAfter(resp.Header.Get("Retry-After"))
+	}
+
+	return res
+}
+
+// retryAfter parses Retry-After given either in seconds or as a date.
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if sec, err := strconv.Atoi(v); err == nil {
+		return time.Duration(sec) * time.Second
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		return time.Until(at)
+	}
+
+	return 0
+}
+
+func parseResult(result map[string]json.Number, to string) (decimal.Decimal, error) {
+	v, ok := result[to]
 	if !ok {
-		return 0, errInvalidResponse
+		return decimal.Decimal{}, errInvalidResponse
+	}
+
+	res, err := decimal.NewFromString(v.String())
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, "parse result to decimal")
+	}
+
+	return res, nil
+}
+
+func parseResults(results map[string]json.Number) (map[string]decimal.Decimal, error) {
+	res := make(map[string]decimal.Decimal, len(results))
+
+	for code, v := range results {
+		course, err := decimal.NewFromString(v.String())
+		if err != nil {
+			return nil, errors.Wrap(err, "parse result to decimal")
+		}
+
+		res[code] = course
 	}
 
-	return v, nil
+	return res, nil
 }
@@ -3,12 +3,15 @@ package fastforex_test
 import (
 	"context"
 	"errors"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/veleton777/test_work_blum/internal/pkg/fastforex"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type ClientSuite struct {
@@ -19,48 +22,143 @@ func TestSuite(t *testing.T) {
 	suite.Run(t, new(ClientSuite))
 }
 
-func (s *ClientSuite) TestConvertMethod() {
+func (s *ClientSuite) TestHistoricalMethod() {
 	testCases := []struct {
 		name    string
 		handler func(res http.ResponseWriter, req *http.Request)
-		expRes  float64
+		expRes  decimal.Decimal
 		expErr  error
 	}{
 		{
 			name: "success",
 			handler: func(res http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/historical" || req.URL.Query().Get("date") != "2024-06-14" {
+					res.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+
 				res.WriteHeader(http.StatusOK)
-				res.Write([]byte(`{"result": {"BTC": 0.00001444655}}`))
+				res.Write([]byte(`{"base": "USD", "results": {"BTC": 0.00001444655}, "date": "2024-06-14"}`))
 			},
-			expRes: 0.00001444655,
+			expRes: decimal.RequireFromString("0.00001444655"),
 			expErr: nil,
 		},
 		{
 			name: "invalid_response_status",
 			handler: func(res http.ResponseWriter, req *http.Request) {
-				res.WriteHeader(http.StatusBadGateway)
+				res.WriteHeader(http.StatusBadRequest)
 				res.Write([]byte(`{}`))
 			},
-			expRes: 0,
+			expRes: decimal.Decimal{},
 			expErr: errors.New("response status not ok"),
 		},
+		{
+			name: "invalid_response_text",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"results": {"ETH": 123}}`))
+			},
+			expRes: decimal.Decimal{},
+			expErr: errors.New("invalid response"),
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			testSrv := httptest.NewServer(http.HandlerFunc(c.handler))
+			defer testSrv.Close()
+
+			ctx := context.Background()
+
+			cl := fastforex.NewClient(testSrv.URL, "apiKey", &http.Client{}, fastforex.Options{})
+			res, err := cl.Historical(ctx, "USD", "BTC", time.Date(2024, 6, 14, 15, 30, 0, 0, time.UTC))
+
+			require.Equal(t, res, c.expRes)
+
+			if c.expErr != nil {
+				require.ErrorContains(t, err, c.expErr.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func (s *ClientSuite) TestFetchMultiMethod() {
+	testCases := []struct {
+		name    string
+		to      []string
+		handler func(res http.ResponseWriter, req *http.Request)
+		expRes  map[string]decimal.Decimal
+		expErr  error
+	}{
+		{
+			name: "success",
+			to:   []string{"BTC", "EUR"},
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/fetch-multi" || req.URL.Query().Get("to") != "BTC,EUR" {
+					res.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"base": "USD", "results": {"BTC": 0.00001444655, "EUR": 0.93}}`))
+			},
+			expRes: map[string]decimal.Decimal{
+				"BTC": decimal.RequireFromString("0.00001444655"),
+				"EUR": decimal.RequireFromString("0.93"),
+			},
+			expErr: nil,
+		},
+		{
+			name: "fetch_all",
+			to:   append(make([]string, 60), "EUR"),
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/fetch-all" || req.URL.Query().Get("from") != "USD" {
+					res.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"base": "USD", "results": {"EUR": 0.93, "GBP": 0.78}}`))
+			},
+			expRes: map[string]decimal.Decimal{"EUR": decimal.RequireFromString("0.93")},
+			expErr: nil,
+		},
+		{
+			name: "success_precision",
+			to:   []string{"BTC"},
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"base": "USD", "results": {"BTC": 0.000000014446550000000001}}`))
+			},
+			expRes: map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.000000014446550000000001")},
+			expErr: nil,
+		},
 		{
 			name: "invalid_response_json",
+			to:   []string{"BTC"},
 			handler: func(res http.ResponseWriter, req *http.Request) {
 				res.WriteHeader(http.StatusOK)
 				res.Write([]byte(`another text`))
 			},
-			expRes: 0,
+			expRes: nil,
 			expErr: errors.New("unmarshal json body to struct"),
 		},
 		{
-			name: "invalid_response_text",
+			name: "invalid_response_status",
+			to:   []string{"BTC"},
 			handler: func(res http.ResponseWriter, req *http.Request) {
-				res.WriteHeader(http.StatusOK)
-				res.Write([]byte(`{"result": {"ETH": 123}}`))
+				res.WriteHeader(http.StatusBadGateway)
+				res.Write([]byte(`{}`))
 			},
-			expRes: 0,
-			expErr: errors.New("invalid response"),
+			expRes: nil,
+			expErr: errors.New("response status not ok"),
 		},
 	}
 
@@ -71,10 +169,10 @@ func (s *ClientSuite) TestConvertMethod() {
 
 			ctx := context.Background()
 
-			cl := fastforex.NewClient(testSrv.URL, "apiKey", &http.Client{})
-			res, err := cl.Convert(ctx, "USD", "BTC", 1)
+			cl := fastforex.NewClient(testSrv.URL, "apiKey", &http.Client{}, fastforex.Options{})
+			res, err := cl.FetchMulti(ctx, "USD", c.to)
 
-			require.Equal(t, res, c.expRes)
+			require.Equal(t, c.expRes, res)
 
 			if c.expErr != nil {
 				require.ErrorContains(t, err, c.expErr.Error())
@@ -86,3 +184,259 @@ func (s *ClientSuite) TestConvertMethod() {
 		})
 	}
 }
+
+func (s *ClientSuite) TestRetry() {
+	testCases := []struct {
+		name        string
+		statuses    []int
+		retryAfter  string
+		expAttempts int32
+		expErr      error
+		expState    string
+	}{
+		{
+			name:        "server_err_then_success",
+			statuses:    []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			expAttempts: 3,
+			expErr:      nil,
+		},
+		{
+			name:        "server_err_attempts_over",
+			statuses:    []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expAttempts: 3,
+			expErr:      fastforex.ErrServer,
+		},
+		{
+			name:        "auth_err_not_retried",
+			statuses:    []int{http.StatusUnauthorized},
+			expAttempts: 1,
+			expErr:      fastforex.ErrAuth,
+		},
+		{
+			name:        "not_found_not_retried",
+			statuses:    []int{http.StatusNotFound},
+			expAttempts: 1,
+			expErr:      fastforex.ErrNotFound,
+		},
+		{
+			name:        "quota_retry_after",
+			statuses:    []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:  "1",
+			expAttempts: 2,
+			expErr:      nil,
+			expState:    "closed",
+		},
+		{
+			name:        "quota_retry_after_over_budget",
+			statuses:    []int{http.StatusTooManyRequests},
+			retryAfter:  "10",
+			expAttempts: 1,
+			expErr:      fastforex.ErrQuota,
+			expState:    "open",
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			testSrv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				status := c.statuses[attempts.Add(1)-1]
+				if status != http.StatusOK {
+					res.Header().Set("Retry-After", c.retryAfter)
+					res.WriteHeader(status)
+					res.Write([]byte(`{"error": "provider message"}`))
+
+					return
+				}
+
+				res.WriteHeader(http.StatusOK)
+				res.Write([]byte(`{"base": "USD", "results": {"BTC": 0.00001444655}}`))
+			}))
+			defer testSrv.Close()
+
+			cl := fastforex.NewClient(testSrv.URL, "apiKey", &http.Client{}, fastforex.Options{
+				MaxAttempts: 3,
+				Backoff:     time.Millisecond,
+				MaxBackoff:  2 * time.Second,
+			})
+
+			start := time.Now()
+			res, err := cl.FetchMulti(context.Background(), "USD", []string{"BTC"})
+
+			require.Equal(t, c.expAttempts, attempts.Load())
+
+			if c.expState != "" {
+				require.Equal(t, c.expState, cl.BreakerState())
+			}
+
+			if c.retryAfter != "" && c.expErr == nil {
+				require.GreaterOrEqual(t, time.Since(start), time.Second)
+			}
+
+			if c.expErr != nil {
+				require.ErrorIs(t, err, c.expErr)
+				require.ErrorContains(t, err, "provider message")
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.00001444655")}, res)
+		})
+	}
+}
+
+func (s *ClientSuite) TestRetryAfter_Deadline() {
+	var attempts atomic.Int32
+
+	testSrv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		attempts.Add(1)
+		res.Header().Set("Retry-After", "1")
+		res.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer testSrv.Close()
+
+	cl := fastforex.NewClient(testSrv.URL, "apiKey", &http.Client{}, fastforex.Options{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := cl.FetchMulti(ctx, "USD", []string{"BTC"})
+
+	s.Require().ErrorIs(err, fastforex.ErrQuota)
+	s.Require().Less(time.Since(start), 500*time.Millisecond)
+	s.Require().Equal(int32(1), attempts.Load())
+
+	_, err = cl.FetchMulti(context.Background(), "USD", []string{"BTC"})
+	s.Require().ErrorIs(err, fastforex.ErrCircuitOpen)
+}
+
+func (s *ClientSuite) TestRequestErr_KeepsCause() {
+	cl := fastforex.NewClient("http://fast-forex", "apiKey", &http.Client{
+		Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, context.DeadlineExceeded
+		}),
+	}, fastforex.Options{MaxAttempts: 1})
+
+	_, err := cl.FetchMulti(context.Background(), "USD", []string{"BTC"})
+	s.Require().ErrorIs(err, fastforex.ErrTimeout)
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+
+	var reqErr *fastforex.RequestError
+	s.Require().ErrorAs(err, &reqErr)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func (s *ClientSuite) TestCircuitBreaker() {
+	var attempts atomic.Int32
+
+	testSrv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		attempts.Add(1)
+		res.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer testSrv.Close()
+
+	cl := fastforex.NewClient(testSrv.URL, "apiKey", &http.Client{}, fastforex.Options{
+		MaxAttempts:      1,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Minute,
+	})
+
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := cl.FetchMulti(ctx, "USD", []string{"BTC"})
+		require.ErrorIs(s.T(), err, fastforex.ErrServer)
+	}
+
+	require.Equal(s.T(), "open", cl.BreakerState())
+
+	_, err := cl.FetchMulti(ctx, "USD", []string{"BTC"})
+	require.ErrorIs(s.T(), err, fastforex.ErrCircuitOpen)
+	require.Equal(s.T(), int32(2), attempts.Load())
+}
+
+func (s *ClientSuite) TestCircuitBreaker_ProbeResolved() {
+	var attempts atomic.Int32
+
+	testSrv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if attempts.Add(1) == 1 {
+			res.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		res.WriteHeader(http.StatusNotFound)
+	}))
+	defer testSrv.Close()
+
+	cl := fastforex.NewClient(testSrv.URL, "apiKey", &http.Client{}, fastforex.Options{
+		MaxAttempts:      1,
+		BreakerThreshold: 1,
+		BreakerCooldown:  10 * time.Millisecond,
+	})
+
+	ctx := context.Background()
+
+	_, err := cl.FetchMulti(ctx, "USD", []string{"BTC"})
+	require.ErrorIs(s.T(), err, fastforex.ErrServer)
+	require.Equal(s.T(), "open", cl.BreakerState())
+
+	time.Sleep(20 * time.Millisecond)
+
+	// the provider answers the probe, so the breaker closes even though the answer is an error
+	_, err = cl.FetchMulti(ctx, "USD", []string{"BTC"})
+	require.ErrorIs(s.T(), err, fastforex.ErrNotFound)
+	require.Equal(s.T(), "closed", cl.BreakerState())
+}
+
+func (s *ClientSuite) TestUsage_TakeCalls() {
+	testSrv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/usage" {
+			res.WriteHeader(http.StatusOK)
+			res.Write([]byte(`{"usage": {"calls": 1234, "limit": 100000}}`))
+
+			return
+		}
+
+		if req.URL.Path == "/fetch-multi" {
+			res.WriteHeader(http.StatusOK)
+			res.Write([]byte(`{"results": {"BTC": 0.00001444655}}`))
+
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+		res.Write([]byte(`{"base": "USD", "results": {"BTC": 0.00001444655}, "date": "2024-06-14"}`))
+	}))
+	defer testSrv.Close()
+
+	cl := fastforex.NewClient(testSrv.URL, "apiKey", &http.Client{}, fastforex.Options{})
+
+	ctx := context.Background()
+
+	_, err := cl.FetchMulti(ctx, "USD", []string{"BTC"})
+	s.Require().NoError(err)
+
+	calls, err := cl.Usage(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1234), calls)
+
+	_, err = cl.Historical(ctx, "USD", "BTC", time.Date(2024, 6, 14, 15, 30, 0, 0, time.UTC))
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(3), cl.TakeCalls())
+	s.Require().Equal(int64(0), cl.TakeCalls())
+	s.Require().Equal(int64(1), cl.TakeFetchCalls())
+	s.Require().Equal(int64(0), cl.TakeFetchCalls())
+}
@@ -1,5 +1,25 @@
 package fastforex
 
-type ConvertResp struct {
-	Result map[string]float64 `json:"result"`
+import "encoding/json"
+
+type HistoricalResp struct {
+	Results map[string]json.Number `json:"results"`
+}
+
+type FetchMultiResp struct {
+	Base    string                 `json:"base"`
+	Results map[string]json.Number `json:"results"`
+}
+
+type ErrorResp struct {
+	Error string `json:"error"`
+}
+
+type UsageResp struct {
+	Usage UsageInfo `json:"usage"`
+}
+
+type UsageInfo struct {
+	Calls int64 `json:"calls"`
+	Limit int64 `json:"limit"`
 }
@@ -0,0 +1,77 @@
+package fastforex
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrAuth        = errors.New("auth failed")
+	ErrQuota       = errors.New("quota exceeded")
+	ErrNotFound    = errors.New("not found")
+	ErrServer      = errors.New("server error")
+	ErrTimeout     = errors.New("request timeout")
+	ErrUnavailable = errors.New("provider unavailable")
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+)
+
+// StatusError is the response of the provider with status other than 200.
+// It unwraps to the error of the status kind.
+type StatusError struct {
+	StatusCode int
+	Message    string
+	// RetryAfter is the delay the provider asked to wait before the next request
+	RetryAfter time.Duration
+
+	kind error
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %d %s: %s", errResponseStatusNotOK, e.StatusCode, e.kind, e.Message)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.kind
+}
+
+// RequestError is the request which got no response. It unwraps both to the error
+// of its kind and to the cause, so the cause like context.DeadlineExceeded is kept.
+type RequestError struct {
+	Err error
+
+	kind error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("request to fast forex: %s: %s", e.kind, e.Err)
+}
+
+func (e *RequestError) Unwrap() []error {
+	return []error{e.kind, e.Err}
+}
+
+func statusKind(code int) error {
+	switch {
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return ErrAuth
+	case code == http.StatusTooManyRequests:
+		return ErrQuota
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code >= http.StatusInternalServerError:
+		return ErrServer
+	}
+
+	return errResponseStatusNotOK
+}
+
+// isTransient reports whether the request may succeed when it is sent again.
+func isTransient(err error) bool {
+	return errors.Is(err, ErrQuota) ||
+		errors.Is(err, ErrServer) ||
+		errors.Is(err, ErrTimeout) ||
+		errors.Is(err, ErrUnavailable)
+}
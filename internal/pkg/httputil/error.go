@@ -98,3 +98,71 @@ func NewCreatedResponse(ctx *fiber.Ctx) error {
 
 	return nil
 }
+
+func NewPreconditionFailedErr(ctx *fiber.Ctx) error {
+	resp := HTTPError{
+		Code:         fiber.StatusPreconditionFailed,
+		Text:         "Precondition Failed",
+		BusinessCode: 0,
+	}
+
+	ctx.Status(fiber.StatusPreconditionFailed)
+
+	if err := ctx.JSON(resp); err != nil {
+		return errors.Wrap(err, "write json resp")
+	}
+
+	return nil
+}
+
+func NewPreconditionRequiredErr(ctx *fiber.Ctx) error {
+	resp := HTTPError{
+		Code:         fiber.StatusPreconditionRequired,
+		Text:         "If-Match header is required",
+		BusinessCode: 0,
+	}
+
+	ctx.Status(fiber.StatusPreconditionRequired)
+
+	if err := ctx.JSON(resp); err != nil {
+		return errors.Wrap(err, "write json resp")
+	}
+
+	return nil
+}
+
+func NewForbiddenErr(ctx *fiber.Ctx) error {
+	resp := HTTPError{
+		Code:         fiber.StatusForbidden,
+		Text:         "Forbidden",
+		BusinessCode: 0,
+	}
+
+	ctx.Status(fiber.StatusForbidden)
+
+	if err := ctx.JSON(resp); err != nil {
+		return errors.Wrap(err, "write json resp")
+	}
+
+	return nil
+}
+
+func NewConflictErr(ctx *fiber.Ctx, msg string) error {
+	resp := HTTPError{
+		Code:         fiber.StatusConflict,
+		Text:         "Conflict",
+		BusinessCode: 0,
+	}
+
+	if msg != "" {
+		resp.Text = msg
+	}
+
+	ctx.Status(fiber.StatusConflict)
+
+	if err := ctx.JSON(resp); err != nil {
+		return errors.Wrap(err, "write json resp")
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package httputil
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrIfMatchRequired = errors.New("if-match header is required")
+	ErrIfMatchInvalid  = errors.New("if-match header does not hold a version")
+)
+
+// ETag returns strong entity tag of the entity version.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// IfMatchVersion returns the entity version from If-Match header made by ETag.
+func IfMatchVersion(ctx *fiber.Ctx) (int64, error) {
+	tag := strings.TrimSpace(ctx.Get(fiber.HeaderIfMatch))
+	if tag == "" {
+		return 0, ErrIfMatchRequired
+	}
+
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, ErrIfMatchInvalid
+	}
+
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrIfMatchInvalid
+	}
+
+	return version, nil
+}
+
+// NewIfMatchErr writes 428 when If-Match header is missing and 412 when it is invalid.
+func NewIfMatchErr(ctx *fiber.Ctx, err error) error {
+	if errors.Is(err, ErrIfMatchRequired) {
+		return NewPreconditionRequiredErr(ctx)
+	}
+
+	return NewPreconditionFailedErr(ctx)
+}
@@ -0,0 +1,52 @@
+package httputil
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/veleton777/test_work_blum/internal/pkg/reqctx"
+)
+
+// HeaderActor holds who makes the request. It is trusted as it is set by the gateway.
+const HeaderActor = "X-Actor"
+
+// HeaderAdminToken holds the token of admin endpoints.
+const HeaderAdminToken = "X-Admin-Token"
+
+const anonymousActor = "anonymous"
+
+// RequestContext puts the actor and the request id into user context of the request.
+// The request id is generated when X-Request-ID header is not sent and is returned in response.
+func RequestContext() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(fiber.HeaderXRequestID)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(fiber.HeaderXRequestID, requestID)
+
+		actor := c.Get(HeaderActor)
+		if actor == "" {
+			actor = anonymousActor
+		}
+
+		ctx := reqctx.WithRequestID(c.UserContext(), requestID)
+		c.SetUserContext(reqctx.WithActor(ctx, actor))
+
+		return c.Next() //nolint:wrapcheck
+	}
+}
+
+// AdminOnly lets through requests with the admin token. Every request is forbidden
+// when the token is not configured.
+func AdminOnly(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" || subtle.ConstantTimeCompare([]byte(c.Get(HeaderAdminToken)), []byte(token)) != 1 {
+			return NewForbiddenErr(c)
+		}
+
+		return c.Next() //nolint:wrapcheck
+	}
+}
@@ -0,0 +1,14 @@
+package pgutil
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+func NumericToDecimal(n pgtype.Numeric) decimal.Decimal {
+	if !n.Valid || n.Int == nil {
+		return decimal.Decimal{}
+	}
+
+	return decimal.NewFromBigInt(n.Int, n.Exp)
+}
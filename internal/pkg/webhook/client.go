@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	errResponseStatusNotOK = errors.New("response status not ok")
+	errInvalidScheme       = errors.New("url scheme must be http or https")
+	errNoHost              = errors.New("url has no host")
+
+	// ErrForbiddenAddress is returned for the targets in loopback, private, link-local and other non-public networks
+	ErrForbiddenAddress = errors.New("webhook address is not public")
+)
+
+const (
+	// HeaderTimestamp holds the unix time the request was signed at
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature holds hex encoded HMAC-SHA256 of "<timestamp>.<body>"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type httpClient interface {
+	Do(r *http.Request) (*http.Response, error)
+}
+
+type resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type Client struct {
+	httpClient httpClient
+	resolver   resolver
+}
+
+// NewClient returns the client sending webhooks with the http client. The client
+// should use the transport from NewTransport, so the addresses are checked again
+// when the connections are made.
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+		resolver:   net.DefaultResolver,
+	}
+}
+
+// NewTransport returns the transport which refuses to connect to non-public
+// addresses. The address is checked after the host is resolved, so a host
+// resolving to another address after the registration is refused as well.
+func NewTransport() *http.Transport {
+	dialer := &net.Dialer{ //nolint:exhaustruct
+		Timeout:   30 * time.Second, //nolint:mnd
+		KeepAlive: 30 * time.Second, //nolint:mnd
+		Control:   dialControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return transport
+}
+
+// Validate checks the url is http or https and every address of its host is public.
+func (c *Client) Validate(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(err, "parse url")
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errInvalidScheme
+	}
+
+	if u.Hostname() == "" {
+		return errNoHost
+	}
+
+	addrs, err := c.resolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return errors.Wrap(err, "resolve host")
+	}
+
+	for _, addr := range addrs {
+		if err = checkIP(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Send posts the signed body to the url once and returns the response status code.
+// Any status other than 2xx is returned as an error.
+func (c *Client) Send(ctx context.Context, url, secret string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrap(err, "create http request")
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, Sign(secret, ts, body))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "request to webhook")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, errResponseStatusNotOK
+	}
+
+	return resp.StatusCode, nil
+}
+
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(err, "split address")
+	}
+
+	return checkIP(net.ParseIP(host))
+}
+
+func checkIP(ip net.IP) error {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return errors.Wrapf(ErrForbiddenAddress, "address %s", ip)
+	}
+
+	return nil
+}
+
+// Sign returns the signature receivers compare with the signature header.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,132 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/pkg/webhook"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ClientSuite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
+
+func (s *ClientSuite) TestSendMethod() {
+	body := []byte(`{"alertId":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"}`)
+
+	testCases := []struct {
+		name    string
+		handler func(res http.ResponseWriter, req *http.Request)
+		expCode int
+		expErr  error
+	}{
+		{
+			name: "success",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				b, _ := io.ReadAll(req.Body)
+
+				ts := req.Header.Get(webhook.HeaderTimestamp)
+				if req.Header.Get(webhook.HeaderSignature) != webhook.Sign("secret", ts, b) {
+					res.WriteHeader(http.StatusUnauthorized)
+
+					return
+				}
+
+				res.WriteHeader(http.StatusNoContent)
+			},
+			expCode: http.StatusNoContent,
+			expErr:  nil,
+		},
+		{
+			name: "invalid_response_status",
+			handler: func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusServiceUnavailable)
+			},
+			expCode: http.StatusServiceUnavailable,
+			expErr:  errors.New("response status not ok"),
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			testSrv := httptest.NewServer(http.HandlerFunc(c.handler))
+			defer testSrv.Close()
+
+			ctx := context.Background()
+
+			cl := webhook.NewClient(&http.Client{})
+			code, err := cl.Send(ctx, testSrv.URL, "secret", body)
+
+			require.Equal(t, c.expCode, code)
+
+			if c.expErr != nil {
+				require.ErrorContains(t, err, c.expErr.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func (s *ClientSuite) TestSign() {
+	require.Equal(
+		s.T(),
+		"5de95064330351ac2d19eabdcd20b1b2886c3a34e219d3b2bbfd60c441bd52d3",
+		webhook.Sign("secret", "1718373600", []byte(`{}`)),
+	)
+}
+
+func (s *ClientSuite) TestValidateMethod() {
+	testCases := []struct {
+		name   string
+		url    string
+		expErr error
+	}{
+		{name: "public", url: "https://93.184.216.34/hook", expErr: nil},
+		{name: "localhost", url: "http://localhost:8080/hook", expErr: webhook.ErrForbiddenAddress},
+		{name: "loopback", url: "http://127.0.0.1/hook", expErr: webhook.ErrForbiddenAddress},
+		{name: "metadata", url: "http://169.254.169.254/latest/meta-data", expErr: webhook.ErrForbiddenAddress},
+		{name: "private", url: "http://10.0.0.1/hook", expErr: webhook.ErrForbiddenAddress},
+		{name: "private_ipv6", url: "http://[fd00::1]/hook", expErr: webhook.ErrForbiddenAddress},
+		{name: "scheme", url: "ftp://93.184.216.34/hook", expErr: errors.New("url scheme must be http or https")},
+	}
+
+	cl := webhook.NewClient(&http.Client{})
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			err := cl.Validate(context.Background(), c.url)
+
+			if c.expErr != nil {
+				require.ErrorContains(t, err, c.expErr.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func (s *ClientSuite) TestSend_TransportRefusesLoopback() {
+	testSrv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer testSrv.Close()
+
+	cl := webhook.NewClient(&http.Client{Transport: webhook.NewTransport()})
+
+	_, err := cl.Send(context.Background(), testSrv.URL, "secret", []byte(`{}`))
+	require.ErrorIs(s.T(), err, webhook.ErrForbiddenAddress)
+}
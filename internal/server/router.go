@@ -3,6 +3,7 @@ package server
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
 )
 
 // @title Swagger Currency API
@@ -13,9 +14,50 @@ import (
 // @BasePath /api
 func (s *API) routes(app *fiber.App) {
 	api := app.Group("api")
+	api.Get("/v1/currencies", s.currencyServer.ListCurrencies)
 	api.Post("/v1/currencies", s.currencyServer.CreateCurrency)
 	api.Put("/v1/currencies/:id", s.currencyServer.UpdateCurrency)
+	api.Patch("/v1/currencies/:id", s.currencyServer.PatchCurrency)
 	api.Delete("/v1/currencies/:id", s.currencyServer.DeleteCurrency)
 
 	api.Get("/v1/currencies/convert", s.currencyServer.Convert)
+	api.Post("/v1/currencies/convert/batch", s.currencyServer.ConvertBatch)
+	api.Post("/v1/currencies/import", s.currencyServer.ImportCurrencies)
+	api.Get("/v1/currencies/export", s.currencyServer.ExportCurrencies)
+
+	// registered after convert and export so that they are not taken as an id
+	api.Get("/v1/currencies/by-code/:code", s.currencyServer.GetCurrencyByCode)
+	api.Get("/v1/currencies/:id", s.currencyServer.GetCurrency)
+	api.Get("/v1/currencies/:id/audit", s.currencyServer.GetCurrencyAudit)
+	api.Post("/v1/currencies/:id/restore", s.currencyServer.RestoreCurrency)
+	api.Delete(
+		"/v1/currencies/:id/purge",
+		httputil.AdminOnly(s.config.HTTPAdminToken()),
+		s.currencyServer.PurgeCurrency,
+	)
+
+	api.Get("/v1/rates/history", s.rateServer.History)
+	api.Get("/v1/rates/candles", s.rateServer.Candles)
+
+	api.Get("/v1/pricing", s.pricingServer.GetPricings)
+	api.Post("/v1/pricing", s.pricingServer.CreatePricing)
+	api.Put("/v1/pricing/:id", s.pricingServer.UpdatePricing)
+	api.Delete("/v1/pricing/:id", s.pricingServer.DeletePricing)
+
+	api.Get("/v1/currency-pairs", s.pairServer.GetPairs)
+	api.Post("/v1/currency-pairs", s.pairServer.CreatePair)
+	api.Put("/v1/currency-pairs/:id", s.pairServer.UpdatePair)
+	api.Delete("/v1/currency-pairs/:id", s.pairServer.DeletePair)
+
+	api.Get("/v1/providers", s.providerServer.GetProviders)
+
+	api.Get("/v1/alerts", s.alertServer.GetAlerts)
+	api.Post("/v1/alerts", s.alertServer.CreateAlert)
+	api.Delete("/v1/alerts/:id", s.alertServer.DeleteAlert)
+	api.Get("/v1/alerts/:id/deliveries", s.alertServer.GetDeliveries)
+
+	api.Post("/v1/quotes", s.quoteServer.CreateQuote)
+	api.Post("/v1/quotes/:id/execute", s.quoteServer.ExecuteQuote)
+
+	api.Get("/v2/currencies/convert", s.currencyV2.Convert)
 }
@@ -10,14 +10,27 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 	"github.com/veleton777/test_work_blum/internal/common"
 	"github.com/veleton777/test_work_blum/internal/config"
 	"github.com/veleton777/test_work_blum/internal/currency/v1"
+	alertpostgres "github.com/veleton777/test_work_blum/internal/currency/v1/alert/storage/postgres"
+	currencyentity "github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/storage/memory"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/storage/postgres"
+	pairpostgres "github.com/veleton777/test_work_blum/internal/currency/v1/pair/storage/postgres"
+	pricingpostgres "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/storage/postgres"
+	quotepostgres "github.com/veleton777/test_work_blum/internal/currency/v1/quote/storage/postgres"
+	ratepostgres "github.com/veleton777/test_work_blum/internal/currency/v1/rate/storage/postgres"
+	usageentity "github.com/veleton777/test_work_blum/internal/currency/v1/usage/entity"
+	usagepostgres "github.com/veleton777/test_work_blum/internal/currency/v1/usage/storage/postgres"
+	"github.com/veleton777/test_work_blum/internal/pkg/cryptocompare"
 	"github.com/veleton777/test_work_blum/internal/pkg/fastforex"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
+	"github.com/veleton777/test_work_blum/internal/pkg/webhook"
 	"github.com/veleton777/test_work_blum/internal/shutdown"
 	v1 "github.com/veleton777/test_work_blum/internal/transport/http/v1"
+	v2 "github.com/veleton777/test_work_blum/internal/transport/http/v2"
 )
 
 type API struct {
@@ -26,7 +39,16 @@ type API struct {
 	l      *zerolog.Logger
 
 	currencyServer *v1.CurrencyServer
+	rateServer     *v1.RateServer
+	quoteServer    *v1.QuoteServer
+	pricingServer  *v1.PricingServer
+	pairServer     *v1.PairServer
+	providerServer *v1.ProviderServer
+	alertServer    *v1.AlertServer
+	currencyV2     *v2.CurrencyServer
 	currencySvc    *currency.Svc
+	usageSvc       *currency.UsageSvc
+	pricingCache   *currency.PricingCache
 }
 
 func New(ctx context.Context, config *config.Config, l *zerolog.Logger) (*API, error) {
@@ -43,23 +65,112 @@ func New(ctx context.Context, config *config.Config, l *zerolog.Logger) (*API, e
 		return nil, errors.Wrap(err, "create pgx client")
 	}
 
-	currencyRepo := postgres.NewRepoPostgres(pgxClient, a.config.PgTimeout())
+	currencyRepo := postgres.NewRepoPostgres(pgxClient, a.config.PgTimeout(), a.config.PgImportTimeout())
+	ratesRepo := ratepostgres.NewRepoPostgres(pgxClient, a.config.PgTimeout())
+	pricingRepo := pricingpostgres.NewRepoPostgres(pgxClient, a.config.PgTimeout())
+	a.pricingCache = currency.NewPricingCache(pricingRepo, l)
+	pairRepo := pairpostgres.NewRepoPostgres(pgxClient, a.config.PgTimeout())
 	courseStorage := memory.NewStorage()
 
-	httpClient := &http.Client{ //nolint:exhaustruct
-		Timeout: a.config.FastForexHTTPTimeout(),
+	pairingRules, err := currencyentity.ParsePairingRules(a.config.CurrencyPairingRules())
+	if err != nil {
+		return nil, errors.Wrap(err, "parse currency pairing rules")
 	}
 
-	fastForexClient := fastforex.NewClient(
+	alertSvc := currency.NewAlertSvc(
+		alertpostgres.NewRepoPostgres(pgxClient, a.config.PgTimeout()),
+		ratesRepo,
+		currencyRepo,
+		pairRepo,
+		webhook.NewClient(&http.Client{ //nolint:exhaustruct
+			Timeout:   a.config.AlertWebhookTimeout(),
+			Transport: webhook.NewTransport(),
+		}),
+		currency.AlertOptions{
+			MaxAttempts:  a.config.AlertWebhookMaxAttempts(),
+			Backoff:      a.config.AlertWebhookBackoff(),
+			PivotCode:    a.config.CurrencyPivot(),
+			PairingRules: pairingRules,
+		},
+		l,
+	)
+
+	sh.AddHiPriority(func(_ context.Context) error {
+		alertSvc.Stop()
+
+		return nil
+	})
+
+	fastForex := fastforex.NewClient(
 		a.config.FastForexAPIHost(),
 		a.config.FastForexAPIKey(),
-		httpClient,
+		&http.Client{ //nolint:exhaustruct
+			Timeout: a.config.FastForexHTTPTimeout(),
+		},
+		fastforex.Options{
+			MaxAttempts:      a.config.FastForexMaxAttempts(),
+			Backoff:          a.config.FastForexRetryBackoff(),
+			MaxBackoff:       a.config.FastForexRetryMaxBackoff(),
+			BreakerThreshold: a.config.FastForexBreakerThreshold(),
+			BreakerCooldown:  a.config.FastForexBreakerCooldown(),
+		},
 	)
 
-	currencySvc := currency.NewCurrencySvc(currencyRepo, fastForexClient, courseStorage, l)
+	budgetPeriod, err := usageentity.ParsePeriod(a.config.FastForexBudgetPeriod())
+	if err != nil {
+		return nil, errors.Wrap(err, "parse fast forex budget period")
+	}
+
+	a.usageSvc = currency.NewUsageSvc(
+		usagepostgres.NewRepoPostgres(pgxClient, a.config.PgTimeout()),
+		fastForex,
+		currency.UsageOptions{
+			Budget:   a.config.FastForexBudget(),
+			Period:   budgetPeriod,
+			Delay:    a.config.FastForexBackgroundTaskDelay(),
+			MaxDelay: a.config.FastForexBudgetMaxDelay(),
+		},
+		l,
+	)
+
+	providers, err := a.providers(l, fastForex)
+	if err != nil {
+		return nil, errors.Wrap(err, "create courses providers")
+	}
+
+	currencySvc := currency.NewCurrencySvc(
+		currencyRepo,
+		providers,
+		courseStorage,
+		ratesRepo,
+		a.pricingCache,
+		pairRepo,
+		alertSvc,
+		currency.Options{
+			PivotCode:     a.config.CurrencyPivot(),
+			StaleMaxAge:   a.config.CurrencyStaleMaxAge(),
+			HistoryMaxGap: a.config.CurrencyHistoryMaxGap(),
+			UpdateWorkers: a.config.CurrencyUpdateWorkers(),
+			CycleTimeout:  a.config.CurrencyCycleTimeout(),
+			PairingRules:  pairingRules,
+		},
+		l,
+	)
 	a.currencySvc = currencySvc
 
 	a.currencyServer = v1.NewCurrencyServer(currencySvc)
+	a.rateServer = v1.NewRateServer(currency.NewRateSvc(ratesRepo))
+	a.currencyV2 = v2.NewCurrencyServer(currencySvc)
+	a.pricingServer = v1.NewPricingServer(currency.NewPricingSvc(a.pricingCache))
+	a.alertServer = v1.NewAlertServer(alertSvc)
+	a.providerServer = v1.NewProviderServer(providers)
+	a.pairServer = v1.NewPairServer(currency.NewPairSvc(pairRepo, currencyRepo, currency.PairOptions{
+		PivotCode:    a.config.CurrencyPivot(),
+		PairingRules: pairingRules,
+	}))
+
+	quoteRepo := quotepostgres.NewRepoPostgres(pgxClient, a.config.PgTimeout())
+	a.quoteServer = v1.NewQuoteServer(currency.NewQuoteSvc(quoteRepo, currencySvc, a.config.CurrencyQuoteTTL()))
 
 	return a, nil
 }
@@ -74,6 +185,7 @@ func (s *API) Run(ctx context.Context) error {
 	app.Use(fiberzerolog.New(fiberzerolog.Config{ //nolint:exhaustruct
 		Logger: s.l,
 	}))
+	app.Use(httputil.RequestContext())
 
 	s.routes(app)
 
@@ -85,11 +197,21 @@ func (s *API) Run(ctx context.Context) error {
 		return nil
 	})
 
-	if err = s.currencySvc.UpdateCourses(ctx); err != nil {
+	if err = s.usageSvc.Reconcile(ctx); err != nil {
+		s.l.Err(err).Msg("reconcile provider usage")
+	}
+
+	if err = s.pricingCache.Refresh(ctx); err != nil {
+		s.l.Err(err).Msg("first load pricings")
+	}
+
+	if err = s.updateCourses(ctx); err != nil {
 		return errors.Wrap(err, "first update courses")
 	}
 
-	go common.BackgroundWorker(ctx, s.config.FastForexBackgroundTaskDelay(), s.l, s.currencySvc.UpdateCourses)
+	go common.AdaptiveWorker(ctx, s.usageSvc.Delay, s.l, s.updateCourses)
+	go common.BackgroundWorker(ctx, s.config.FastForexUsageReconcileInterval(), s.l, s.usageSvc.Reconcile)
+	go common.BackgroundWorker(ctx, s.config.CurrencyPricingRefreshInterval(), s.l, s.pricingCache.Refresh)
 
 	go func() {
 		s.l.Info().Msg("start server")
@@ -104,6 +226,48 @@ func (s *API) Run(ctx context.Context) error {
 	return err
 }
 
+// updateCourses runs the update courses cycle and records the provider calls it made.
+func (s *API) updateCourses(ctx context.Context) error {
+	err := s.currencySvc.UpdateCourses(ctx)
+
+	if recordErr := s.usageSvc.Record(ctx); recordErr != nil {
+		s.l.Err(recordErr).Msg("record provider usage")
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// providers registers the configured courses providers in priority order.
+func (s *API) providers(l *zerolog.Logger, fastForex *fastforex.Client) (*currency.Providers, error) {
+	clients := map[string]currency.CurrenciesAPI{
+		"fastforex": fastForex,
+		"cryptocompare": cryptocompare.NewClient(
+			s.config.CryptoCompareAPIHost(),
+			s.config.CryptoCompareAPIKey(),
+			&http.Client{ //nolint:exhaustruct
+				Timeout: s.config.CryptoCompareHTTPTimeout(),
+			},
+		),
+	}
+
+	apis := make([]currency.CurrenciesAPI, 0, len(s.config.CurrencyProviders()))
+
+	for _, name := range s.config.CurrencyProviders() {
+		api, ok := clients[name]
+		if !ok {
+			return nil, errors.Errorf("unknown courses provider %s", name)
+		}
+
+		apis = append(apis, api)
+	}
+
+	return currency.NewProviders(l, currency.ProvidersOptions{
+		Aggregate: s.config.CurrencyAggregate(),
+		Tolerance: decimal.NewFromFloat(s.config.CurrencyOutlierTolerance()),
+		Budgets:   []currency.BudgetReporter{s.usageSvc},
+	}, apis...), nil
+}
+
 func (s *API) pgxClient(ctx context.Context) (*pgxpool.Pool, error) {
 	pgCfg, err := pgxpool.ParseConfig(
 		fmt.Sprintf(
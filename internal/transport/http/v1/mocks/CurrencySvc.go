@@ -16,27 +16,57 @@ type CurrencySvc struct {
 	mock.Mock
 }
 
-// Convert provides a mock function with given fields: ctx, course
-func (_m *CurrencySvc) Convert(ctx context.Context, course dto.ConvertCurrencyReq) (float64, error) {
-	ret := _m.Called(ctx, course)
+// Convert provides a mock function with given fields: ctx, conversion
+func (_m *CurrencySvc) Convert(ctx context.Context, conversion dto.Conversion) (dto.ConversionResult, error) {
+	ret := _m.Called(ctx, conversion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Convert")
 	}
 
-	var r0 float64
+	var r0 dto.ConversionResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, dto.ConvertCurrencyReq) (float64, error)); ok {
-		return rf(ctx, course)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.Conversion) (dto.ConversionResult, error)); ok {
+		return rf(ctx, conversion)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, dto.ConvertCurrencyReq) float64); ok {
-		r0 = rf(ctx, course)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.Conversion) dto.ConversionResult); ok {
+		r0 = rf(ctx, conversion)
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(dto.ConversionResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, dto.ConvertCurrencyReq) error); ok {
-		r1 = rf(ctx, course)
+	if rf, ok := ret.Get(1).(func(context.Context, dto.Conversion) error); ok {
+		r1 = rf(ctx, conversion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConvertBatch provides a mock function with given fields: ctx, conversions
+func (_m *CurrencySvc) ConvertBatch(ctx context.Context, conversions []dto.Conversion) ([]dto.BatchConversionResult, error) {
+	ret := _m.Called(ctx, conversions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConvertBatch")
+	}
+
+	var r0 []dto.BatchConversionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.Conversion) ([]dto.BatchConversionResult, error)); ok {
+		return rf(ctx, conversions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.Conversion) []dto.BatchConversionResult); ok {
+		r0 = rf(ctx, conversions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.BatchConversionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []dto.Conversion) error); ok {
+		r1 = rf(ctx, conversions)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,14 +92,232 @@ func (_m *CurrencySvc) CreateCurrency(ctx context.Context, currency dto.Currency
 	return r0
 }
 
-// DeleteCurrency provides a mock function with given fields: ctx, id
-func (_m *CurrencySvc) DeleteCurrency(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// DeleteCurrency provides a mock function with given fields: ctx, id, version
+func (_m *CurrencySvc) DeleteCurrency(ctx context.Context, id uuid.UUID, version int64) error {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteCurrency")
 	}
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportCurrencies provides a mock function with given fields: ctx
+func (_m *CurrencySvc) ExportCurrencies(ctx context.Context) ([]dto.CurrencyRecord, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportCurrencies")
+	}
+
+	var r0 []dto.CurrencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dto.CurrencyRecord, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dto.CurrencyRecord); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.CurrencyRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrency provides a mock function with given fields: ctx, id
+func (_m *CurrencySvc) GetCurrency(ctx context.Context, id uuid.UUID) (dto.CurrencyResp, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrency")
+	}
+
+	var r0 dto.CurrencyResp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (dto.CurrencyResp, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) dto.CurrencyResp); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(dto.CurrencyResp)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrencyAudit provides a mock function with given fields: ctx, id
+func (_m *CurrencySvc) GetCurrencyAudit(ctx context.Context, id uuid.UUID) ([]dto.CurrencyAuditRecord, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrencyAudit")
+	}
+
+	var r0 []dto.CurrencyAuditRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]dto.CurrencyAuditRecord, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []dto.CurrencyAuditRecord); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.CurrencyAuditRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrencyByCode provides a mock function with given fields: ctx, code
+func (_m *CurrencySvc) GetCurrencyByCode(ctx context.Context, code string) (dto.CurrencyResp, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrencyByCode")
+	}
+
+	var r0 dto.CurrencyResp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (dto.CurrencyResp, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) dto.CurrencyResp); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(dto.CurrencyResp)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportCurrencies provides a mock function with given fields: ctx, req
+func (_m *CurrencySvc) ImportCurrencies(ctx context.Context, req dto.ImportCurrenciesReq) (dto.ImportReport, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportCurrencies")
+	}
+
+	var r0 dto.ImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ImportCurrenciesReq) (dto.ImportReport, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ImportCurrenciesReq) dto.ImportReport); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.ImportReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ImportCurrenciesReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCurrencies provides a mock function with given fields: ctx, req
+func (_m *CurrencySvc) ListCurrencies(ctx context.Context, req dto.ListCurrenciesReq) (dto.CurrenciesPage, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCurrencies")
+	}
+
+	var r0 dto.CurrenciesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ListCurrenciesReq) (dto.CurrenciesPage, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ListCurrenciesReq) dto.CurrenciesPage); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.CurrenciesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ListCurrenciesReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchCurrency provides a mock function with given fields: ctx, patch
+func (_m *CurrencySvc) PatchCurrency(ctx context.Context, patch dto.CurrencyPatch) (int64, error) {
+	ret := _m.Called(ctx, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchCurrency")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CurrencyPatch) (int64, error)); ok {
+		return rf(ctx, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CurrencyPatch) int64); ok {
+		r0 = rf(ctx, patch)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.CurrencyPatch) error); ok {
+		r1 = rf(ctx, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeCurrency provides a mock function with given fields: ctx, id
+func (_m *CurrencySvc) PurgeCurrency(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeCurrency")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
 		r0 = rf(ctx, id)
@@ -80,22 +328,60 @@ func (_m *CurrencySvc) DeleteCurrency(ctx context.Context, id uuid.UUID) error {
 	return r0
 }
 
+// RestoreCurrency provides a mock function with given fields: ctx, id
+func (_m *CurrencySvc) RestoreCurrency(ctx context.Context, id uuid.UUID) (dto.CurrencyResp, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreCurrency")
+	}
+
+	var r0 dto.CurrencyResp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (dto.CurrencyResp, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) dto.CurrencyResp); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(dto.CurrencyResp)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateCurrency provides a mock function with given fields: ctx, currency
-func (_m *CurrencySvc) UpdateCurrency(ctx context.Context, currency dto.Currency) error {
+func (_m *CurrencySvc) UpdateCurrency(ctx context.Context, currency dto.Currency) (int64, error) {
 	ret := _m.Called(ctx, currency)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateCurrency")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, dto.Currency) error); ok {
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.Currency) (int64, error)); ok {
+		return rf(ctx, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.Currency) int64); ok {
 		r0 = rf(ctx, currency)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, dto.Currency) error); ok {
+		r1 = rf(ctx, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewCurrencySvc creates a new instance of CurrencySvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
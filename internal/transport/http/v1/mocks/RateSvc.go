@@ -0,0 +1,85 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	dto "github.com/veleton777/test_work_blum/internal/dto"
+)
+
+// RateSvc is an autogenerated mock type for the RateSvc type
+type RateSvc struct {
+	mock.Mock
+}
+
+// Candles provides a mock function with given fields: ctx, req
+func (_m *RateSvc) Candles(ctx context.Context, req dto.CandlesReq) (dto.CandlesResp, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Candles")
+	}
+
+	var r0 dto.CandlesResp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CandlesReq) (dto.CandlesResp, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CandlesReq) dto.CandlesResp); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.CandlesResp)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.CandlesReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RatesHistory provides a mock function with given fields: ctx, req
+func (_m *RateSvc) RatesHistory(ctx context.Context, req dto.RatesHistoryReq) (dto.RatesHistoryResp, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RatesHistory")
+	}
+
+	var r0 dto.RatesHistoryResp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.RatesHistoryReq) (dto.RatesHistoryResp, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.RatesHistoryReq) dto.RatesHistoryResp); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.RatesHistoryResp)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.RatesHistoryReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRateSvc creates a new instance of RateSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateSvc {
+	mock := &RateSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
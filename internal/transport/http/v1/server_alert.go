@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	alertentity "github.com/veleton777/test_work_blum/internal/currency/v1/alert/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
+)
+
+var (
+	errInvalidThreshold     = errors.New("invalid threshold format")
+	errInvalidChangePercent = errors.New("invalid change percent format")
+	errInvalidWindow        = errors.New("invalid window format")
+)
+
+type AlertServer struct {
+	alertSvc  AlertSvc
+	validator *validator.Validate
+}
+
+//go:generate mockery --name AlertSvc
+type AlertSvc interface {
+	CreateAlert(ctx context.Context, req dto.CreateAlert) (dto.Alert, error)
+	GetAlerts(ctx context.Context) ([]dto.Alert, error)
+	DeleteAlert(ctx context.Context, id uuid.UUID) error
+	GetDeliveries(ctx context.Context, alertID uuid.UUID) ([]dto.AlertDelivery, error)
+}
+
+func NewAlertServer(alertSvc AlertSvc) *AlertServer {
+	return &AlertServer{
+		alertSvc:  alertSvc,
+		validator: validator.New(),
+	}
+}
+
+// CreateAlert godoc
+//
+//	@Summary		Create alert
+//	@Description	Register alert on the rate of currency pair. Triggered alerts are posted to url
+//	@Description	signed with HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>" in X-Webhook-Signature header.
+//	@Description	The secret is returned only in this response. The url must resolve to a public address
+//	@Description	Alerts are set only on pairs fetched directly, synthetic pairs are rejected
+//	@Tags			alert
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		dto.CreateAlertReq	true	"CreateAlertReq"
+//	@Success		201		{object}  dto.Alert
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v1/alerts [post]
+func (s *AlertServer) CreateAlert(c *fiber.Ctx) error {
+	var req dto.CreateAlertReq
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid json body format") //nolint:wrapcheck
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	alert, err := alertFromReq(req)
+	if err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	res, err := s.alertSvc.CreateAlert(c.UserContext(), alert)
+	if err != nil {
+		return alertErr(c, err)
+	}
+
+	c.Status(fiber.StatusCreated)
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// GetAlerts godoc
+//
+//	@Summary		List alerts
+//	@Description	List registered alerts without their secrets
+//	@Tags			alert
+//	@Produce		json
+//	@Success		200		{array}   dto.Alert
+//	@Failure		500		{object}  httputil.HTTPError
+//	@Router			/v1/alerts [get]
+func (s *AlertServer) GetAlerts(c *fiber.Ctx) error {
+	res, err := s.alertSvc.GetAlerts(c.UserContext())
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// DeleteAlert godoc
+//
+//	@Summary		Delete alert
+//	@Description	Delete alert with its delivery log
+//	@Tags			alert
+//	@Produce		json
+//	@Param          id   path string  true  "AlertID" Format(uuid)
+//	@Success		204
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Router			/v1/alerts/{id} [delete]
+func (s *AlertServer) DeleteAlert(c *fiber.Ctx) error {
+	alertID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	if err = s.alertSvc.DeleteAlert(c.UserContext(), alertID); err != nil {
+		return alertErr(c, err)
+	}
+
+	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
+}
+
+// GetDeliveries godoc
+//
+//	@Summary		List alert deliveries
+//	@Description	List every attempt to deliver webhooks of the alert
+//	@Tags			alert
+//	@Produce		json
+//	@Param          id   path string  true  "AlertID" Format(uuid)
+//	@Success		200		{array}   dto.AlertDelivery
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		500		{object}  httputil.HTTPError
+//	@Router			/v1/alerts/{id}/deliveries [get]
+func (s *AlertServer) GetDeliveries(c *fiber.Ctx) error {
+	alertID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	res, err := s.alertSvc.GetDeliveries(c.UserContext(), alertID)
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+func alertFromReq(req dto.CreateAlertReq) (dto.CreateAlert, error) {
+	var err error
+
+	res := dto.CreateAlert{
+		From: req.From,
+		To:   req.To,
+		Kind: req.Kind,
+		URL:  req.URL,
+	}
+
+	if req.Threshold != "" {
+		if res.Threshold, err = decimal.NewFromString(req.Threshold); err != nil {
+			return dto.CreateAlert{}, errInvalidThreshold
+		}
+	}
+
+	if req.ChangePercent != "" {
+		if res.ChangePercent, err = decimal.NewFromString(req.ChangePercent); err != nil {
+			return dto.CreateAlert{}, errInvalidChangePercent
+		}
+	}
+
+	if req.Window != "" {
+		if res.Window, err = time.ParseDuration(req.Window); err != nil {
+			return dto.CreateAlert{}, errInvalidWindow
+		}
+	}
+
+	return res, nil
+}
+
+func alertErr(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, alertentity.ErrInvalidAlertKind), errors.Is(err, alertentity.ErrInvalidAlertValue),
+		errors.Is(err, alertentity.ErrInvalidWebhookURL), errors.Is(err, alertentity.ErrAlertPairNotFetched):
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	case errors.Is(err, entity.ErrEntityNotFound):
+		return httputil.NewNotFoundErr(c) //nolint:wrapcheck
+	}
+
+	return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+}
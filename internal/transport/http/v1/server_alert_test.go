@@ -0,0 +1,352 @@
+//go:build integration
+
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	alertentity "github.com/veleton777/test_work_blum/internal/currency/v1/alert/entity"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	v1 "github.com/veleton777/test_work_blum/internal/transport/http/v1"
+	"github.com/veleton777/test_work_blum/internal/transport/http/v1/mocks"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ServerAlertSuite struct {
+	suite.Suite
+
+	srv          *v1.AlertServer
+	mockAlertSvc *mocks.AlertSvc
+}
+
+func TestAlertSuite(t *testing.T) {
+	suite.Run(t, new(ServerAlertSuite))
+}
+
+func (s *ServerAlertSuite) SetupSuite() {
+	s.mockAlertSvc = mocks.NewAlertSvc(s.T())
+
+	s.srv = v1.NewAlertServer(s.mockAlertSvc)
+}
+
+func (s *ServerAlertSuite) TestCreateAlert() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			data: `{"from": "BTC", "to": "USD", "kind": "change", "changePercent": "5", "window": "1h",` +
+				` "url": "https://example.com/hook"}`,
+			mockFunc: func() {
+				s.mockAlertSvc.On("CreateAlert", ctx, dto.CreateAlert{
+					From:          "BTC",
+					To:            "USD",
+					Kind:          "change",
+					ChangePercent: decimal.NewFromInt(5),
+					Window:        time.Hour,
+					URL:           "https://example.com/hook",
+				}).Return(dto.Alert{
+					ID:            uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+					From:          "BTC",
+					To:            "USD",
+					Kind:          "change",
+					ChangePercent: decimal.NewFromInt(5),
+					Window:        "1h0m0s",
+					URL:           "https://example.com/hook",
+					Secret:        "secret",
+					CreatedAt:     time.Date(2024, 6, 14, 13, 0, 0, 0, time.UTC),
+				}, nil).Once()
+			},
+			expRes: `{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","from":"BTC","to":"USD","kind":"change",` +
+				`"threshold":"0","changePercent":"5","window":"1h0m0s","url":"https://example.com/hook",` +
+				`"secret":"secret","createdAt":"2024-06-14T13:00:00Z"}`,
+			expCode: 201,
+		},
+		{
+			name:     "invalid_json",
+			data:     `invalid_json`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid json body format"}`,
+			expCode:  400,
+		},
+		{
+			name:     "threshold_required",
+			data:     `{"from": "BTC", "to": "USD", "kind": "cross", "url": "https://example.com/hook"}`,
+			mockFunc: func() {},
+			expRes: `{"code":400,"text":"Key: 'CreateAlertReq.Threshold' Error:` +
+				`Field validation for 'Threshold' failed on the 'required_if' tag"}`,
+			expCode: 400,
+		},
+		{
+			name: "invalid_window",
+			data: `{"from": "BTC", "to": "USD", "kind": "change", "changePercent": "5", "window": "hour",` +
+				` "url": "https://example.com/hook"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid window format"}`,
+			expCode:  400,
+		},
+		{
+			name: "invalid_value",
+			data: `{"from": "BTC", "to": "USD", "kind": "cross", "threshold": "-1", "url": "https://example.com/hook"}`,
+			mockFunc: func() {
+				s.mockAlertSvc.On("CreateAlert", ctx, mock.Anything).
+					Return(dto.Alert{}, alertentity.ErrInvalidAlertValue).Once()
+			},
+			expRes:  `{"code":400,"text":"threshold and change percent must be positive, window must be at least a second"}`,
+			expCode: 400,
+		},
+		{
+			name: "private_webhook_url",
+			data: `{"from": "BTC", "to": "USD", "kind": "cross", "threshold": "70000", "url": "http://10.0.0.1/hook"}`,
+			mockFunc: func() {
+				s.mockAlertSvc.On("CreateAlert", ctx, mock.Anything).
+					Return(dto.Alert{}, fmt.Errorf("address 10.0.0.1: %w", alertentity.ErrInvalidWebhookURL)).Once()
+			},
+			expRes:  `{"code":400,"text":"address 10.0.0.1: webhook url must be http or https url of a public host"}`,
+			expCode: 400,
+		},
+		{
+			name: "pair_not_fetched",
+			data: `{"from": "BTC", "to": "ETH", "kind": "cross", "threshold": "20", "url": "https://example.com/hook"}`,
+			mockFunc: func() {
+				s.mockAlertSvc.On("CreateAlert", ctx, mock.Anything).
+					Return(dto.Alert{}, alertentity.ErrAlertPairNotFetched).Once()
+			},
+			expRes:  `{"code":400,"text":"alerts may be set only on enabled pairs which are fetched directly"}`,
+			expCode: 400,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/", s.srv.CreateAlert)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerAlertSuite) TestGetAlerts() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				s.mockAlertSvc.On("GetAlerts", ctx).
+					Return([]dto.Alert{{
+						ID:        uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+						From:      "BTC",
+						To:        "USD",
+						Kind:      "cross",
+						Threshold: decimal.NewFromInt(70000),
+						URL:       "https://example.com/hook",
+						CreatedAt: time.Date(2024, 6, 14, 13, 0, 0, 0, time.UTC),
+					}}, nil).Once()
+			},
+			expRes: `[{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","from":"BTC","to":"USD","kind":"cross",` +
+				`"threshold":"70000","changePercent":"0","url":"https://example.com/hook",` +
+				`"createdAt":"2024-06-14T13:00:00Z"}]`,
+			expCode: 200,
+		},
+		{
+			name: "svc_err",
+			mockFunc: func() {
+				s.mockAlertSvc.On("GetAlerts", ctx).
+					Return(nil, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", s.srv.GetAlerts)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/", nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerAlertSuite) TestDeleteAlert() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockAlertSvc.On("DeleteAlert", ctx, id).
+					Return(nil).Once()
+			},
+			expRes:  "",
+			expCode: 204,
+		},
+		{
+			name:     "invalid_id",
+			id:       "123",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid id format"}`,
+			expCode:  400,
+		},
+		{
+			name: "not_found",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockAlertSvc.On("DeleteAlert", ctx, id).
+					Return(entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Delete("/:id", s.srv.DeleteAlert)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("DELETE", "/"+c.id, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerAlertSuite) TestGetDeliveries() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockAlertSvc.On("GetDeliveries", ctx, id).
+					Return([]dto.AlertDelivery{
+						{
+							Attempt:    1,
+							StatusCode: 503,
+							Error:      "response status not ok",
+							CreatedAt:  time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC),
+						},
+						{
+							Attempt:    2,
+							StatusCode: 200,
+							CreatedAt:  time.Date(2024, 6, 14, 14, 0, 1, 0, time.UTC),
+						},
+					}, nil).Once()
+			},
+			expRes: `[{"attempt":1,"statusCode":503,"error":"response status not ok","createdAt":"2024-06-14T14:00:00Z"},` +
+				`{"attempt":2,"statusCode":200,"createdAt":"2024-06-14T14:00:01Z"}]`,
+			expCode: 200,
+		},
+		{
+			name:     "invalid_id",
+			id:       "123",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid id format"}`,
+			expCode:  400,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/:id/deliveries", s.srv.GetDeliveries)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/"+c.id+"/deliveries", nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
@@ -3,11 +3,15 @@ package v1
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
 	"github.com/veleton777/test_work_blum/internal/dto"
 	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
@@ -15,6 +19,20 @@ import (
 
 const codeCurrencyNotAllowedForConvert = 1
 
+const maxConvertBatchSize = 10000
+
+const jsonNull = "null"
+
+var errEmptyCurrencyPatch = errors.New("patch has no fields to update")
+
+// patchableCurrencyFields are the json names of dto.CurrencyPatch fields.
+var patchableCurrencyFields = map[string]struct{}{ //nolint:gochecknoglobals
+	"name":        {},
+	"code":        {},
+	"type":        {},
+	"isAvailable": {},
+}
+
 type CurrencyServer struct {
 	currencySvc CurrencySvc
 	validator   *validator.Validate
@@ -23,10 +41,20 @@ type CurrencyServer struct {
 //go:generate mockery --name CurrencySvc
 type CurrencySvc interface {
 	CreateCurrency(ctx context.Context, currency dto.Currency) error
-	UpdateCurrency(ctx context.Context, currency dto.Currency) error
-	DeleteCurrency(ctx context.Context, id uuid.UUID) error
+	UpdateCurrency(ctx context.Context, currency dto.Currency) (int64, error)
+	PatchCurrency(ctx context.Context, patch dto.CurrencyPatch) (int64, error)
+	DeleteCurrency(ctx context.Context, id uuid.UUID, version int64) error
+	RestoreCurrency(ctx context.Context, id uuid.UUID) (dto.CurrencyResp, error)
+	PurgeCurrency(ctx context.Context, id uuid.UUID) error
+	GetCurrency(ctx context.Context, id uuid.UUID) (dto.CurrencyResp, error)
+	GetCurrencyByCode(ctx context.Context, code string) (dto.CurrencyResp, error)
+	ListCurrencies(ctx context.Context, req dto.ListCurrenciesReq) (dto.CurrenciesPage, error)
+	GetCurrencyAudit(ctx context.Context, id uuid.UUID) ([]dto.CurrencyAuditRecord, error)
+	ImportCurrencies(ctx context.Context, req dto.ImportCurrenciesReq) (dto.ImportReport, error)
+	ExportCurrencies(ctx context.Context) ([]dto.CurrencyRecord, error)
 
-	Convert(ctx context.Context, course dto.ConvertCurrencyReq) (float64, error)
+	Convert(ctx context.Context, conversion dto.Conversion) (dto.ConversionResult, error)
+	ConvertBatch(ctx context.Context, conversions []dto.Conversion) ([]dto.BatchConversionResult, error)
 }
 
 func NewCurrencyServer(currencySvc CurrencySvc) *CurrencyServer {
@@ -71,15 +99,18 @@ func (s *CurrencyServer) CreateCurrency(c *fiber.Ctx) error {
 // UpdateCurrency godoc
 //
 //		@Summary		Update currency
-//		@Description	Update currency
+//		@Description	Update currency of the version passed in If-Match header, the new version is returned in ETag header
 //		@Tags			currency
 //		@Accept			json
 //		@Produce		json
 //	    @Param          id   path string  true  "CurrencyID" Format(uuid)
+//	    @Param          If-Match   header string  true  "ETag of the currency"
 //		@Param			payload	body		dto.Currency	true	"UpdateCurrencyDTO"
 //		@Success		204
 //		@Failure		400		{object}  httputil.HTTPError
 //		@Failure		404		{object}  httputil.HTTPError
+//		@Failure		412		{object}  httputil.HTTPError
+//		@Failure		428		{object}  httputil.HTTPError
 //		@Router			/v1/currencies/{id} [put]
 func (s *CurrencyServer) UpdateCurrency(c *fiber.Ctx) error {
 	id := c.Params("id")
@@ -89,6 +120,11 @@ func (s *CurrencyServer) UpdateCurrency(c *fiber.Ctx) error {
 		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
 	}
 
+	version, err := httputil.IfMatchVersion(c)
+	if err != nil {
+		return httputil.NewIfMatchErr(c, err) //nolint:wrapcheck
+	}
+
 	var currency dto.Currency
 	if err = json.Unmarshal(c.Body(), &currency); err != nil {
 		return httputil.NewBadRequestErr(c, "invalid json body format") //nolint:wrapcheck
@@ -99,29 +135,84 @@ func (s *CurrencyServer) UpdateCurrency(c *fiber.Ctx) error {
 	}
 
 	currency.ID = currencyID
+	currency.Version = version
 
-	if err = s.currencySvc.UpdateCurrency(c.UserContext(), currency); err != nil {
-		if errors.Is(err, entity.ErrEntityNotFound) {
-			return httputil.NewNotFoundErr(c) //nolint:wrapcheck
-		}
+	newVersion, err := s.currencySvc.UpdateCurrency(c.UserContext(), currency)
+	if err != nil {
+		return currencyWriteErr(c, err)
+	}
 
-		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	c.Set(fiber.HeaderETag, httputil.ETag(newVersion))
+
+	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
+}
+
+// PatchCurrency godoc
+//
+//	@Summary		Patch currency
+//	@Description	Update supplied fields of the currency of the version passed in If-Match header using JSON Merge Patch,
+//	@Description	the fields can not be removed with null. The new version is returned in ETag header
+//	@Tags			currency
+//	@Accept			json
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param          id   path string  true  "CurrencyID" Format(uuid)
+//	@Param          If-Match   header string  true  "ETag of the currency"
+//	@Param			payload	body		dto.CurrencyPatch	true	"CurrencyPatch"
+//	@Success		204
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Failure		412		{object}  httputil.HTTPError
+//	@Failure		428		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/{id} [patch]
+func (s *CurrencyServer) PatchCurrency(c *fiber.Ctx) error {
+	currencyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	version, err := httputil.IfMatchVersion(c)
+	if err != nil {
+		return httputil.NewIfMatchErr(c, err) //nolint:wrapcheck
+	}
+
+	patch, err := currencyPatchFromBody(c.Body())
+	if err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	if err = s.validator.Struct(patch); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	patch.ID = currencyID
+	patch.Version = version
+
+	newVersion, err := s.currencySvc.PatchCurrency(c.UserContext(), patch)
+	if err != nil {
+		return currencyWriteErr(c, err)
 	}
 
+	c.Set(fiber.HeaderETag, httputil.ETag(newVersion))
+
 	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
 }
 
 // DeleteCurrency godoc
 //
 //	@Summary		Delete currency
-//	@Description	Delete currency
+//	@Description	Delete currency of the version passed in If-Match header. The currency is hidden with its rates kept,
+//	@Description	it can be restored and its code is taken until it is purged
 //	@Tags			currency
 //	@Accept			json
 //	@Produce		json
 //	@Param          id   path string  true  "CurrencyID" Format(uuid)
+//	@Param          If-Match   header string  true  "ETag of the currency"
 //	@Success		204
 //	@Failure		400		{object}  httputil.HTTPError
 //	@Failure		404		{object}  httputil.HTTPError
+//	@Failure		412		{object}  httputil.HTTPError
+//	@Failure		428		{object}  httputil.HTTPError
 //	@Router			/v1/currencies/{id} [delete]
 func (s *CurrencyServer) DeleteCurrency(c *fiber.Ctx) error {
 	id := c.Params("id")
@@ -131,7 +222,85 @@ func (s *CurrencyServer) DeleteCurrency(c *fiber.Ctx) error {
 		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
 	}
 
-	if err = s.currencySvc.DeleteCurrency(c.UserContext(), currencyID); err != nil {
+	version, err := httputil.IfMatchVersion(c)
+	if err != nil {
+		return httputil.NewIfMatchErr(c, err) //nolint:wrapcheck
+	}
+
+	if err = s.currencySvc.DeleteCurrency(c.UserContext(), currencyID, version); err != nil {
+		return currencyWriteErr(c, err)
+	}
+
+	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
+}
+
+// ListCurrencies godoc
+//
+//	@Summary		List currencies
+//	@Description	List currencies filtered by type, availability and name, sorted by code or name.
+//	@Description	Pass nextCursor of the response as cursor to get the next page
+//	@Tags			currency
+//	@Produce		json
+//	@Param			payload	query		dto.ListCurrenciesQuery	false	"ListCurrenciesQuery"
+//	@Success		200		{object}  dto.CurrenciesPage
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v1/currencies [get]
+func (s *CurrencyServer) ListCurrencies(c *fiber.Ctx) error {
+	var query dto.ListCurrenciesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid query params") //nolint:wrapcheck
+	}
+
+	if err := s.validator.Struct(query); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	req := dto.ListCurrenciesReq{
+		Type:   query.Type,
+		Search: query.Search,
+		Sort:   query.Sort,
+		Cursor: query.Cursor,
+		Limit:  query.Limit,
+	}
+
+	if query.IsAvailable != "" {
+		// boolean validation above guarantees the value is parsable
+		isAvailable, _ := strconv.ParseBool(query.IsAvailable)
+		req.IsAvailable = &isAvailable
+	}
+
+	res, err := s.currencySvc.ListCurrencies(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCursor) {
+			return httputil.NewBadRequestErr(c, "invalid cursor") //nolint:wrapcheck
+		}
+
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// GetCurrency godoc
+//
+//	@Summary		Get currency
+//	@Description	Get currency by id
+//	@Tags			currency
+//	@Produce		json
+//	@Param          id   path string  true  "CurrencyID" Format(uuid)
+//	@Success		200		{object}  dto.CurrencyResp
+//	@Header			200		{string}  ETag  "Version of the currency"
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/{id} [get]
+func (s *CurrencyServer) GetCurrency(c *fiber.Ctx) error {
+	currencyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	res, err := s.currencySvc.GetCurrency(c.UserContext(), currencyID)
+	if err != nil {
 		if errors.Is(err, entity.ErrEntityNotFound) {
 			return httputil.NewNotFoundErr(c) //nolint:wrapcheck
 		}
@@ -139,13 +308,123 @@ func (s *CurrencyServer) DeleteCurrency(c *fiber.Ctx) error {
 		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
 	}
 
+	c.Set(fiber.HeaderETag, httputil.ETag(res.Version))
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// RestoreCurrency godoc
+//
+//	@Summary		Restore currency
+//	@Description	Restore deleted currency
+//	@Tags			currency
+//	@Produce		json
+//	@Param          id   path string  true  "CurrencyID" Format(uuid)
+//	@Success		200		{object}  dto.CurrencyResp
+//	@Header			200		{string}  ETag  "Version of the currency"
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Failure		409		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/{id}/restore [post]
+func (s *CurrencyServer) RestoreCurrency(c *fiber.Ctx) error {
+	currencyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	res, err := s.currencySvc.RestoreCurrency(c.UserContext(), currencyID)
+	if err != nil {
+		return currencyWriteErr(c, err)
+	}
+
+	c.Set(fiber.HeaderETag, httputil.ETag(res.Version))
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// PurgeCurrency godoc
+//
+//	@Summary		Purge currency
+//	@Description	Remove deleted currency for good, its audit is kept. Admin only
+//	@Tags			currency
+//	@Produce		json
+//	@Param          id   path string  true  "CurrencyID" Format(uuid)
+//	@Param          X-Admin-Token   header string  true  "Admin token"
+//	@Success		204
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		403		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Failure		409		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/{id}/purge [delete]
+func (s *CurrencyServer) PurgeCurrency(c *fiber.Ctx) error {
+	currencyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	if err = s.currencySvc.PurgeCurrency(c.UserContext(), currencyID); err != nil {
+		return currencyWriteErr(c, err)
+	}
+
 	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
 }
 
+// GetCurrencyAudit godoc
+//
+//	@Summary		Get currency audit
+//	@Description	List changes of the currency from the oldest one with who made them. Actor is taken
+//	@Description	from X-Actor header of the request which made the change, request id from X-Request-ID header
+//	@Tags			currency
+//	@Produce		json
+//	@Param          id   path string  true  "CurrencyID" Format(uuid)
+//	@Success		200		{array}   dto.CurrencyAuditRecord
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		500		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/{id}/audit [get]
+func (s *CurrencyServer) GetCurrencyAudit(c *fiber.Ctx) error {
+	currencyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	res, err := s.currencySvc.GetCurrencyAudit(c.UserContext(), currencyID)
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// GetCurrencyByCode godoc
+//
+//	@Summary		Get currency by code
+//	@Description	Get currency by code
+//	@Tags			currency
+//	@Produce		json
+//	@Param          code   path string  true  "Currency code"
+//	@Success		200		{object}  dto.CurrencyResp
+//	@Header			200		{string}  ETag  "Version of the currency"
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/by-code/{code} [get]
+func (s *CurrencyServer) GetCurrencyByCode(c *fiber.Ctx) error {
+	res, err := s.currencySvc.GetCurrencyByCode(c.UserContext(), c.Params("code"))
+	if err != nil {
+		if errors.Is(err, entity.ErrEntityNotFound) {
+			return httputil.NewNotFoundErr(c) //nolint:wrapcheck
+		}
+
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	c.Set(fiber.HeaderETag, httputil.ETag(res.Version))
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
 // Convert godoc
 //
 //		@Summary		Convert course for currencies
-//		@Description	Convert course for currencies
+//		@Description	Convert course for currencies, with `at` set the rate valid at that moment is used
 //		@Tags			currency
 //		@Accept			json
 //		@Produce		json
@@ -163,12 +442,188 @@ func (s *CurrencyServer) Convert(c *fiber.Ctx) error {
 		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
 	}
 
-	res, err := s.currencySvc.Convert(c.UserContext(), req)
+	at, ok := parseAt(req.At)
+	if !ok {
+		return httputil.NewBadRequestErr(c, "at must not be in the future") //nolint:wrapcheck
+	}
+
+	res, err := s.currencySvc.Convert(c.UserContext(), dto.Conversion{
+		From:   req.From,
+		To:     req.To,
+		Amount: decimal.NewFromFloat(req.Amount),
+		At:     at,
+	})
 	if err != nil {
-		return httputil.NewBusinessErr(c, codeCurrencyNotAllowedForConvert) //nolint:wrapcheck
+		if errors.Is(err, entity.ErrCurrencyNotAvailable) {
+			return httputil.NewBusinessErr(c, codeCurrencyNotAllowedForConvert) //nolint:wrapcheck
+		}
+
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
 	}
 
-	resp := dto.ConvertCurrencyResp{Course: res}
+	return c.JSON(toConvertCurrencyResp(res)) //nolint:wrapcheck
+}
+
+// ConvertBatch godoc
+//
+//	@Summary		Convert many amounts at once
+//	@Description	Convert many amounts using one consistent set of courses, errors are returned per item.
+//	@Description	Invalid items get the validation error in their place, the rest of the batch is converted
+//	@Tags			currency
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		[]dto.ConvertCurrencyReq	true	"ConvertCurrencyReq list"
+//	@Success		200		{object}  dto.ConvertCurrencyBatchResp
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/convert/batch [post]
+func (s *CurrencyServer) ConvertBatch(c *fiber.Ctx) error {
+	var reqs []dto.ConvertCurrencyReq
+	if err := json.Unmarshal(c.Body(), &reqs); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid json body format") //nolint:wrapcheck
+	}
+
+	if len(reqs) == 0 || len(reqs) > maxConvertBatchSize {
+		return httputil.NewBadRequestErr( //nolint:wrapcheck
+			c, fmt.Sprintf("batch must contain from 1 to %d items", maxConvertBatchSize),
+		)
+	}
+
+	resp := dto.ConvertCurrencyBatchResp{
+		Items: make([]dto.ConvertCurrencyBatchItem, len(reqs)),
+	}
+
+	// invalid items get their errors at once, the valid ones are converted together
+	conversions := make([]dto.Conversion, 0, len(reqs))
+	indexes := make([]int, 0, len(reqs))
+
+	for i, req := range reqs {
+		if err := s.validator.Struct(req); err != nil {
+			resp.Items[i].Error = &dto.ConvertCurrencyBatchErr{Text: err.Error()} //nolint:exhaustruct
+
+			continue
+		}
+
+		at, ok := parseAt(req.At)
+		if !ok {
+			resp.Items[i].Error = &dto.ConvertCurrencyBatchErr{Text: "at must not be in the future"} //nolint:exhaustruct
+
+			continue
+		}
+
+		conversions = append(conversions, dto.Conversion{
+			From:   req.From,
+			To:     req.To,
+			Amount: decimal.NewFromFloat(req.Amount),
+			At:     at,
+		})
+		indexes = append(indexes, i)
+	}
+
+	if len(conversions) == 0 {
+		return c.JSON(resp) //nolint:wrapcheck
+	}
+
+	results, err := s.currencySvc.ConvertBatch(c.UserContext(), conversions)
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	for j, r := range results {
+		i := indexes[j]
+
+		if r.Err != nil {
+			resp.Items[i].Error = &dto.ConvertCurrencyBatchErr{
+				Text:         "Bad Request",
+				BusinessCode: codeCurrencyNotAllowedForConvert,
+			}
+
+			continue
+		}
+
+		item := toConvertCurrencyResp(r.Result)
+		resp.Items[i].Result = &item
+	}
 
 	return c.JSON(resp) //nolint:wrapcheck
 }
+
+func toConvertCurrencyResp(res dto.ConversionResult) dto.ConvertCurrencyResp {
+	return dto.ConvertCurrencyResp{
+		Course:        res.Result.InexactFloat64(),
+		Rate:          res.Rate.InexactFloat64(),
+		Amount:        res.Amount.InexactFloat64(),
+		Result:        res.Result.InexactFloat64(),
+		AsOf:          res.AsOf,
+		Source:        res.Source,
+		CycleID:       res.CycleID,
+		IsSynthetic:   res.IsSynthetic,
+		Via:           res.Via,
+		IsStale:       res.IsStale,
+		SpreadPercent: res.SpreadPercent.InexactFloat64(),
+		SpreadAmount:  res.SpreadAmount.InexactFloat64(),
+		Fee:           res.Fee.InexactFloat64(),
+		Net:           res.Net.InexactFloat64(),
+	}
+}
+
+// parseAt returns the moment to convert at, nil means the current rate.
+// The datetime validation guarantees the value is parsable.
+func parseAt(at string) (*time.Time, bool) {
+	if at == "" {
+		return nil, true
+	}
+
+	t, _ := time.Parse(time.RFC3339, at)
+	if t.After(time.Now()) {
+		return nil, false
+	}
+
+	return &t, true
+}
+
+// currencyPatchFromBody parses merge patch document. Unknown fields and nulls are rejected
+// because every field of the currency is required.
+func currencyPatchFromBody(body []byte) (dto.CurrencyPatch, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return dto.CurrencyPatch{}, errors.New("invalid json body format")
+	}
+
+	if len(fields) == 0 {
+		return dto.CurrencyPatch{}, errEmptyCurrencyPatch
+	}
+
+	for name, value := range fields {
+		if _, ok := patchableCurrencyFields[name]; !ok {
+			return dto.CurrencyPatch{}, errors.Errorf("unknown field %s", name)
+		}
+
+		if string(value) == jsonNull {
+			return dto.CurrencyPatch{}, errors.Errorf("field %s can not be null", name)
+		}
+	}
+
+	var patch dto.CurrencyPatch
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return dto.CurrencyPatch{}, errors.New("invalid json body format")
+	}
+
+	return patch, nil
+}
+
+func currencyWriteErr(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, entity.ErrEntityNotFound):
+		return httputil.NewNotFoundErr(c) //nolint:wrapcheck
+	case errors.Is(err, entity.ErrVersionConflict):
+		return httputil.NewPreconditionFailedErr(c) //nolint:wrapcheck
+	case errors.Is(err, entity.ErrCurrencyAlreadyExists):
+		return httputil.NewBadRequestErr(c, "currency already exists") //nolint:wrapcheck
+	case errors.Is(err, entity.ErrInvalidCurrencyType):
+		return httputil.NewBadRequestErr(c, entity.ErrInvalidCurrencyType.Error()) //nolint:wrapcheck
+	case errors.Is(err, entity.ErrCurrencyNotDeleted):
+		return httputil.NewConflictErr(c, entity.ErrCurrencyNotDeleted.Error()) //nolint:wrapcheck
+	}
+
+	return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+}
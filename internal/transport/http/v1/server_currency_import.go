@@ -0,0 +1,246 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
+)
+
+const formatCSV = "csv"
+
+const maxImportRecords = 10000
+
+// currencyCSVHeader is the header of exported csv, import accepts the columns in any order.
+var currencyCSVHeader = []string{"code", "name", "type", "isAvailable"} //nolint:gochecknoglobals
+
+var errTooManyImportRecords = errors.Errorf("import is limited to %d records", maxImportRecords)
+
+// ImportCurrencies godoc
+//
+//	@Summary		Import currencies
+//	@Description	Create or update currencies by code in one transaction, a single invalid row rejects the whole import.
+//	@Description	CSV body has header with code, name, type and isAvailable columns, json body is an array of records
+//	@Tags			currency
+//	@Accept			json
+//	@Accept			text/csv
+//	@Produce		json
+//	@Param			format	query		string	false	"Body format"	Enums(csv, json)
+//	@Param			dryRun	query		bool	false	"Report the changes without saving them"
+//	@Param			payload	body		[]dto.CurrencyRecord	true	"Currencies"
+//	@Success		200		{object}  dto.ImportReport
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		422		{object}  dto.ImportReport
+//	@Failure		500		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/import [post]
+func (s *CurrencyServer) ImportCurrencies(c *fiber.Ctx) error {
+	var query dto.ImportCurrenciesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid query params") //nolint:wrapcheck
+	}
+
+	if err := s.validator.Struct(query); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	// boolean validation above guarantees the value is parsable
+	dryRun, _ := strconv.ParseBool(query.DryRun)
+
+	var (
+		records   []dto.CurrencyRecord
+		parseErrs map[int]string
+		err       error
+	)
+
+	if query.Format == formatCSV {
+		records, parseErrs, err = currencyRecordsFromCSV(c.Body())
+	} else {
+		records, err = currencyRecordsFromJSON(c.Body())
+	}
+
+	if err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	if len(records) > maxImportRecords {
+		return httputil.NewBadRequestErr(c, errTooManyImportRecords.Error()) //nolint:wrapcheck
+	}
+
+	var rowErrs []dto.ImportRowError
+
+	for i, record := range records {
+		if msg, ok := parseErrs[i+1]; ok {
+			rowErrs = append(rowErrs, dto.ImportRowError{Row: i + 1, Code: record.Code, Error: msg})
+
+			continue
+		}
+
+		if err = s.validator.Struct(record); err != nil {
+			rowErrs = append(rowErrs, dto.ImportRowError{Row: i + 1, Code: record.Code, Error: err.Error()})
+		}
+	}
+
+	if len(rowErrs) > 0 {
+		return importReportResponse(c, dto.ImportReport{DryRun: dryRun, Errors: rowErrs})
+	}
+
+	res, err := s.currencySvc.ImportCurrencies(c.UserContext(), dto.ImportCurrenciesReq{
+		Records: records,
+		DryRun:  dryRun,
+	})
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return importReportResponse(c, res)
+}
+
+// ExportCurrencies godoc
+//
+//	@Summary		Export currencies
+//	@Description	Export the whole catalogue ordered by code in the format accepted by import
+//	@Tags			currency
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			format	query		string	false	"Response format"	Enums(csv, json)
+//	@Success		200		{array}   dto.CurrencyRecord
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		500		{object}  httputil.HTTPError
+//	@Router			/v1/currencies/export [get]
+func (s *CurrencyServer) ExportCurrencies(c *fiber.Ctx) error {
+	var query dto.ExportCurrenciesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid query params") //nolint:wrapcheck
+	}
+
+	if err := s.validator.Struct(query); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	records, err := s.currencySvc.ExportCurrencies(c.UserContext())
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	if query.Format != formatCSV {
+		return c.JSON(records) //nolint:wrapcheck
+	}
+
+	body, err := currencyRecordsToCSV(records)
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Attachment("currencies.csv")
+
+	return c.Send(body) //nolint:wrapcheck
+}
+
+func importReportResponse(c *fiber.Ctx, report dto.ImportReport) error {
+	if len(report.Errors) > 0 {
+		c.Status(fiber.StatusUnprocessableEntity)
+	}
+
+	return c.JSON(report) //nolint:wrapcheck
+}
+
+func currencyRecordsFromJSON(body []byte) ([]dto.CurrencyRecord, error) {
+	var records []dto.CurrencyRecord
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, errors.New("invalid json body format")
+	}
+
+	return records, nil
+}
+
+// currencyRecordsFromCSV reads records by the header columns. Rows which can not be read
+// into a record are returned as errors by their numbers and left empty in records.
+func currencyRecordsFromCSV(body []byte) ([]dto.CurrencyRecord, map[int]string, error) {
+	r := csv.NewReader(bytes.NewReader(body))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, errors.New("invalid csv header")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+
+	for _, name := range currencyCSVHeader {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, errors.Errorf("csv header has no %s column", name)
+		}
+	}
+
+	var records []dto.CurrencyRecord
+
+	rowErrs := make(map[int]string)
+
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if errors.Is(err, csv.ErrFieldCount) {
+			records = append(records, dto.CurrencyRecord{})
+			rowErrs[len(records)] = "wrong number of fields"
+
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, errors.New("invalid csv body format")
+		}
+
+		record := dto.CurrencyRecord{
+			Code: row[columns["code"]],
+			Name: row[columns["name"]],
+		}
+
+		if record.Type, err = strconv.Atoi(row[columns["type"]]); err != nil {
+			rowErrs[len(records)+1] = "invalid type format"
+		} else if record.IsAvailable, err = strconv.ParseBool(row[columns["isAvailable"]]); err != nil {
+			rowErrs[len(records)+1] = "invalid isAvailable format"
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rowErrs, nil
+}
+
+func currencyRecordsToCSV(records []dto.CurrencyRecord) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(currencyCSVHeader); err != nil {
+		return nil, errors.Wrap(err, "write csv header")
+	}
+
+	for _, r := range records {
+		err := w.Write([]string{r.Code, r.Name, strconv.Itoa(r.Type), strconv.FormatBool(r.IsAvailable)})
+		if err != nil {
+			return nil, errors.Wrap(err, "write csv row")
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return nil, errors.Wrap(err, "flush csv")
+	}
+
+	return buf.Bytes(), nil
+}
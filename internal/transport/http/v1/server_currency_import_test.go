@@ -0,0 +1,217 @@
+//go:build integration
+
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func (s *ServerCurrencySuite) TestImportCurrencies() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		query    string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "json_success",
+			data: `[{"code": "BTC", "name": "Bitcoin", "type": 1, "isAvailable": false}]`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ImportCurrencies", ctx, dto.ImportCurrenciesReq{
+					Records: []dto.CurrencyRecord{{Code: "BTC", Name: "Bitcoin", Type: 1}},
+				}).
+					Return(dto.ImportReport{Updated: 1}, nil).Once()
+			},
+			expRes:  `{"dryRun":false,"created":0,"updated":1,"unchanged":0}`,
+			expCode: 200,
+		},
+		{
+			name:  "csv_dry_run",
+			query: "?format=csv&dryRun=true",
+			data:  "name,code,type,isAvailable\nBitcoin,BTC,1,true\nDollar,USD,2,false\n",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ImportCurrencies", ctx, dto.ImportCurrenciesReq{
+					Records: []dto.CurrencyRecord{
+						{Code: "BTC", Name: "Bitcoin", Type: 1, IsAvailable: true},
+						{Code: "USD", Name: "Dollar", Type: 2},
+					},
+					DryRun: true,
+				}).
+					Return(dto.ImportReport{DryRun: true, Created: 1, Unchanged: 1}, nil).Once()
+			},
+			expRes:  `{"dryRun":true,"created":1,"updated":0,"unchanged":1}`,
+			expCode: 200,
+		},
+		{
+			name:     "csv_row_errors",
+			query:    "?format=csv",
+			data:     "code,name,type,isAvailable\nBTC,Bitcoin,x,true\nETH,Ethereum,1\nUSD,,2,true\nEUR,Euro,2,yes\n",
+			mockFunc: func() {},
+			expRes: `{"dryRun":false,"created":0,"updated":0,"unchanged":0,"errors":[` +
+				`{"row":1,"code":"BTC","error":"invalid type format"},` +
+				`{"row":2,"error":"wrong number of fields"},` +
+				`{"row":3,"code":"USD","error":"Key: 'CurrencyRecord.Name' Error:Field validation for 'Name' failed on the 'required' tag"},` +
+				`{"row":4,"code":"EUR","error":"invalid isAvailable format"}]}`,
+			expCode: 422,
+		},
+		{
+			name: "svc_row_errors",
+			data: `[{"code": "BTC", "name": "Bitcoin", "type": 1}, {"code": "BTC", "name": "Bitcoin", "type": 1}]`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ImportCurrencies", ctx, dto.ImportCurrenciesReq{
+					Records: []dto.CurrencyRecord{
+						{Code: "BTC", Name: "Bitcoin", Type: 1},
+						{Code: "BTC", Name: "Bitcoin", Type: 1},
+					},
+				}).
+					Return(dto.ImportReport{Errors: []dto.ImportRowError{
+						{Row: 2, Code: "BTC", Error: "duplicate code of row 1"},
+					}}, nil).Once()
+			},
+			expRes: `{"dryRun":false,"created":0,"updated":0,"unchanged":0,"errors":[` +
+				`{"row":2,"code":"BTC","error":"duplicate code of row 1"}]}`,
+			expCode: 422,
+		},
+		{
+			name:     "csv_without_column",
+			query:    "?format=csv",
+			data:     "code,name,type\nBTC,Bitcoin,1\n",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"csv header has no isAvailable column"}`,
+			expCode:  400,
+		},
+		{
+			name:     "invalid_json",
+			data:     `{"code": "BTC"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid json body format"}`,
+			expCode:  400,
+		},
+		{
+			name:     "invalid_format",
+			query:    "?format=xml",
+			data:     `[]`,
+			mockFunc: func() {},
+			expRes: `{"code":400,"text":"Key: 'ImportCurrenciesQuery.Format' Error:Field validation for 'Format' ` +
+				`failed on the 'oneof' tag"}`,
+			expCode: 400,
+		},
+		{
+			name: "svc_err",
+			data: `[{"code": "BTC", "name": "Bitcoin", "type": 1}]`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ImportCurrencies", ctx, dto.ImportCurrenciesReq{
+					Records: []dto.CurrencyRecord{{Code: "BTC", Name: "Bitcoin", Type: 1}},
+				}).
+					Return(dto.ImportReport{}, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/import", s.srv.ImportCurrencies)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("POST", "/import"+c.query, strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerCurrencySuite) TestExportCurrencies() {
+	ctx := context.Background()
+
+	records := []dto.CurrencyRecord{
+		{Code: "BTC", Name: "Bitcoin", Type: 1, IsAvailable: true},
+		{Code: "USD", Name: "US, Dollar", Type: 2},
+	}
+
+	testCases := []struct {
+		name        string
+		query       string
+		mockFunc    func()
+		expRes      string
+		expCode     int
+		contentType string
+	}{
+		{
+			name: "json",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ExportCurrencies", ctx).Return(records, nil).Once()
+			},
+			expRes: `[{"code":"BTC","name":"Bitcoin","type":1,"isAvailable":true},` +
+				`{"code":"USD","name":"US, Dollar","type":2,"isAvailable":false}]`,
+			expCode:     200,
+			contentType: "application/json",
+		},
+		{
+			name:  "csv",
+			query: "?format=csv",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ExportCurrencies", ctx).Return(records, nil).Once()
+			},
+			expRes:      "code,name,type,isAvailable\nBTC,Bitcoin,1,true\nUSD,\"US, Dollar\",2,false\n",
+			expCode:     200,
+			contentType: "text/csv; charset=utf-8",
+		},
+		{
+			name: "svc_err",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ExportCurrencies", ctx).Return(nil, errors.New("")).Once()
+			},
+			expRes:      `{"code":500,"text":"Internal Server error"}`,
+			expCode:     500,
+			contentType: "application/json",
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/export", s.srv.ExportCurrencies)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/export"+c.query, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+			assert.Equal(s.T(), c.contentType, resp.Header.Get("Content-Type"))
+		})
+	}
+}
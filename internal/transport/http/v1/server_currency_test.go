@@ -7,16 +7,20 @@ import (
 	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
 	v1 "github.com/veleton777/test_work_blum/internal/transport/http/v1"
 	"github.com/veleton777/test_work_blum/internal/transport/http/v1/mocks"
 	"io"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 type ServerCurrencySuite struct {
@@ -121,25 +125,76 @@ func (s *ServerCurrencySuite) TestUpdateCurrency() {
 	testCases := []struct {
 		name     string
 		id       string
+		ifMatch  string
 		data     string
 		mockFunc func()
 		expRes   string
+		expETag  string
 		expCode  int
 	}{
 		{
-			name: "success",
-			id:   uuid.New().String(),
-			data: `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
+			name:    "success",
+			id:      uuid.New().String(),
+			ifMatch: `"3"`,
+			data:    `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
 			mockFunc: func() {
-				s.mockCurrencySvc.On("UpdateCurrency", ctx, mock.Anything).
-					Return(nil).Once()
+				s.mockCurrencySvc.On("UpdateCurrency", ctx, mock.MatchedBy(func(c dto.Currency) bool {
+					return c.Version == 3
+				})).
+					Return(int64(4), nil).Once()
+			},
+			expRes:  "",
+			expETag: `"4"`,
+			expCode: 204,
+		},
+		{
+			name:    "make_unavailable",
+			id:      uuid.New().String(),
+			ifMatch: `"4"`,
+			data:    `{"name": "test", "code": "code", "type": 1, "isAvailable": false}`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("UpdateCurrency", ctx, mock.MatchedBy(func(c dto.Currency) bool {
+					return c.Version == 4 && !c.IsAvailable
+				})).
+					Return(int64(5), nil).Once()
 			},
 			expRes:  "",
+			expETag: `"5"`,
 			expCode: 204,
 		},
+		{
+			name:     "if_match_required",
+			id:       uuid.New().String(),
+			data:     `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
+			mockFunc: func() {},
+			expRes:   `{"code":428,"text":"If-Match header is required"}`,
+			expCode:  428,
+		},
+		{
+			name:     "invalid_if_match",
+			id:       uuid.New().String(),
+			ifMatch:  `W/"3"`,
+			data:     `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
+			mockFunc: func() {},
+			expRes:   `{"code":412,"text":"Precondition Failed"}`,
+			expCode:  412,
+		},
+		{
+			name:    "version_conflict",
+			id:      uuid.New().String(),
+			ifMatch: `"2"`,
+			data:    `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("UpdateCurrency", ctx, mock.Anything).
+					Return(int64(0), entity.ErrVersionConflict).Once()
+			},
+			expRes:  `{"code":412,"text":"Precondition Failed"}`,
+			expCode: 412,
+		},
 		{
 			name:     "invalid_id",
 			id:       "123",
+			ifMatch:  `"1"`,
 			data:     `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
 			mockFunc: func() {},
 			expRes:   `{"code":400,"text":"invalid id format"}`,
@@ -148,6 +203,7 @@ func (s *ServerCurrencySuite) TestUpdateCurrency() {
 		{
 			name:     "invalid_json",
 			id:       uuid.New().String(),
+			ifMatch:  `"1"`,
 			data:     `invalid_json`,
 			mockFunc: func() {},
 			expRes:   `{"code":400,"text":"invalid json body format"}`,
@@ -156,29 +212,32 @@ func (s *ServerCurrencySuite) TestUpdateCurrency() {
 		{
 			name:     "validation_err",
 			id:       uuid.New().String(),
+			ifMatch:  `"1"`,
 			data:     `{"name": "test", "type": 1, "isAvailable": true}`,
 			mockFunc: func() {},
 			expRes:   `{"code":400,"text":"Key: 'Currency.Code' Error:Field validation for 'Code' failed on the 'required' tag"}`,
 			expCode:  400,
 		},
 		{
-			name: "currency_not_found",
-			id:   uuid.New().String(),
-			data: `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
+			name:    "currency_not_found",
+			id:      uuid.New().String(),
+			ifMatch: `"1"`,
+			data:    `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
 			mockFunc: func() {
 				s.mockCurrencySvc.On("UpdateCurrency", ctx, mock.Anything).
-					Return(entity.ErrEntityNotFound).Once()
+					Return(int64(0), entity.ErrEntityNotFound).Once()
 			},
 			expRes:  `{"code":404,"text":"Not Found"}`,
 			expCode: 404,
 		},
 		{
-			name: "svc_err",
-			id:   uuid.New().String(),
-			data: `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
+			name:    "svc_err",
+			id:      uuid.New().String(),
+			ifMatch: `"1"`,
+			data:    `{"name": "test", "code": "code", "type": 1, "isAvailable": true}`,
 			mockFunc: func() {
 				s.mockCurrencySvc.On("UpdateCurrency", ctx, mock.Anything).
-					Return(errors.New("")).Once()
+					Return(int64(0), errors.New("")).Once()
 			},
 			expRes:  `{"code":500,"text":"Internal Server error"}`,
 			expCode: 500,
@@ -193,6 +252,9 @@ func (s *ServerCurrencySuite) TestUpdateCurrency() {
 			c.mockFunc()
 
 			req := httptest.NewRequest("PUT", "/"+c.id, strings.NewReader(c.data))
+			if c.ifMatch != "" {
+				req.Header.Set("If-Match", c.ifMatch)
+			}
 
 			resp, err := app.Test(req, 1)
 			s.Require().NoError(err)
@@ -204,6 +266,146 @@ func (s *ServerCurrencySuite) TestUpdateCurrency() {
 
 			assert.Equal(s.T(), c.expCode, resp.StatusCode)
 			assert.Equal(s.T(), string(respBody), c.expRes)
+			assert.Equal(s.T(), c.expETag, resp.Header.Get("ETag"))
+		})
+	}
+}
+
+func (s *ServerCurrencySuite) TestPatchCurrency() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		ifMatch  string
+		data     string
+		mockFunc func()
+		expRes   string
+		expETag  string
+		expCode  int
+	}{
+		{
+			name:    "success",
+			id:      id.String(),
+			ifMatch: `"3"`,
+			data:    `{"isAvailable": false}`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("PatchCurrency", ctx, mock.MatchedBy(func(p dto.CurrencyPatch) bool {
+					return p.ID == id && p.Version == 3 && p.Name == nil && p.Code == nil && p.Type == nil &&
+						p.IsAvailable != nil && !*p.IsAvailable
+				})).
+					Return(int64(4), nil).Once()
+			},
+			expETag: `"4"`,
+			expCode: 204,
+		},
+		{
+			name:     "if_match_required",
+			id:       id.String(),
+			data:     `{"isAvailable": false}`,
+			mockFunc: func() {},
+			expRes:   `{"code":428,"text":"If-Match header is required"}`,
+			expCode:  428,
+		},
+		{
+			name:     "invalid_json",
+			id:       id.String(),
+			ifMatch:  `"1"`,
+			data:     `[]`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid json body format"}`,
+			expCode:  400,
+		},
+		{
+			name:     "empty_patch",
+			id:       id.String(),
+			ifMatch:  `"1"`,
+			data:     `{}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"patch has no fields to update"}`,
+			expCode:  400,
+		},
+		{
+			name:     "unknown_field",
+			id:       id.String(),
+			ifMatch:  `"1"`,
+			data:     `{"id": "123"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"unknown field id"}`,
+			expCode:  400,
+		},
+		{
+			name:     "null_field",
+			id:       id.String(),
+			ifMatch:  `"1"`,
+			data:     `{"name": null}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"field name can not be null"}`,
+			expCode:  400,
+		},
+		{
+			name:     "validation_err",
+			id:       id.String(),
+			ifMatch:  `"1"`,
+			data:     `{"type": 9}`,
+			mockFunc: func() {},
+			expRes: `{"code":400,"text":"Key: 'CurrencyPatch.Type' Error:Field validation for 'Type' failed ` +
+				`on the 'oneof' tag"}`,
+			expCode: 400,
+		},
+		{
+			name:    "version_conflict",
+			id:      id.String(),
+			ifMatch: `"2"`,
+			data:    `{"name": "Bitcoin"}`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("PatchCurrency", ctx, mock.Anything).
+					Return(int64(0), entity.ErrVersionConflict).Once()
+			},
+			expRes:  `{"code":412,"text":"Precondition Failed"}`,
+			expCode: 412,
+		},
+		{
+			name:    "currency_not_found",
+			id:      id.String(),
+			ifMatch: `"1"`,
+			data:    `{"code": "BTC"}`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("PatchCurrency", ctx, mock.Anything).
+					Return(int64(0), entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Patch("/:id", s.srv.PatchCurrency)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("PATCH", "/"+c.id, strings.NewReader(c.data))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+
+			if c.ifMatch != "" {
+				req.Header.Set("If-Match", c.ifMatch)
+			}
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+			assert.Equal(s.T(), c.expETag, resp.Header.Get("ETag"))
 		})
 	}
 }
@@ -214,42 +416,65 @@ func (s *ServerCurrencySuite) TestDeleteCurrency() {
 	testCases := []struct {
 		name     string
 		id       string
+		ifMatch  string
 		mockFunc func()
 		expRes   string
 		expCode  int
 	}{
 		{
-			name: "success",
-			id:   uuid.New().String(),
+			name:    "success",
+			id:      uuid.New().String(),
+			ifMatch: `"2"`,
 			mockFunc: func() {
-				s.mockCurrencySvc.On("DeleteCurrency", ctx, mock.Anything).
+				s.mockCurrencySvc.On("DeleteCurrency", ctx, mock.Anything, int64(2)).
 					Return(nil).Once()
 			},
 			expRes:  "",
 			expCode: 204,
 		},
+		{
+			name:     "if_match_required",
+			id:       uuid.New().String(),
+			mockFunc: func() {},
+			expRes:   `{"code":428,"text":"If-Match header is required"}`,
+			expCode:  428,
+		},
+		{
+			name:    "version_conflict",
+			id:      uuid.New().String(),
+			ifMatch: `"1"`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("DeleteCurrency", ctx, mock.Anything, int64(1)).
+					Return(entity.ErrVersionConflict).Once()
+			},
+			expRes:  `{"code":412,"text":"Precondition Failed"}`,
+			expCode: 412,
+		},
 		{
 			name:     "invalid_id",
 			id:       "123",
+			ifMatch:  `"1"`,
 			mockFunc: func() {},
 			expRes:   `{"code":400,"text":"invalid id format"}`,
 			expCode:  400,
 		},
 		{
-			name: "currency_not_found",
-			id:   uuid.New().String(),
+			name:    "currency_not_found",
+			id:      uuid.New().String(),
+			ifMatch: `"1"`,
 			mockFunc: func() {
-				s.mockCurrencySvc.On("DeleteCurrency", ctx, mock.Anything).
+				s.mockCurrencySvc.On("DeleteCurrency", ctx, mock.Anything, int64(1)).
 					Return(entity.ErrEntityNotFound).Once()
 			},
 			expRes:  `{"code":404,"text":"Not Found"}`,
 			expCode: 404,
 		},
 		{
-			name: "svc_err",
-			id:   uuid.New().String(),
+			name:    "svc_err",
+			id:      uuid.New().String(),
+			ifMatch: `"1"`,
 			mockFunc: func() {
-				s.mockCurrencySvc.On("DeleteCurrency", ctx, mock.Anything).
+				s.mockCurrencySvc.On("DeleteCurrency", ctx, mock.Anything, int64(1)).
 					Return(errors.New("")).Once()
 			},
 			expRes:  `{"code":500,"text":"Internal Server error"}`,
@@ -265,6 +490,9 @@ func (s *ServerCurrencySuite) TestDeleteCurrency() {
 			c.mockFunc()
 
 			req := httptest.NewRequest("DELETE", "/"+c.id, nil)
+			if c.ifMatch != "" {
+				req.Header.Set("If-Match", c.ifMatch)
+			}
 
 			resp, err := app.Test(req, 1)
 			s.Require().NoError(err)
@@ -280,6 +508,463 @@ func (s *ServerCurrencySuite) TestDeleteCurrency() {
 	}
 }
 
+func (s *ServerCurrencySuite) TestListCurrencies() {
+	ctx := context.Background()
+
+	isAvailable := true
+
+	testCases := []struct {
+		name     string
+		query    string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name:  "success",
+			query: "?type=1&isAvailable=true&search=coin&sort=-name&limit=1",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ListCurrencies", ctx, dto.ListCurrenciesReq{
+					Type:        1,
+					IsAvailable: &isAvailable,
+					Search:      "coin",
+					Sort:        "-name",
+					Limit:       1,
+				}).Return(dto.CurrenciesPage{
+					Currencies: []dto.CurrencyResp{{
+						ID:          uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+						Name:        "Bitcoin",
+						Code:        "BTC",
+						Type:        1,
+						IsAvailable: true,
+						Version:     1,
+					}},
+					NextCursor: "next",
+				}, nil).Once()
+			},
+			expRes: `{"currencies":[{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","name":"Bitcoin","code":"BTC",` +
+				`"type":1,"isAvailable":true,"version":1}],"nextCursor":"next"}`,
+			expCode: 200,
+		},
+		{
+			name:  "no_filters",
+			query: "",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ListCurrencies", ctx, dto.ListCurrenciesReq{}).
+					Return(dto.CurrenciesPage{Currencies: []dto.CurrencyResp{}}, nil).Once()
+			},
+			expRes:  `{"currencies":[]}`,
+			expCode: 200,
+		},
+		{
+			name:     "invalid_sort",
+			query:    "?sort=type",
+			mockFunc: func() {},
+			expRes: `{"code":400,"text":"Key: 'ListCurrenciesQuery.Sort' Error:` +
+				`Field validation for 'Sort' failed on the 'oneof' tag"}`,
+			expCode: 400,
+		},
+		{
+			name:  "invalid_cursor",
+			query: "?cursor=abc",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ListCurrencies", ctx, dto.ListCurrenciesReq{Cursor: "abc"}).
+					Return(dto.CurrenciesPage{}, entity.ErrInvalidCursor).Once()
+			},
+			expRes:  `{"code":400,"text":"invalid cursor"}`,
+			expCode: 400,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", s.srv.ListCurrencies)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/"+c.query, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerCurrencySuite) TestGetCurrency() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		mockFunc func()
+		expRes   string
+		expETag  string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockCurrencySvc.On("GetCurrency", ctx, id).
+					Return(dto.CurrencyResp{ID: id, Name: "US Dollar", Code: "USD", Type: 2, Version: 7}, nil).Once()
+			},
+			expRes: `{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","name":"US Dollar","code":"USD","type":2,` +
+				`"isAvailable":false,"version":7}`,
+			expETag: `"7"`,
+			expCode: 200,
+		},
+		{
+			name:     "invalid_id",
+			id:       "123",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid id format"}`,
+			expCode:  400,
+		},
+		{
+			name: "not_found",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockCurrencySvc.On("GetCurrency", ctx, id).
+					Return(dto.CurrencyResp{}, entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/:id", s.srv.GetCurrency)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/"+c.id, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+			assert.Equal(s.T(), c.expETag, resp.Header.Get("ETag"))
+		})
+	}
+}
+
+func (s *ServerCurrencySuite) TestRestoreCurrency() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		mockFunc func()
+		expRes   string
+		expETag  string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockCurrencySvc.On("RestoreCurrency", ctx, id).
+					Return(dto.CurrencyResp{ID: id, Name: "Bitcoin", Code: "BTC", Type: 1, Version: 4}, nil).Once()
+			},
+			expRes: `{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","name":"Bitcoin","code":"BTC","type":1,` +
+				`"isAvailable":false,"version":4}`,
+			expETag: `"4"`,
+			expCode: 200,
+		},
+		{
+			name:     "invalid_id",
+			id:       "123",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid id format"}`,
+			expCode:  400,
+		},
+		{
+			name: "not_deleted",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockCurrencySvc.On("RestoreCurrency", ctx, id).
+					Return(dto.CurrencyResp{}, entity.ErrCurrencyNotDeleted).Once()
+			},
+			expRes:  `{"code":409,"text":"currency is not deleted"}`,
+			expCode: 409,
+		},
+		{
+			name: "not_found",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockCurrencySvc.On("RestoreCurrency", ctx, id).
+					Return(dto.CurrencyResp{}, entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/:id/restore", s.srv.RestoreCurrency)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("POST", "/"+c.id+"/restore", nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+			assert.Equal(s.T(), c.expETag, resp.Header.Get("ETag"))
+		})
+	}
+}
+
+func (s *ServerCurrencySuite) TestPurgeCurrency() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name       string
+		id         string
+		adminToken string
+		token      string
+		mockFunc   func()
+		expRes     string
+		expCode    int
+	}{
+		{
+			name:       "success",
+			id:         id.String(),
+			adminToken: "secret",
+			token:      "secret",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("PurgeCurrency", ctx, id).Return(nil).Once()
+			},
+			expRes:  "",
+			expCode: 204,
+		},
+		{
+			name:       "invalid_token",
+			id:         id.String(),
+			adminToken: "secret",
+			token:      "secret-2",
+			mockFunc:   func() {},
+			expRes:     `{"code":403,"text":"Forbidden"}`,
+			expCode:    403,
+		},
+		{
+			name:     "token_not_configured",
+			id:       id.String(),
+			mockFunc: func() {},
+			expRes:   `{"code":403,"text":"Forbidden"}`,
+			expCode:  403,
+		},
+		{
+			name:       "not_deleted",
+			id:         id.String(),
+			adminToken: "secret",
+			token:      "secret",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("PurgeCurrency", ctx, id).Return(entity.ErrCurrencyNotDeleted).Once()
+			},
+			expRes:  `{"code":409,"text":"currency is not deleted"}`,
+			expCode: 409,
+		},
+		{
+			name:       "not_found",
+			id:         id.String(),
+			adminToken: "secret",
+			token:      "secret",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("PurgeCurrency", ctx, id).Return(entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Delete("/:id/purge", httputil.AdminOnly(c.adminToken), s.srv.PurgeCurrency)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("DELETE", "/"+c.id+"/purge", nil)
+			req.Header.Set(httputil.HeaderAdminToken, c.token)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerCurrencySuite) TestGetCurrencyAudit() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+	createdAt := time.Date(2024, 6, 14, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		id       string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockCurrencySvc.On("GetCurrencyAudit", ctx, id).
+					Return([]dto.CurrencyAuditRecord{{
+						ID:        id,
+						Action:    "delete",
+						Actor:     "admin",
+						RequestID: "req-1",
+						Before:    &dto.CurrencyResp{ID: id, Name: "Bitcoin", Code: "BTC", Type: 1, Version: 3},
+						CreatedAt: createdAt,
+					}}, nil).Once()
+			},
+			expRes: `[{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","action":"delete","actor":"admin","requestId":"req-1",` +
+				`"before":{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","name":"Bitcoin","code":"BTC","type":1,` +
+				`"isAvailable":false,"version":3},"after":null,"createdAt":"2024-06-14T10:00:00Z"}]`,
+			expCode: 200,
+		},
+		{
+			name:     "invalid_id",
+			id:       "123",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid id format"}`,
+			expCode:  400,
+		},
+		{
+			name: "svc_err",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockCurrencySvc.On("GetCurrencyAudit", ctx, id).
+					Return(nil, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/:id/audit", s.srv.GetCurrencyAudit)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/"+c.id+"/audit", nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerCurrencySuite) TestGetCurrencyByCode() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		code     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			code: "BTC",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("GetCurrencyByCode", ctx, "BTC").
+					Return(dto.CurrencyResp{ID: id, Name: "Bitcoin", Code: "BTC", Type: 1, IsAvailable: true, Version: 1}, nil).Once()
+			},
+			expRes:  `{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","name":"Bitcoin","code":"BTC","type":1,"isAvailable":true,"version":1}`,
+			expCode: 200,
+		},
+		{
+			name: "not_found",
+			code: "XXX",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("GetCurrencyByCode", ctx, "XXX").
+					Return(dto.CurrencyResp{}, entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/by-code/:code", s.srv.GetCurrencyByCode)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/by-code/"+c.code, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
 func (s *ServerCurrencySuite) TestConvert() {
 	ctx := context.Background()
 
@@ -295,11 +980,85 @@ func (s *ServerCurrencySuite) TestConvert() {
 			params: "?from=USD&to=BTC&amount=1",
 			mockFunc: func() {
 				s.mockCurrencySvc.On("Convert", ctx, mock.Anything).
-					Return(123.54, nil).Once()
+					Return(dto.ConversionResult{
+						Rate:    decimal.RequireFromString("123.54"),
+						Amount:  decimal.NewFromInt(1),
+						Result:  decimal.RequireFromString("123.54"),
+						AsOf:    time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC),
+						Source:  "fastforex",
+						CycleID: uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+
+						SpreadPercent: decimal.NewFromInt(1),
+						SpreadAmount:  decimal.RequireFromString("1.2354"),
+						Fee:           decimal.RequireFromString("0.1"),
+						Net:           decimal.RequireFromString("122.2046"),
+					}, nil).Once()
+			},
+			expRes: `{"course":123.54,"rate":123.54,"amount":1,"result":123.54,"asOf":"2024-06-14T14:00:00Z",` +
+				`"source":"fastforex","cycleId":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","isSynthetic":false,"isStale":false,` +
+				`"spreadPercent":1,"spreadAmount":1.2354,"fee":0.1,"net":122.2046}`,
+			expCode: 200,
+		},
+		{
+			name:   "success_synthetic",
+			params: "?from=BTC&to=ETH&amount=1",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("Convert", ctx, mock.Anything).
+					Return(dto.ConversionResult{
+						Rate:        decimal.RequireFromString("19.5"),
+						Amount:      decimal.NewFromInt(1),
+						Result:      decimal.RequireFromString("19.5"),
+						AsOf:        time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC),
+						Source:      "fastforex",
+						IsSynthetic: true,
+						Via:         "USD",
+						Net:         decimal.RequireFromString("19.5"),
+					}, nil).Once()
 			},
-			expRes:  `{"course":123.54}`,
+			expRes: `{"course":19.5,"rate":19.5,"amount":1,"result":19.5,"asOf":"2024-06-14T14:00:00Z","source":"fastforex",` +
+				`"cycleId":"00000000-0000-0000-0000-000000000000","isSynthetic":true,"via":"USD","isStale":false,` +
+				`"spreadPercent":0,"spreadAmount":0,"fee":0,"net":19.5}`,
 			expCode: 200,
 		},
+		{
+			name:   "success_at",
+			params: "?from=USD&to=BTC&amount=1&at=2024-06-14T14:00:00Z",
+			mockFunc: func() {
+				at := time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC)
+
+				s.mockCurrencySvc.On("Convert", ctx, dto.Conversion{
+					From:   "USD",
+					To:     "BTC",
+					Amount: decimal.NewFromInt(1),
+					At:     &at,
+				}).Return(dto.ConversionResult{
+					Rate:   decimal.RequireFromString("0.5"),
+					Amount: decimal.NewFromInt(1),
+					Result: decimal.RequireFromString("0.5"),
+					AsOf:   at,
+					Source: "fastforex",
+					Net:    decimal.RequireFromString("0.5"),
+				}, nil).Once()
+			},
+			expRes: `{"course":0.5,"rate":0.5,"amount":1,"result":0.5,"asOf":"2024-06-14T14:00:00Z","source":"fastforex",` +
+				`"cycleId":"00000000-0000-0000-0000-000000000000","isSynthetic":false,"isStale":false,` +
+				`"spreadPercent":0,"spreadAmount":0,"fee":0,"net":0.5}`,
+			expCode: 200,
+		},
+		{
+			name:     "at_in_future",
+			params:   "?from=USD&to=BTC&amount=1&at=2999-01-01T00:00:00Z",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"at must not be in the future"}`,
+			expCode:  400,
+		},
+		{
+			name:     "invalid_at",
+			params:   "?from=USD&to=BTC&amount=1&at=yesterday",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"Key: 'ConvertCurrencyReq.At' Error:Field validation for 'At' failed on the 'datetime' tag"}`,
+			expCode:  400,
+		},
 		{
 			name:     "validation_err",
 			params:   "?from=USD&to=BTC",
@@ -308,15 +1067,25 @@ func (s *ServerCurrencySuite) TestConvert() {
 			expCode:  400,
 		},
 		{
-			name:   "svc_err",
+			name:   "currency_not_available",
 			params: "?from=USD&to=BTC&amount=1",
 			mockFunc: func() {
 				s.mockCurrencySvc.On("Convert", ctx, mock.Anything).
-					Return(float64(0), errors.New("")).Once()
+					Return(dto.ConversionResult{}, entity.ErrCurrencyNotAvailable).Once()
 			},
 			expRes:  `{"code":400,"text":"Bad Request","businessCode":1}`,
 			expCode: 400,
 		},
+		{
+			name:   "svc_err",
+			params: "?from=USD&to=BTC&amount=1",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("Convert", ctx, mock.Anything).
+					Return(dto.ConversionResult{}, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
 	}
 
 	for _, c := range testCases {
@@ -341,3 +1110,122 @@ func (s *ServerCurrencySuite) TestConvert() {
 		})
 	}
 }
+
+func (s *ServerCurrencySuite) TestConvertBatch() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			data: `[{"from": "USD", "to": "BTC", "amount": 2}, {"from": "USD", "to": "ETH", "amount": 1}]`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ConvertBatch", ctx, []dto.Conversion{
+					{From: "USD", To: "BTC", Amount: decimal.NewFromInt(2)},
+					{From: "USD", To: "ETH", Amount: decimal.NewFromInt(1)},
+				}).Return([]dto.BatchConversionResult{
+					{
+						Result: dto.ConversionResult{
+							Rate:   decimal.RequireFromString("0.5"),
+							Amount: decimal.NewFromInt(2),
+							Result: decimal.NewFromInt(1),
+							AsOf:   time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC),
+							Source: "fastforex",
+							Net:    decimal.NewFromInt(1),
+						},
+					},
+					{Err: entity.ErrCurrencyNotAvailable},
+				}, nil).Once()
+			},
+			expRes: `{"items":[{"result":{"course":1,"rate":0.5,"amount":2,"result":1,"asOf":"2024-06-14T14:00:00Z",` +
+				`"source":"fastforex","cycleId":"00000000-0000-0000-0000-000000000000","isSynthetic":false,"isStale":false,` +
+				`"spreadPercent":0,"spreadAmount":0,"fee":0,"net":1}},` +
+				`{"error":{"text":"Bad Request","businessCode":1}}]}`,
+			expCode: 200,
+		},
+		{
+			name: "svc_err",
+			data: `[{"from": "USD", "to": "BTC", "amount": 2}]`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ConvertBatch", ctx, mock.Anything).
+					Return(nil, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+		{
+			name:     "invalid_json",
+			data:     `{"from": "USD"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid json body format"}`,
+			expCode:  400,
+		},
+		{
+			name:     "at_in_future",
+			data:     `[{"from": "USD", "to": "BTC", "amount": 2, "at": "2999-01-01T00:00:00Z"}]`,
+			mockFunc: func() {},
+			expRes:   `{"items":[{"error":{"text":"at must not be in the future"}}]}`,
+			expCode:  200,
+		},
+		{
+			name:     "empty_batch",
+			data:     `[]`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"batch must contain from 1 to 10000 items"}`,
+			expCode:  400,
+		},
+		{
+			name: "validation_err",
+			data: `[{"from": "USD", "to": "BTC"}, {"from": "USD", "to": "BTC", "amount": 2}]`,
+			mockFunc: func() {
+				s.mockCurrencySvc.On("ConvertBatch", ctx, []dto.Conversion{
+					{From: "USD", To: "BTC", Amount: decimal.NewFromInt(2)},
+				}).Return([]dto.BatchConversionResult{
+					{
+						Result: dto.ConversionResult{
+							Rate:   decimal.RequireFromString("0.5"),
+							Amount: decimal.NewFromInt(2),
+							Result: decimal.NewFromInt(1),
+							AsOf:   time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC),
+							Source: "fastforex",
+							Net:    decimal.NewFromInt(1),
+						},
+					},
+				}, nil).Once()
+			},
+			expRes: `{"items":[{"error":{"text":"Key: 'ConvertCurrencyReq.Amount' Error:Field validation for 'Amount' ` +
+				`failed on the 'required' tag"}},` +
+				`{"result":{"course":1,"rate":0.5,"amount":2,"result":1,"asOf":"2024-06-14T14:00:00Z",` +
+				`"source":"fastforex","cycleId":"00000000-0000-0000-0000-000000000000","isSynthetic":false,"isStale":false,` +
+				`"spreadPercent":0,"spreadAmount":0,"fee":0,"net":1}}]}`,
+			expCode: 200,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/", s.srv.ConvertBatch)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	pairentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
+)
+
+var errInvalidRefreshInterval = errors.New("invalid refresh interval format")
+
+type PairServer struct {
+	pairSvc   PairSvc
+	validator *validator.Validate
+}
+
+//go:generate mockery --name PairSvc
+type PairSvc interface {
+	GetPairs(ctx context.Context) ([]dto.Pair, error)
+	CreatePair(ctx context.Context, pair dto.SavePair) (dto.Pair, error)
+	UpdatePair(ctx context.Context, pair dto.SavePair) error
+	DeletePair(ctx context.Context, id uuid.UUID) error
+}
+
+func NewPairServer(pairSvc PairSvc) *PairServer {
+	return &PairServer{
+		pairSvc:   pairSvc,
+		validator: validator.New(),
+	}
+}
+
+// GetPairs godoc
+//
+//	@Summary		List currency pairs
+//	@Description	List currency pairs registered for fetching courses
+//	@Tags			pair
+//	@Produce		json
+//	@Success		200		{array}   dto.Pair
+//	@Failure		500		{object}  httputil.HTTPError
+//	@Router			/v1/currency-pairs [get]
+func (s *PairServer) GetPairs(c *fiber.Ctx) error {
+	res, err := s.pairSvc.GetPairs(c.UserContext())
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// CreatePair godoc
+//
+//	@Summary		Create currency pair
+//	@Description	Register currency pair for fetching courses. Pairs are allowed between currency types
+//	@Description	by the pairing rules, pairs with the pivot currency are always allowed
+//	@Tags			pair
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		dto.PairReq	true	"PairReq"
+//	@Success		201		{object}  dto.Pair
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v1/currency-pairs [post]
+func (s *PairServer) CreatePair(c *fiber.Ctx) error {
+	pair, err := s.pairFromBody(c)
+	if err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	res, err := s.pairSvc.CreatePair(c.UserContext(), pair)
+	if err != nil {
+		return pairErr(c, err)
+	}
+
+	c.Status(fiber.StatusCreated)
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// UpdatePair godoc
+//
+//	@Summary		Update currency pair
+//	@Description	Update currency pair registered for fetching courses
+//	@Tags			pair
+//	@Accept			json
+//	@Produce		json
+//	@Param          id   path string  true  "PairID" Format(uuid)
+//	@Param			payload	body		dto.PairReq	true	"PairReq"
+//	@Success		204
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Router			/v1/currency-pairs/{id} [put]
+func (s *PairServer) UpdatePair(c *fiber.Ctx) error {
+	pairID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	pair, err := s.pairFromBody(c)
+	if err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	pair.ID = pairID
+
+	if err = s.pairSvc.UpdatePair(c.UserContext(), pair); err != nil {
+		return pairErr(c, err)
+	}
+
+	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
+}
+
+// DeletePair godoc
+//
+//	@Summary		Delete currency pair
+//	@Description	Delete currency pair, its courses are not fetched anymore
+//	@Tags			pair
+//	@Produce		json
+//	@Param          id   path string  true  "PairID" Format(uuid)
+//	@Success		204
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Router			/v1/currency-pairs/{id} [delete]
+func (s *PairServer) DeletePair(c *fiber.Ctx) error {
+	pairID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	if err = s.pairSvc.DeletePair(c.UserContext(), pairID); err != nil {
+		return pairErr(c, err)
+	}
+
+	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
+}
+
+func (s *PairServer) pairFromBody(c *fiber.Ctx) (dto.SavePair, error) {
+	var req dto.PairReq
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return dto.SavePair{}, errors.New("invalid json body format")
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return dto.SavePair{}, err //nolint:wrapcheck
+	}
+
+	res := dto.SavePair{
+		From:     req.From,
+		To:       req.To,
+		Enabled:  req.Enabled == nil || *req.Enabled,
+		Provider: req.Provider,
+	}
+
+	if req.RefreshInterval != "" {
+		var err error
+		if res.RefreshInterval, err = time.ParseDuration(req.RefreshInterval); err != nil {
+			return dto.SavePair{}, errInvalidRefreshInterval
+		}
+	}
+
+	return res, nil
+}
+
+func pairErr(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pairentity.ErrInvalidPair), errors.Is(err, pairentity.ErrUnknownCurrency),
+		errors.Is(err, pairentity.ErrPairNotAllowed), errors.Is(err, pairentity.ErrPairAlreadyExists):
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	case errors.Is(err, entity.ErrEntityNotFound):
+		return httputil.NewNotFoundErr(c) //nolint:wrapcheck
+	}
+
+	return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+}
@@ -0,0 +1,356 @@
+//go:build integration
+
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	pairentity "github.com/veleton777/test_work_blum/internal/currency/v1/pair/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	v1 "github.com/veleton777/test_work_blum/internal/transport/http/v1"
+	"github.com/veleton777/test_work_blum/internal/transport/http/v1/mocks"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ServerPairSuite struct {
+	suite.Suite
+
+	srv         *v1.PairServer
+	mockPairSvc *mocks.PairSvc
+}
+
+func TestPairSuite(t *testing.T) {
+	suite.Run(t, new(ServerPairSuite))
+}
+
+func (s *ServerPairSuite) SetupSuite() {
+	s.mockPairSvc = mocks.NewPairSvc(s.T())
+
+	s.srv = v1.NewPairServer(s.mockPairSvc)
+}
+
+func (s *ServerPairSuite) TestGetPairs() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				s.mockPairSvc.On("GetPairs", ctx).
+					Return([]dto.Pair{{
+						ID:              uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+						From:            "BTC",
+						To:              "USD",
+						Enabled:         true,
+						RefreshInterval: "5m0s",
+						CreatedAt:       time.Date(2024, 6, 14, 13, 0, 0, 0, time.UTC),
+					}}, nil).Once()
+			},
+			expRes: `[{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","from":"BTC","to":"USD","enabled":true,` +
+				`"refreshInterval":"5m0s","createdAt":"2024-06-14T13:00:00Z"}]`,
+			expCode: 200,
+		},
+		{
+			name: "svc_err",
+			mockFunc: func() {
+				s.mockPairSvc.On("GetPairs", ctx).
+					Return(nil, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", s.srv.GetPairs)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/", nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerPairSuite) TestCreatePair() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			data: `{"from": "BTC", "to": "USD", "refreshInterval": "5m", "provider": "fastforex"}`,
+			mockFunc: func() {
+				s.mockPairSvc.On("CreatePair", ctx, dto.SavePair{
+					From:            "BTC",
+					To:              "USD",
+					Enabled:         true,
+					RefreshInterval: 5 * time.Minute,
+					Provider:        "fastforex",
+				}).Return(dto.Pair{
+					ID:              uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+					From:            "BTC",
+					To:              "USD",
+					Enabled:         true,
+					RefreshInterval: "5m0s",
+					Provider:        "fastforex",
+					CreatedAt:       time.Date(2024, 6, 14, 13, 0, 0, 0, time.UTC),
+				}, nil).Once()
+			},
+			expRes: `{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","from":"BTC","to":"USD","enabled":true,` +
+				`"refreshInterval":"5m0s","provider":"fastforex","createdAt":"2024-06-14T13:00:00Z"}`,
+			expCode: 201,
+		},
+		{
+			name: "disabled",
+			data: `{"from": "ETH", "to": "USD", "enabled": false}`,
+			mockFunc: func() {
+				s.mockPairSvc.On("CreatePair", ctx, dto.SavePair{From: "ETH", To: "USD"}).
+					Return(dto.Pair{
+						ID:        uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+						From:      "ETH",
+						To:        "USD",
+						CreatedAt: time.Date(2024, 6, 14, 13, 0, 0, 0, time.UTC),
+					}, nil).Once()
+			},
+			expRes: `{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","from":"ETH","to":"USD","enabled":false,` +
+				`"createdAt":"2024-06-14T13:00:00Z"}`,
+			expCode: 201,
+		},
+		{
+			name:     "invalid_json",
+			data:     `invalid_json`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid json body format"}`,
+			expCode:  400,
+		},
+		{
+			name:     "validation_err",
+			data:     `{"from": "BTC"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"Key: 'PairReq.To' Error:Field validation for 'To' failed on the 'required' tag"}`,
+			expCode:  400,
+		},
+		{
+			name:     "invalid_refresh_interval",
+			data:     `{"from": "BTC", "to": "USD", "refreshInterval": "often"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid refresh interval format"}`,
+			expCode:  400,
+		},
+		{
+			name: "not_allowed",
+			data: `{"from": "BTC", "to": "ETH"}`,
+			mockFunc: func() {
+				s.mockPairSvc.On("CreatePair", ctx, mock.Anything).
+					Return(dto.Pair{}, pairentity.ErrPairNotAllowed).Once()
+			},
+			expRes:  `{"code":400,"text":"pair of currency types is not allowed by pairing rules"}`,
+			expCode: 400,
+		},
+		{
+			name: "already_exists",
+			data: `{"from": "BTC", "to": "USD"}`,
+			mockFunc: func() {
+				s.mockPairSvc.On("CreatePair", ctx, mock.Anything).
+					Return(dto.Pair{}, pairentity.ErrPairAlreadyExists).Once()
+			},
+			expRes:  `{"code":400,"text":"pair already exists"}`,
+			expCode: 400,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/", s.srv.CreatePair)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerPairSuite) TestUpdatePair() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			data: `{"from": "BTC", "to": "USD", "enabled": false, "refreshInterval": "1h"}`,
+			mockFunc: func() {
+				s.mockPairSvc.On("UpdatePair", ctx, dto.SavePair{
+					ID:              id,
+					From:            "BTC",
+					To:              "USD",
+					RefreshInterval: time.Hour,
+				}).Return(nil).Once()
+			},
+			expRes:  "",
+			expCode: 204,
+		},
+		{
+			name:     "invalid_id",
+			id:       "123",
+			data:     `{}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid id format"}`,
+			expCode:  400,
+		},
+		{
+			name: "unknown_currency",
+			id:   id.String(),
+			data: `{"from": "BTC", "to": "XXX"}`,
+			mockFunc: func() {
+				s.mockPairSvc.On("UpdatePair", ctx, mock.Anything).
+					Return(pairentity.ErrUnknownCurrency).Once()
+			},
+			expRes:  `{"code":400,"text":"pair currency not found"}`,
+			expCode: 400,
+		},
+		{
+			name: "not_found",
+			id:   id.String(),
+			data: `{"from": "BTC", "to": "USD"}`,
+			mockFunc: func() {
+				s.mockPairSvc.On("UpdatePair", ctx, mock.Anything).
+					Return(entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Put("/:id", s.srv.UpdatePair)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("PUT", "/"+c.id, strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerPairSuite) TestDeletePair() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockPairSvc.On("DeletePair", ctx, id).
+					Return(nil).Once()
+			},
+			expRes:  "",
+			expCode: 204,
+		},
+		{
+			name: "not_found",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockPairSvc.On("DeletePair", ctx, id).
+					Return(entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Delete("/:id", s.srv.DeletePair)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("DELETE", "/"+c.id, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
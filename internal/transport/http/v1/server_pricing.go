@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
+)
+
+type PricingServer struct {
+	pricingSvc PricingSvc
+}
+
+//go:generate mockery --name PricingSvc
+type PricingSvc interface {
+	GetPricings(ctx context.Context) ([]dto.PairPricing, error)
+	CreatePricing(ctx context.Context, pricing dto.PairPricing) (dto.PairPricing, error)
+	UpdatePricing(ctx context.Context, pricing dto.PairPricing) error
+	DeletePricing(ctx context.Context, id uuid.UUID) error
+}
+
+func NewPricingServer(pricingSvc PricingSvc) *PricingServer {
+	return &PricingServer{
+		pricingSvc: pricingSvc,
+	}
+}
+
+// GetPricings godoc
+//
+//	@Summary		List pricing
+//	@Description	List spreads and fees for currency pairs and currency types
+//	@Tags			pricing
+//	@Produce		json
+//	@Success		200		{array}   dto.PairPricing
+//	@Failure		500		{object}  httputil.HTTPError
+//	@Router			/v1/pricing [get]
+func (s *PricingServer) GetPricings(c *fiber.Ctx) error {
+	res, err := s.pricingSvc.GetPricings(c.UserContext())
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// CreatePricing godoc
+//
+//	@Summary		Create pricing
+//	@Description	Create spread and fees for currency pair or currency types
+//	@Tags			pricing
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		dto.PairPricing	true	"PairPricing"
+//	@Success		201		{object}  dto.PairPricing
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v1/pricing [post]
+func (s *PricingServer) CreatePricing(c *fiber.Ctx) error {
+	var req dto.PairPricing
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid json body format") //nolint:wrapcheck
+	}
+
+	res, err := s.pricingSvc.CreatePricing(c.UserContext(), req)
+	if err != nil {
+		return pricingErr(c, err)
+	}
+
+	c.Status(fiber.StatusCreated)
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// UpdatePricing godoc
+//
+//	@Summary		Update pricing
+//	@Description	Update spread and fees for currency pair or currency types
+//	@Tags			pricing
+//	@Accept			json
+//	@Produce		json
+//	@Param          id   path string  true  "PricingID" Format(uuid)
+//	@Param			payload	body		dto.PairPricing	true	"PairPricing"
+//	@Success		204
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Router			/v1/pricing/{id} [put]
+func (s *PricingServer) UpdatePricing(c *fiber.Ctx) error {
+	pricingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	var req dto.PairPricing
+	if err = json.Unmarshal(c.Body(), &req); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid json body format") //nolint:wrapcheck
+	}
+
+	req.ID = pricingID
+
+	if err = s.pricingSvc.UpdatePricing(c.UserContext(), req); err != nil {
+		return pricingErr(c, err)
+	}
+
+	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
+}
+
+// DeletePricing godoc
+//
+//	@Summary		Delete pricing
+//	@Description	Delete pricing
+//	@Tags			pricing
+//	@Produce		json
+//	@Param          id   path string  true  "PricingID" Format(uuid)
+//	@Success		204
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Router			/v1/pricing/{id} [delete]
+func (s *PricingServer) DeletePricing(c *fiber.Ctx) error {
+	pricingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	if err = s.pricingSvc.DeletePricing(c.UserContext(), pricingID); err != nil {
+		return pricingErr(c, err)
+	}
+
+	return httputil.NewNoContentResponse(c) //nolint:wrapcheck
+}
+
+func pricingErr(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pricingentity.ErrInvalidPricingScope), errors.Is(err, pricingentity.ErrInvalidPricingValue):
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	case errors.Is(err, pricingentity.ErrPricingAlreadyExists):
+		return httputil.NewBadRequestErr(c, "pricing already exists") //nolint:wrapcheck
+	case errors.Is(err, entity.ErrEntityNotFound):
+		return httputil.NewNotFoundErr(c) //nolint:wrapcheck
+	}
+
+	return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+}
@@ -0,0 +1,321 @@
+//go:build integration
+
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	pricingentity "github.com/veleton777/test_work_blum/internal/currency/v1/pricing/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	v1 "github.com/veleton777/test_work_blum/internal/transport/http/v1"
+	"github.com/veleton777/test_work_blum/internal/transport/http/v1/mocks"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type ServerPricingSuite struct {
+	suite.Suite
+
+	srv            *v1.PricingServer
+	mockPricingSvc *mocks.PricingSvc
+}
+
+func TestPricingSuite(t *testing.T) {
+	suite.Run(t, new(ServerPricingSuite))
+}
+
+func (s *ServerPricingSuite) SetupSuite() {
+	s.mockPricingSvc = mocks.NewPricingSvc(s.T())
+
+	s.srv = v1.NewPricingServer(s.mockPricingSvc)
+}
+
+func (s *ServerPricingSuite) TestGetPricings() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				s.mockPricingSvc.On("GetPricings", ctx).
+					Return([]dto.PairPricing{{
+						ID:            uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+						FromType:      2,
+						ToType:        1,
+						SpreadPercent: decimal.RequireFromString("0.5"),
+					}}, nil).Once()
+			},
+			expRes: `[{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","fromType":2,"toType":1,` +
+				`"spreadPercent":"0.5","fixedFee":"0","minFee":"0"}]`,
+			expCode: 200,
+		},
+		{
+			name: "svc_err",
+			mockFunc: func() {
+				s.mockPricingSvc.On("GetPricings", ctx).
+					Return(nil, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", s.srv.GetPricings)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/", nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerPricingSuite) TestCreatePricing() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			data: `{"from": "USD", "to": "BTC", "spreadPercent": "0.5", "fixedFee": "0.000001"}`,
+			mockFunc: func() {
+				s.mockPricingSvc.On("CreatePricing", ctx, dto.PairPricing{
+					From:          "USD",
+					To:            "BTC",
+					SpreadPercent: decimal.RequireFromString("0.5"),
+					FixedFee:      decimal.RequireFromString("0.000001"),
+				}).Return(dto.PairPricing{
+					ID:            uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+					From:          "USD",
+					To:            "BTC",
+					SpreadPercent: decimal.RequireFromString("0.5"),
+					FixedFee:      decimal.RequireFromString("0.000001"),
+				}, nil).Once()
+			},
+			expRes: `{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","from":"USD","to":"BTC",` +
+				`"spreadPercent":"0.5","fixedFee":"0.000001","minFee":"0"}`,
+			expCode: 201,
+		},
+		{
+			name:     "invalid_json",
+			data:     `invalid_json`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid json body format"}`,
+			expCode:  400,
+		},
+		{
+			name: "invalid_scope",
+			data: `{"from": "USD", "spreadPercent": "0.5"}`,
+			mockFunc: func() {
+				s.mockPricingSvc.On("CreatePricing", ctx, mock.Anything).
+					Return(dto.PairPricing{}, pricingentity.ErrInvalidPricingScope).Once()
+			},
+			expRes:  `{"code":400,"text":"pricing must be set either for currency codes or for currency types"}`,
+			expCode: 400,
+		},
+		{
+			name: "already_exists",
+			data: `{"from": "USD", "to": "BTC"}`,
+			mockFunc: func() {
+				s.mockPricingSvc.On("CreatePricing", ctx, mock.Anything).
+					Return(dto.PairPricing{}, pricingentity.ErrPricingAlreadyExists).Once()
+			},
+			expRes:  `{"code":400,"text":"pricing already exists"}`,
+			expCode: 400,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/", s.srv.CreatePricing)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerPricingSuite) TestUpdatePricing() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			data: `{"fromType": 2, "toType": 1, "minFee": "0.00001"}`,
+			mockFunc: func() {
+				s.mockPricingSvc.On("UpdatePricing", ctx, dto.PairPricing{
+					ID:       id,
+					FromType: 2,
+					ToType:   1,
+					MinFee:   decimal.RequireFromString("0.00001"),
+				}).Return(nil).Once()
+			},
+			expRes:  "",
+			expCode: 204,
+		},
+		{
+			name:     "invalid_id",
+			id:       "123",
+			data:     `{}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid id format"}`,
+			expCode:  400,
+		},
+		{
+			name: "invalid_value",
+			id:   id.String(),
+			data: `{"fromType": 2, "toType": 1, "spreadPercent": "150"}`,
+			mockFunc: func() {
+				s.mockPricingSvc.On("UpdatePricing", ctx, mock.Anything).
+					Return(pricingentity.ErrInvalidPricingValue).Once()
+			},
+			expRes:  `{"code":400,"text":"spread must be in [0, 100) and fees must not be negative"}`,
+			expCode: 400,
+		},
+		{
+			name: "not_found",
+			id:   id.String(),
+			data: `{"fromType": 2, "toType": 1}`,
+			mockFunc: func() {
+				s.mockPricingSvc.On("UpdatePricing", ctx, mock.Anything).
+					Return(entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Put("/:id", s.srv.UpdatePricing)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("PUT", "/"+c.id, strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerPricingSuite) TestDeletePricing() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockPricingSvc.On("DeletePricing", ctx, id).
+					Return(nil).Once()
+			},
+			expRes:  "",
+			expCode: 204,
+		},
+		{
+			name: "not_found",
+			id:   id.String(),
+			mockFunc: func() {
+				s.mockPricingSvc.On("DeletePricing", ctx, id).
+					Return(entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Delete("/:id", s.srv.DeletePricing)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("DELETE", "/"+c.id, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
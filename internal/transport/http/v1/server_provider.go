@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/veleton777/test_work_blum/internal/dto"
+)
+
+type ProviderServer struct {
+	providerSvc ProviderSvc
+}
+
+//go:generate mockery --name ProviderSvc
+type ProviderSvc interface {
+	Statuses(ctx context.Context) []dto.ProviderStatus
+}
+
+func NewProviderServer(providerSvc ProviderSvc) *ProviderServer {
+	return &ProviderServer{
+		providerSvc: providerSvc,
+	}
+}
+
+// GetProviders godoc
+//
+//	@Summary		List courses providers
+//	@Description	List courses providers in priority order with the states of their circuit breakers and call budgets.
+//	@Description	The open breaker rejects requests to the provider until its cooldown passes,
+//	@Description	the provider with the exhausted budget is refreshed at the longest interval
+//	@Tags			provider
+//	@Produce		json
+//	@Success		200		{array}   dto.ProviderStatus
+//	@Router			/v1/providers [get]
+func (s *ProviderServer) GetProviders(c *fiber.Ctx) error {
+	return c.JSON(s.providerSvc.Statuses(c.UserContext())) //nolint:wrapcheck
+}
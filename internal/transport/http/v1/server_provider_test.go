@@ -0,0 +1,55 @@
+//go:build integration
+
+package v1_test
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	v1 "github.com/veleton777/test_work_blum/internal/transport/http/v1"
+	"github.com/veleton777/test_work_blum/internal/transport/http/v1/mocks"
+	"io"
+	"net/http/httptest"
+	"testing"
+)
+
+type ServerProviderSuite struct {
+	suite.Suite
+
+	srv             *v1.ProviderServer
+	mockProviderSvc *mocks.ProviderSvc
+}
+
+func TestProviderSuite(t *testing.T) {
+	suite.Run(t, new(ServerProviderSuite))
+}
+
+func (s *ServerProviderSuite) SetupSuite() {
+	s.mockProviderSvc = mocks.NewProviderSvc(s.T())
+
+	s.srv = v1.NewProviderServer(s.mockProviderSvc)
+}
+
+func (s *ServerProviderSuite) TestGetProviders() {
+	s.mockProviderSvc.On("Statuses", context.Background()).
+		Return([]dto.ProviderStatus{
+			{Name: "fastforex", BreakerState: "open"},
+			{Name: "cryptocompare"},
+		}).Once()
+
+	app := fiber.New()
+	app.Get("/", s.srv.GetProviders)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil), 10)
+	s.Require().NoError(err)
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+
+	assert.Equal(s.T(), 200, resp.StatusCode)
+	assert.Equal(s.T(), `[{"name":"fastforex","breakerState":"open"},{"name":"cryptocompare"}]`, string(respBody))
+}
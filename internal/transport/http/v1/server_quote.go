@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	quoteentity "github.com/veleton777/test_work_blum/internal/currency/v1/quote/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
+)
+
+const (
+	codeQuoteExpired         = 2
+	codeQuoteAlreadyExecuted = 3
+)
+
+type QuoteServer struct {
+	quoteSvc  QuoteSvc
+	validator *validator.Validate
+}
+
+//go:generate mockery --name QuoteSvc
+type QuoteSvc interface {
+	CreateQuote(ctx context.Context, req dto.CreateQuoteReq) (dto.QuoteResp, error)
+	ExecuteQuote(ctx context.Context, req dto.ExecuteQuote) (dto.ExecuteQuoteResp, error)
+}
+
+func NewQuoteServer(quoteSvc QuoteSvc) *QuoteServer {
+	return &QuoteServer{
+		quoteSvc:  quoteSvc,
+		validator: validator.New(),
+	}
+}
+
+// CreateQuote godoc
+//
+//	@Summary		Create quote
+//	@Description	Lock current rate for currency pair until the quote expires
+//	@Tags			quote
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		dto.CreateQuoteReq	true	"CreateQuoteReq"
+//	@Success		201		{object}  dto.QuoteResp
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v1/quotes [post]
+func (s *QuoteServer) CreateQuote(c *fiber.Ctx) error {
+	var req dto.CreateQuoteReq
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid json body format") //nolint:wrapcheck
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	res, err := s.quoteSvc.CreateQuote(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, entity.ErrCurrencyNotAvailable) {
+			return httputil.NewBusinessErr(c, codeCurrencyNotAllowedForConvert) //nolint:wrapcheck
+		}
+
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	c.Status(fiber.StatusCreated)
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// ExecuteQuote godoc
+//
+//	@Summary		Execute quote
+//	@Description	Convert amount at the rate locked by the quote
+//	@Tags			quote
+//	@Accept			json
+//	@Produce		json
+//	@Param          id   path string  true  "QuoteID" Format(uuid)
+//	@Param			payload	body		dto.ExecuteQuoteReq	true	"ExecuteQuoteReq"
+//	@Success		200		{object}  dto.ExecuteQuoteResp
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Failure		404		{object}  httputil.HTTPError
+//	@Router			/v1/quotes/{id}/execute [post]
+func (s *QuoteServer) ExecuteQuote(c *fiber.Ctx) error {
+	quoteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.NewBadRequestErr(c, "invalid id format") //nolint:wrapcheck
+	}
+
+	var req dto.ExecuteQuoteReq
+	if err = json.Unmarshal(c.Body(), &req); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid json body format") //nolint:wrapcheck
+	}
+
+	if err = s.validator.Struct(req); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return httputil.NewBadRequestErr(c, "amount must be a positive decimal") //nolint:wrapcheck
+	}
+
+	res, err := s.quoteSvc.ExecuteQuote(c.UserContext(), dto.ExecuteQuote{
+		ID:     quoteID,
+		Amount: amount,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrEntityNotFound):
+			return httputil.NewNotFoundErr(c) //nolint:wrapcheck
+		case errors.Is(err, quoteentity.ErrQuoteExpired):
+			return httputil.NewBusinessErr(c, codeQuoteExpired) //nolint:wrapcheck
+		case errors.Is(err, quoteentity.ErrQuoteAlreadyExecuted):
+			return httputil.NewBusinessErr(c, codeQuoteAlreadyExecuted) //nolint:wrapcheck
+		}
+
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
@@ -0,0 +1,238 @@
+//go:build integration
+
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	quoteentity "github.com/veleton777/test_work_blum/internal/currency/v1/quote/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	v1 "github.com/veleton777/test_work_blum/internal/transport/http/v1"
+	"github.com/veleton777/test_work_blum/internal/transport/http/v1/mocks"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ServerQuoteSuite struct {
+	suite.Suite
+
+	srv          *v1.QuoteServer
+	mockQuoteSvc *mocks.QuoteSvc
+}
+
+func TestQuoteSuite(t *testing.T) {
+	suite.Run(t, new(ServerQuoteSuite))
+}
+
+func (s *ServerQuoteSuite) SetupSuite() {
+	s.mockQuoteSvc = mocks.NewQuoteSvc(s.T())
+
+	s.srv = v1.NewQuoteServer(s.mockQuoteSvc)
+}
+
+func (s *ServerQuoteSuite) TestCreateQuote() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			data: `{"from": "USD", "to": "BTC"}`,
+			mockFunc: func() {
+				s.mockQuoteSvc.On("CreateQuote", ctx, dto.CreateQuoteReq{From: "USD", To: "BTC"}).
+					Return(dto.QuoteResp{
+						ID:            id,
+						From:          "USD",
+						To:            "BTC",
+						Rate:          decimal.RequireFromString("0.00001444655"),
+						Source:        "fastforex",
+						SpreadPercent: decimal.RequireFromString("0.5"),
+						FixedFee:      decimal.RequireFromString("0.000001"),
+						MinFee:        decimal.Zero,
+						ExpiresAt:     time.Date(2024, 6, 14, 14, 0, 30, 0, time.UTC),
+					}, nil).Once()
+			},
+			expRes: `{"id":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","from":"USD","to":"BTC","rate":"0.00001444655",` +
+				`"source":"fastforex","spreadPercent":"0.5","fixedFee":"0.000001","minFee":"0","expiresAt":"2024-06-14T14:00:30Z"}`,
+			expCode: 201,
+		},
+		{
+			name:     "validation_err",
+			data:     `{"from": "USD"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"Key: 'CreateQuoteReq.To' Error:Field validation for 'To' failed on the 'required' tag"}`,
+			expCode:  400,
+		},
+		{
+			name: "currency_not_available",
+			data: `{"from": "USD", "to": "BTC"}`,
+			mockFunc: func() {
+				s.mockQuoteSvc.On("CreateQuote", ctx, mock.Anything).
+					Return(dto.QuoteResp{}, entity.ErrCurrencyNotAvailable).Once()
+			},
+			expRes:  `{"code":400,"text":"Bad Request","businessCode":1}`,
+			expCode: 400,
+		},
+		{
+			name: "svc_err",
+			data: `{"from": "USD", "to": "BTC"}`,
+			mockFunc: func() {
+				s.mockQuoteSvc.On("CreateQuote", ctx, mock.Anything).
+					Return(dto.QuoteResp{}, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/", s.srv.CreateQuote)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerQuoteSuite) TestExecuteQuote() {
+	ctx := context.Background()
+
+	id := uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2")
+
+	testCases := []struct {
+		name     string
+		id       string
+		data     string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			data: `{"amount": "100.5"}`,
+			mockFunc: func() {
+				s.mockQuoteSvc.On("ExecuteQuote", ctx, dto.ExecuteQuote{ID: id, Amount: decimal.RequireFromString("100.5")}).
+					Return(dto.ExecuteQuoteResp{
+						QuoteID:       id,
+						From:          "USD",
+						To:            "BTC",
+						Rate:          decimal.RequireFromString("0.00001444655"),
+						Amount:        decimal.RequireFromString("100.5"),
+						Result:        decimal.RequireFromString("0.001451878275"),
+						SpreadPercent: decimal.RequireFromString("0.5"),
+						SpreadAmount:  decimal.RequireFromString("0.000007259391375"),
+						Fee:           decimal.RequireFromString("0.000001"),
+						Net:           decimal.RequireFromString("0.001443618883625"),
+						ExecutedAt:    time.Date(2024, 6, 14, 14, 0, 10, 0, time.UTC),
+					}, nil).Once()
+			},
+			expRes: `{"quoteId":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","from":"USD","to":"BTC","rate":"0.00001444655",` +
+				`"amount":"100.5","result":"0.001451878275","spreadPercent":"0.5","spreadAmount":"0.000007259391375",` +
+				`"fee":"0.000001","net":"0.001443618883625","executedAt":"2024-06-14T14:00:10Z"}`,
+			expCode: 200,
+		},
+		{
+			name:     "invalid_id",
+			id:       "123",
+			data:     `{"amount": "1"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"invalid id format"}`,
+			expCode:  400,
+		},
+		{
+			name:     "invalid_amount",
+			id:       id.String(),
+			data:     `{"amount": "0"}`,
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"amount must be a positive decimal"}`,
+			expCode:  400,
+		},
+		{
+			name: "not_found",
+			id:   id.String(),
+			data: `{"amount": "1"}`,
+			mockFunc: func() {
+				s.mockQuoteSvc.On("ExecuteQuote", ctx, mock.Anything).
+					Return(dto.ExecuteQuoteResp{}, entity.ErrEntityNotFound).Once()
+			},
+			expRes:  `{"code":404,"text":"Not Found"}`,
+			expCode: 404,
+		},
+		{
+			name: "expired",
+			id:   id.String(),
+			data: `{"amount": "1"}`,
+			mockFunc: func() {
+				s.mockQuoteSvc.On("ExecuteQuote", ctx, mock.Anything).
+					Return(dto.ExecuteQuoteResp{}, quoteentity.ErrQuoteExpired).Once()
+			},
+			expRes:  `{"code":400,"text":"Bad Request","businessCode":2}`,
+			expCode: 400,
+		},
+		{
+			name: "already_executed",
+			id:   id.String(),
+			data: `{"amount": "1"}`,
+			mockFunc: func() {
+				s.mockQuoteSvc.On("ExecuteQuote", ctx, mock.Anything).
+					Return(dto.ExecuteQuoteResp{}, quoteentity.ErrQuoteAlreadyExecuted).Once()
+			},
+			expRes:  `{"code":400,"text":"Bad Request","businessCode":3}`,
+			expCode: 400,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/:id/execute", s.srv.ExecuteQuote)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("POST", "/"+c.id+"/execute", strings.NewReader(c.data))
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
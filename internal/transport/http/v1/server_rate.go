@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	rateentity "github.com/veleton777/test_work_blum/internal/currency/v1/rate/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
+)
+
+const (
+	// maxCandles limits how many candles one request may span.
+	maxCandles = 5000
+	// maxRates limits how many rates one history request returns.
+	maxRates = 5000
+)
+
+type RateServer struct {
+	rateSvc   RateSvc
+	validator *validator.Validate
+}
+
+//go:generate mockery --name RateSvc
+type RateSvc interface {
+	RatesHistory(ctx context.Context, req dto.RatesHistoryReq) (dto.RatesHistoryResp, error)
+	Candles(ctx context.Context, req dto.CandlesReq) (dto.CandlesResp, error)
+}
+
+func NewRateServer(rateSvc RateSvc) *RateServer {
+	return &RateServer{
+		rateSvc:   rateSvc,
+		validator: validator.New(),
+	}
+}
+
+// History godoc
+//
+//	@Summary		Rates history for currency pair
+//	@Description	Rates history for currency pair in [start, end] time range, at most limit rates are returned.
+//	@Description	When the range has more rates, nextStart is the start of the request for the rest of them
+//	@Tags			rate
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	query		dto.RatesHistoryQuery	true	"RatesHistoryQuery"
+//	@Success		200		{object}  dto.RatesHistoryResp
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v1/rates/history [get]
+func (s *RateServer) History(c *fiber.Ctx) error {
+	var query dto.RatesHistoryQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid query params") //nolint:wrapcheck
+	}
+
+	if err := s.validator.Struct(query); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	// datetime validation above guarantees both values are parsable
+	start, _ := time.Parse(time.RFC3339, query.Start)
+	end, _ := time.Parse(time.RFC3339, query.End)
+
+	if end.Before(start) {
+		return httputil.NewBadRequestErr(c, "end must not be before start") //nolint:wrapcheck
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = maxRates
+	}
+
+	res, err := s.rateSvc.RatesHistory(c.UserContext(), dto.RatesHistoryReq{
+		From:  query.From,
+		To:    query.To,
+		Start: start,
+		End:   end,
+		Limit: limit,
+	})
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
+
+// Candles godoc
+//
+//	@Summary		OHLC candles for currency pair
+//	@Description	Open, high, low and close rates of currency pair per 1m, 1h or 1d interval in [start, end] time range
+//	@Tags			rate
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	query		dto.CandlesQuery	true	"CandlesQuery"
+//	@Success		200		{object}  dto.CandlesResp
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v1/rates/candles [get]
+func (s *RateServer) Candles(c *fiber.Ctx) error {
+	var query dto.CandlesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid query params") //nolint:wrapcheck
+	}
+
+	if err := s.validator.Struct(query); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	// datetime validation above guarantees both values are parsable
+	start, _ := time.Parse(time.RFC3339, query.Start)
+	end, _ := time.Parse(time.RFC3339, query.End)
+
+	if end.Before(start) {
+		return httputil.NewBadRequestErr(c, "end must not be before start") //nolint:wrapcheck
+	}
+
+	if end.Sub(start) > maxCandles*rateentity.CandleInterval(query.Interval).Duration() {
+		return httputil.NewBadRequestErr(c, "time range is too large for the interval") //nolint:wrapcheck
+	}
+
+	res, err := s.rateSvc.Candles(c.UserContext(), dto.CandlesReq{
+		From:     query.From,
+		To:       query.To,
+		Interval: query.Interval,
+		Start:    start,
+		End:      end,
+	})
+	if err != nil {
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	return c.JSON(res) //nolint:wrapcheck
+}
@@ -0,0 +1,263 @@
+//go:build integration
+
+package v1_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	v1 "github.com/veleton777/test_work_blum/internal/transport/http/v1"
+	"github.com/veleton777/test_work_blum/internal/transport/http/v1/mocks"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type ServerRateSuite struct {
+	suite.Suite
+
+	srv         *v1.RateServer
+	mockRateSvc *mocks.RateSvc
+}
+
+func TestRateSuite(t *testing.T) {
+	suite.Run(t, new(ServerRateSuite))
+}
+
+func (s *ServerRateSuite) SetupSuite() {
+	s.mockRateSvc = mocks.NewRateSvc(s.T())
+
+	s.srv = v1.NewRateServer(s.mockRateSvc)
+}
+
+func (s *ServerRateSuite) TestHistory() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		params   string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name:   "success",
+			params: "?from=USD&to=BTC&start=2024-06-14T00:00:00Z&end=2024-06-15T00:00:00Z",
+			mockFunc: func() {
+				s.mockRateSvc.On("RatesHistory", ctx, dto.RatesHistoryReq{
+					From:  "USD",
+					To:    "BTC",
+					Start: start,
+					End:   end,
+					Limit: 5000,
+				}).Return(dto.RatesHistoryResp{
+					From: "USD",
+					To:   "BTC",
+					Rates: []dto.Rate{
+						{
+							Rate:      decimal.RequireFromString("0.00001444655"),
+							Source:    "fastforex",
+							CycleID:   uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+							CreatedAt: start.Add(14 * time.Hour),
+						},
+					},
+				}, nil).Once()
+			},
+			expRes: `{"from":"USD","to":"BTC","rates":[{"rate":"0.00001444655","source":"fastforex",` +
+				`"cycleId":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","createdAt":"2024-06-14T14:00:00Z"}]}`,
+			expCode: 200,
+		},
+		{
+			name:   "next_page",
+			params: "?from=USD&to=BTC&start=2024-06-14T00:00:00Z&end=2024-06-15T00:00:00Z&limit=1",
+			mockFunc: func() {
+				nextStart := start.Add(15 * time.Hour)
+
+				s.mockRateSvc.On("RatesHistory", ctx, dto.RatesHistoryReq{
+					From:  "USD",
+					To:    "BTC",
+					Start: start,
+					End:   end,
+					Limit: 1,
+				}).Return(dto.RatesHistoryResp{
+					From: "USD",
+					To:   "BTC",
+					Rates: []dto.Rate{
+						{
+							Rate:      decimal.RequireFromString("0.00001444655"),
+							Source:    "fastforex",
+							CycleID:   uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+							CreatedAt: start.Add(14 * time.Hour),
+						},
+					},
+					NextStart: &nextStart,
+				}, nil).Once()
+			},
+			expRes: `{"from":"USD","to":"BTC","rates":[{"rate":"0.00001444655","source":"fastforex",` +
+				`"cycleId":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","createdAt":"2024-06-14T14:00:00Z"}],` +
+				`"nextStart":"2024-06-14T15:00:00Z"}`,
+			expCode: 200,
+		},
+		{
+			name:     "limit_too_large",
+			params:   "?from=USD&to=BTC&start=2024-06-14T00:00:00Z&end=2024-06-15T00:00:00Z&limit=5001",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"Key: 'RatesHistoryQuery.Limit' Error:Field validation for 'Limit' failed on the 'max' tag"}`,
+			expCode:  400,
+		},
+		{
+			name:     "validation_err",
+			params:   "?from=USD&to=BTC&start=2024-06-14&end=2024-06-15T00:00:00Z",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"Key: 'RatesHistoryQuery.Start' Error:Field validation for 'Start' failed on the 'datetime' tag"}`,
+			expCode:  400,
+		},
+		{
+			name:     "end_before_start",
+			params:   "?from=USD&to=BTC&start=2024-06-15T00:00:00Z&end=2024-06-14T00:00:00Z",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"end must not be before start"}`,
+			expCode:  400,
+		},
+		{
+			name:   "svc_err",
+			params: "?from=USD&to=BTC&start=2024-06-14T00:00:00Z&end=2024-06-15T00:00:00Z",
+			mockFunc: func() {
+				s.mockRateSvc.On("RatesHistory", ctx, mock.Anything).
+					Return(dto.RatesHistoryResp{}, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", s.srv.History)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/"+c.params, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
+
+func (s *ServerRateSuite) TestCandles() {
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		params   string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name:   "success",
+			params: "?from=USD&to=BTC&interval=1h&start=2024-06-14T00:00:00Z&end=2024-06-15T00:00:00Z",
+			mockFunc: func() {
+				s.mockRateSvc.On("Candles", ctx, dto.CandlesReq{
+					From:     "USD",
+					To:       "BTC",
+					Interval: "1h",
+					Start:    start,
+					End:      end,
+				}).Return(dto.CandlesResp{
+					From:     "USD",
+					To:       "BTC",
+					Interval: "1h",
+					Candles: []dto.Candle{
+						{
+							Start: start.Add(14 * time.Hour),
+							Open:  decimal.RequireFromString("0.00001444655"),
+							High:  decimal.RequireFromString("0.00001445"),
+							Low:   decimal.RequireFromString("0.0000144"),
+							Close: decimal.RequireFromString("0.00001444"),
+						},
+					},
+				}, nil).Once()
+			},
+			expRes: `{"from":"USD","to":"BTC","interval":"1h","candles":[{"start":"2024-06-14T14:00:00Z",` +
+				`"open":"0.00001444655","high":"0.00001445","low":"0.0000144","close":"0.00001444"}]}`,
+			expCode: 200,
+		},
+		{
+			name:     "invalid_interval",
+			params:   "?from=USD&to=BTC&interval=5m&start=2024-06-14T00:00:00Z&end=2024-06-15T00:00:00Z",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"Key: 'CandlesQuery.Interval' Error:Field validation for 'Interval' failed on the 'oneof' tag"}`,
+			expCode:  400,
+		},
+		{
+			name:     "end_before_start",
+			params:   "?from=USD&to=BTC&interval=1h&start=2024-06-15T00:00:00Z&end=2024-06-14T00:00:00Z",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"end must not be before start"}`,
+			expCode:  400,
+		},
+		{
+			name:     "range_too_large",
+			params:   "?from=USD&to=BTC&interval=1m&start=2024-06-01T00:00:00Z&end=2024-06-15T00:00:00Z",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"time range is too large for the interval"}`,
+			expCode:  400,
+		},
+		{
+			name:   "svc_err",
+			params: "?from=USD&to=BTC&interval=1d&start=2024-06-14T00:00:00Z&end=2024-06-15T00:00:00Z",
+			mockFunc: func() {
+				s.mockRateSvc.On("Candles", ctx, mock.Anything).
+					Return(dto.CandlesResp{}, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", s.srv.Candles)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/"+c.params, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	dto "github.com/veleton777/test_work_blum/internal/dto"
+)
+
+// CurrencySvc is an autogenerated mock type for the CurrencySvc type
+type CurrencySvc struct {
+	mock.Mock
+}
+
+// Convert provides a mock function with given fields: ctx, conversion
+func (_m *CurrencySvc) Convert(ctx context.Context, conversion dto.Conversion) (dto.ConversionResult, error) {
+	ret := _m.Called(ctx, conversion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Convert")
+	}
+
+	var r0 dto.ConversionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.Conversion) (dto.ConversionResult, error)); ok {
+		return rf(ctx, conversion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.Conversion) dto.ConversionResult); ok {
+		r0 = rf(ctx, conversion)
+	} else {
+		r0 = ret.Get(0).(dto.ConversionResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.Conversion) error); ok {
+		r1 = rf(ctx, conversion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCurrencySvc creates a new instance of CurrencySvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCurrencySvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CurrencySvc {
+	mock := &CurrencySvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
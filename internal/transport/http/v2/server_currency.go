@@ -0,0 +1,113 @@
+package v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	"github.com/veleton777/test_work_blum/internal/pkg/httputil"
+)
+
+const codeCurrencyNotAllowedForConvert = 1
+
+type CurrencyServer struct {
+	currencySvc CurrencySvc
+	validator   *validator.Validate
+}
+
+//go:generate mockery --name CurrencySvc
+type CurrencySvc interface {
+	Convert(ctx context.Context, conversion dto.Conversion) (dto.ConversionResult, error)
+}
+
+func NewCurrencyServer(currencySvc CurrencySvc) *CurrencyServer {
+	return &CurrencyServer{
+		currencySvc: currencySvc,
+		validator:   validator.New(),
+	}
+}
+
+// Convert godoc
+//
+//	@Summary		Convert currencies with decimal precision
+//	@Description	Convert currencies, amount and result are passed as decimal strings.
+//	@Description	With `at` set the rate valid at that moment is used
+//	@Tags			currency
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	query		dto.ConvertCurrencyV2Req	true	"ConvertCurrencyV2Req"
+//	@Success		200		{object}  dto.ConvertCurrencyV2Resp
+//	@Failure		400		{object}  httputil.HTTPError
+//	@Router			/v2/currencies/convert [get]
+func (s *CurrencyServer) Convert(c *fiber.Ctx) error {
+	var req dto.ConvertCurrencyV2Req
+	if err := c.QueryParser(&req); err != nil {
+		return httputil.NewBadRequestErr(c, "invalid query params") //nolint:wrapcheck
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return httputil.NewBadRequestErr(c, err.Error()) //nolint:wrapcheck
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return httputil.NewBadRequestErr(c, "amount must be a positive decimal") //nolint:wrapcheck
+	}
+
+	at, ok := parseAt(req.At)
+	if !ok {
+		return httputil.NewBadRequestErr(c, "at must not be in the future") //nolint:wrapcheck
+	}
+
+	res, err := s.currencySvc.Convert(c.UserContext(), dto.Conversion{
+		From:   req.From,
+		To:     req.To,
+		Amount: amount,
+		At:     at,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrCurrencyNotAvailable) {
+			return httputil.NewBusinessErr(c, codeCurrencyNotAllowedForConvert) //nolint:wrapcheck
+		}
+
+		return httputil.NewInternalServerErr(c) //nolint:wrapcheck
+	}
+
+	resp := dto.ConvertCurrencyV2Resp{
+		Rate:          res.Rate,
+		Amount:        res.Amount,
+		Result:        res.Result,
+		AsOf:          res.AsOf,
+		Source:        res.Source,
+		CycleID:       res.CycleID,
+		IsSynthetic:   res.IsSynthetic,
+		Via:           res.Via,
+		IsStale:       res.IsStale,
+		SpreadPercent: res.SpreadPercent,
+		SpreadAmount:  res.SpreadAmount,
+		Fee:           res.Fee,
+		Net:           res.Net,
+	}
+
+	return c.JSON(resp) //nolint:wrapcheck
+}
+
+// parseAt returns the moment to convert at, nil means the current rate.
+// The datetime validation guarantees the value is parsable.
+func parseAt(at string) (*time.Time, bool) {
+	if at == "" {
+		return nil, true
+	}
+
+	t, _ := time.Parse(time.RFC3339, at)
+	if t.After(time.Now()) {
+		return nil, false
+	}
+
+	return &t, true
+}
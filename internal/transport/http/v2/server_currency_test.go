@@ -0,0 +1,191 @@
+//go:build integration
+
+package v2_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/veleton777/test_work_blum/internal/currency/v1/currency/entity"
+	"github.com/veleton777/test_work_blum/internal/dto"
+	v2 "github.com/veleton777/test_work_blum/internal/transport/http/v2"
+	"github.com/veleton777/test_work_blum/internal/transport/http/v2/mocks"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type ServerCurrencySuite struct {
+	suite.Suite
+
+	srv             *v2.CurrencyServer
+	mockCurrencySvc *mocks.CurrencySvc
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(ServerCurrencySuite))
+}
+
+func (s *ServerCurrencySuite) SetupSuite() {
+	s.mockCurrencySvc = mocks.NewCurrencySvc(s.T())
+
+	s.srv = v2.NewCurrencyServer(s.mockCurrencySvc)
+}
+
+func (s *ServerCurrencySuite) TestConvert() {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		params   string
+		mockFunc func()
+		expRes   string
+		expCode  int
+	}{
+		{
+			name:   "success",
+			params: "?from=USD&to=BTC&amount=0.1",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("Convert", ctx, dto.Conversion{
+					From:   "USD",
+					To:     "BTC",
+					Amount: decimal.RequireFromString("0.1"),
+				}).Return(dto.ConversionResult{
+					Rate:    decimal.RequireFromString("0.00001444655"),
+					Amount:  decimal.RequireFromString("0.1"),
+					Result:  decimal.RequireFromString("0.000001444655"),
+					AsOf:    time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC),
+					Source:  "fastforex",
+					CycleID: uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+
+					SpreadPercent: decimal.NewFromInt(1),
+					SpreadAmount:  decimal.RequireFromString("0.00000001444655"),
+					Fee:           decimal.RequireFromString("0.0000001"),
+					Net:           decimal.RequireFromString("0.00000133020845"),
+				}, nil).Once()
+			},
+			expRes: `{"rate":"0.00001444655","amount":"0.1","result":"0.000001444655","asOf":"2024-06-14T14:00:00Z",` +
+				`"source":"fastforex","cycleId":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","isSynthetic":false,"isStale":false,` +
+				`"spreadPercent":"1","spreadAmount":"0.00000001444655","fee":"0.0000001","net":"0.00000133020845"}`,
+			expCode: 200,
+		},
+		{
+			name:   "success_small_amount",
+			params: "?from=BTC&to=USD&amount=0.00000001",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("Convert", ctx, dto.Conversion{
+					From:   "BTC",
+					To:     "USD",
+					Amount: decimal.RequireFromString("0.00000001"),
+				}).Return(dto.ConversionResult{
+					Rate:    decimal.RequireFromString("69220.55"),
+					Amount:  decimal.RequireFromString("0.00000001"),
+					Result:  decimal.RequireFromString("0.0006922055"),
+					AsOf:    time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC),
+					Source:  "fastforex",
+					CycleID: uuid.MustParse("5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2"),
+					IsStale: true,
+					Net:     decimal.RequireFromString("0.0006922055"),
+				}, nil).Once()
+			},
+			expRes: `{"rate":"69220.55","amount":"0.00000001","result":"0.0006922055","asOf":"2024-06-14T14:00:00Z",` +
+				`"source":"fastforex","cycleId":"5f0c7f3e-2c1d-4b7a-9a57-0e7c35d1a8b2","isSynthetic":false,"isStale":true,` +
+				`"spreadPercent":"0","spreadAmount":"0","fee":"0","net":"0.0006922055"}`,
+			expCode: 200,
+		},
+		{
+			name:     "validation_err",
+			params:   "?from=USD&to=BTC&amount=abc",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"Key: 'ConvertCurrencyV2Req.Amount' Error:Field validation for 'Amount' failed on the 'numeric' tag"}`,
+			expCode:  400,
+		},
+		{
+			name:   "success_at",
+			params: "?from=USD&to=BTC&amount=2&at=2024-06-14T14:00:00Z",
+			mockFunc: func() {
+				at := time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC)
+
+				s.mockCurrencySvc.On("Convert", ctx, dto.Conversion{
+					From:   "USD",
+					To:     "BTC",
+					Amount: decimal.NewFromInt(2),
+					At:     &at,
+				}).Return(dto.ConversionResult{
+					Rate:   decimal.RequireFromString("0.5"),
+					Amount: decimal.NewFromInt(2),
+					Result: decimal.NewFromInt(1),
+					AsOf:   at,
+					Source: "fastforex",
+					Net:    decimal.NewFromInt(1),
+				}, nil).Once()
+			},
+			expRes: `{"rate":"0.5","amount":"2","result":"1","asOf":"2024-06-14T14:00:00Z",` +
+				`"source":"fastforex","cycleId":"00000000-0000-0000-0000-000000000000","isSynthetic":false,"isStale":false,` +
+				`"spreadPercent":"0","spreadAmount":"0","fee":"0","net":"1"}`,
+			expCode: 200,
+		},
+		{
+			name:     "at_in_future",
+			params:   "?from=USD&to=BTC&amount=1&at=2999-01-01T00:00:00Z",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"at must not be in the future"}`,
+			expCode:  400,
+		},
+		{
+			name:     "negative_amount",
+			params:   "?from=USD&to=BTC&amount=-1",
+			mockFunc: func() {},
+			expRes:   `{"code":400,"text":"amount must be a positive decimal"}`,
+			expCode:  400,
+		},
+		{
+			name:   "currency_not_available",
+			params: "?from=USD&to=BTC&amount=1",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("Convert", ctx, mock.Anything).
+					Return(dto.ConversionResult{}, entity.ErrCurrencyNotAvailable).Once()
+			},
+			expRes:  `{"code":400,"text":"Bad Request","businessCode":1}`,
+			expCode: 400,
+		},
+		{
+			name:   "svc_err",
+			params: "?from=USD&to=BTC&amount=1",
+			mockFunc: func() {
+				s.mockCurrencySvc.On("Convert", ctx, mock.Anything).
+					Return(dto.ConversionResult{}, errors.New("")).Once()
+			},
+			expRes:  `{"code":500,"text":"Internal Server error"}`,
+			expCode: 500,
+		},
+	}
+
+	for _, c := range testCases {
+		s.T().Run(c.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", s.srv.Convert)
+
+			c.mockFunc()
+
+			req := httptest.NewRequest("GET", "/"+c.params, nil)
+
+			resp, err := app.Test(req, 10)
+			s.Require().NoError(err)
+
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+
+			assert.Equal(s.T(), c.expCode, resp.StatusCode)
+			assert.Equal(s.T(), c.expRes, string(respBody))
+		})
+	}
+}
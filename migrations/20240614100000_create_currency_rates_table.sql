@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE currency_rates
+(
+    id         BIGSERIAL PRIMARY KEY,
+    code_from  VARCHAR     NOT NULL,
+    code_to    VARCHAR     NOT NULL,
+    rate       NUMERIC     NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX currency_rates_pair_created_at_idx ON currency_rates (code_from, code_to, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS currency_rates;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE currency_rates
+    ADD COLUMN provider VARCHAR NOT NULL DEFAULT '',
+    ADD COLUMN cycle_id UUID;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE currency_rates
+    DROP COLUMN provider,
+    DROP COLUMN cycle_id;
+-- +goose StatementEnd
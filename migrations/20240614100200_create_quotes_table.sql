@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE quotes
+(
+    id          UUID PRIMARY KEY,
+    code_from   VARCHAR     NOT NULL,
+    code_to     VARCHAR     NOT NULL,
+    rate        NUMERIC     NOT NULL,
+    source      VARCHAR     NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    executed_at TIMESTAMPTZ
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS quotes;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pair_pricing
+(
+    id             UUID PRIMARY KEY,
+    code_from      VARCHAR,
+    code_to        VARCHAR,
+    type_from      INT,
+    type_to        INT,
+    spread_percent NUMERIC NOT NULL DEFAULT 0,
+    fixed_fee      NUMERIC NOT NULL DEFAULT 0,
+    min_fee        NUMERIC NOT NULL DEFAULT 0,
+    CHECK (
+        (code_from IS NOT NULL AND code_to IS NOT NULL AND type_from IS NULL AND type_to IS NULL) OR
+        (code_from IS NULL AND code_to IS NULL AND type_from IS NOT NULL AND type_to IS NOT NULL)
+    )
+);
+
+CREATE UNIQUE INDEX pair_pricing_codes_idx ON pair_pricing (code_from, code_to);
+CREATE UNIQUE INDEX pair_pricing_types_idx ON pair_pricing (type_from, type_to);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pair_pricing;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE rate_candles
+(
+    code_from    VARCHAR     NOT NULL,
+    code_to      VARCHAR     NOT NULL,
+    period       VARCHAR     NOT NULL,
+    bucket_start TIMESTAMPTZ NOT NULL,
+    open         NUMERIC     NOT NULL,
+    high         NUMERIC     NOT NULL,
+    low          NUMERIC     NOT NULL,
+    close        NUMERIC     NOT NULL,
+    open_at      TIMESTAMPTZ NOT NULL,
+    close_at     TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (code_from, code_to, period, bucket_start)
+);
+
+INSERT INTO rate_candles (code_from, code_to, period, bucket_start, open, high, low, close, open_at, close_at)
+SELECT r.code_from,
+       r.code_to,
+       i.period,
+       date_trunc(i.unit, r.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket_start,
+       (array_agg(r.rate ORDER BY r.created_at))[1],
+       max(r.rate),
+       min(r.rate),
+       (array_agg(r.rate ORDER BY r.created_at DESC))[1],
+       min(r.created_at),
+       max(r.created_at)
+FROM currency_rates r
+         CROSS JOIN (VALUES ('1m', 'minute'), ('1h', 'hour'), ('1d', 'day')) AS i (period, unit)
+GROUP BY r.code_from, r.code_to, i.period, bucket_start;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rate_candles;
+-- +goose StatementEnd
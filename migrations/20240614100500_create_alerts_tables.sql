@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE alerts
+(
+    id                UUID PRIMARY KEY,
+    code_from         VARCHAR     NOT NULL,
+    code_to           VARCHAR     NOT NULL,
+    kind              VARCHAR     NOT NULL,
+    threshold         NUMERIC     NOT NULL DEFAULT 0,
+    change_percent    NUMERIC     NOT NULL DEFAULT 0,
+    window_seconds    BIGINT      NOT NULL DEFAULT 0,
+    webhook_url       VARCHAR     NOT NULL,
+    secret            VARCHAR     NOT NULL,
+    last_triggered_at TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX alerts_pair_idx ON alerts (code_from, code_to);
+
+CREATE TABLE webhook_deliveries
+(
+    id          UUID PRIMARY KEY,
+    alert_id    UUID        NOT NULL REFERENCES alerts (id) ON DELETE CASCADE,
+    attempt     INT         NOT NULL,
+    status_code INT         NOT NULL DEFAULT 0,
+    error       VARCHAR     NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX webhook_deliveries_alert_id_idx ON webhook_deliveries (alert_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS alerts;
+-- +goose StatementEnd
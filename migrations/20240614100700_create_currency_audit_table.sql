@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE currency_audit
+(
+    id          UUID PRIMARY KEY,
+    currency_id UUID        NOT NULL,
+    action      VARCHAR     NOT NULL,
+    actor       VARCHAR     NOT NULL,
+    request_id  VARCHAR     NOT NULL DEFAULT '',
+    before      JSONB,
+    after       JSONB,
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX currency_audit_currency_id_idx ON currency_audit (currency_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS currency_audit;
+-- +goose StatementEnd
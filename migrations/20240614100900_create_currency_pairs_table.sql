@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE currency_pairs
+(
+    id              UUID PRIMARY KEY,
+    code_from       VARCHAR     NOT NULL,
+    code_to         VARCHAR     NOT NULL,
+    enabled         BOOLEAN     NOT NULL DEFAULT TRUE,
+    refresh_seconds BIGINT      NOT NULL DEFAULT 0,
+    provider        VARCHAR     NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX currency_pairs_codes_idx ON currency_pairs (code_from, code_to);
+
+-- register the fiat and crypto pairs which were fetched before the registry
+INSERT INTO currency_pairs (id, code_from, code_to, created_at)
+SELECT gen_random_uuid(), f.code, c.code, now()
+FROM currencies f
+         JOIN currencies c ON f.type = 2 AND c.type = 1
+UNION ALL
+SELECT gen_random_uuid(), c.code, f.code, now()
+FROM currencies f
+         JOIN currencies c ON f.type = 2 AND c.type = 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS currency_pairs;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE provider_usage
+(
+    provider     VARCHAR     NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    calls        BIGINT      NOT NULL DEFAULT 0,
+    updated_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (provider, period_start)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS provider_usage;
+-- +goose StatementEnd
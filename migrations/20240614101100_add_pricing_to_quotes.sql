@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quotes
+    ADD COLUMN spread_percent NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN fixed_fee      NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN min_fee        NUMERIC NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE quotes
+    DROP COLUMN spread_percent,
+    DROP COLUMN fixed_fee,
+    DROP COLUMN min_fee;
+-- +goose StatementEnd
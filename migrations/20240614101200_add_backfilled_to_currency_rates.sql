@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE currency_rates
+    ADD COLUMN is_backfilled BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE currency_rates
+    DROP COLUMN is_backfilled;
+-- +goose StatementEnd